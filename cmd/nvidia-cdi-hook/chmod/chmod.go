@@ -20,13 +20,12 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
-	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 )
@@ -112,6 +111,7 @@ func (m command) run(c *cli.Context, cfg *config) error {
 	if err != nil {
 		return fmt.Errorf("failed to load container state: %v", err)
 	}
+	logger.AddContainerFields(m.logger, s.ID, s.Bundle)
 
 	containerRoot, err := s.GetContainerRoot()
 	if err != nil {
@@ -121,14 +121,22 @@ func (m command) run(c *cli.Context, cfg *config) error {
 		return fmt.Errorf("empty container root detected")
 	}
 
-	paths := m.getPaths(containerRoot, cfg.paths.Value(), cfg.mode)
+	return m.chmodPaths(containerroot.Root(containerRoot), cfg.paths.Value(), cfg.mode)
+}
+
+// chmodPaths applies the desired mode to the specified paths in the root.
+// Paths (including symlinks) are resolved in the container root and the mode
+// is applied through a file handle so that paths outside the root can never be
+// modified.
+func (m command) chmodPaths(root containerroot.Root, paths []string, desiredMode fs.FileMode) error {
 	if len(paths) == 0 {
 		m.logger.Debugf("No paths specified; exiting")
 		return nil
 	}
 
+	var err error
 	for _, path := range paths {
-		err = os.Chmod(path, cfg.mode)
+		err = m.chmodPath(root, path, desiredMode)
 		// in some cases this is not an issue (e.g. whole /dev mounted), see #143
 		if errors.Is(err, fs.ErrPermission) {
 			m.logger.Debugf("Ignoring permission error with chmod: %v", err)
@@ -139,22 +147,25 @@ func (m command) run(c *cli.Context, cfg *config) error {
 	return err
 }
 
-// getPaths updates the specified paths relative to the root.
-func (m command) getPaths(root string, paths []string, desiredMode fs.FileMode) []string {
-	var pathsInRoot []string
-	for _, f := range paths {
-		path := filepath.Join(root, f)
-		stat, err := os.Stat(path)
-		if err != nil {
-			m.logger.Debugf("Skipping path %q: %v", path, err)
-			continue
-		}
-		if (stat.Mode()&(fs.ModePerm|fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky))^desiredMode == 0 {
-			m.logger.Debugf("Skipping path %q: already desired mode", path)
-			continue
-		}
-		pathsInRoot = append(pathsInRoot, path)
+// chmodPath applies the desired mode to the specified path in the root.
+// Paths that do not exist or that already have the desired mode are skipped.
+func (m command) chmodPath(root containerroot.Root, path string, desiredMode fs.FileMode) error {
+	handle, err := root.Open(path)
+	if err != nil {
+		m.logger.Debugf("Skipping path %q: %v", path, err)
+		return nil
+	}
+	defer handle.Close()
+
+	stat, err := handle.Stat()
+	if err != nil {
+		m.logger.Debugf("Skipping path %q: %v", path, err)
+		return nil
+	}
+	if (stat.Mode()&(fs.ModePerm|fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky))^desiredMode == 0 {
+		m.logger.Debugf("Skipping path %q: already desired mode", path)
+		return nil
 	}
 
-	return pathsInRoot
+	return root.Chmod(handle, desiredMode)
 }
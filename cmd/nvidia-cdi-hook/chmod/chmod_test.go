@@ -0,0 +1,130 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package chmod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+)
+
+func TestChmodPaths(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		contents      map[string]string
+		paths         []string
+		expectedModes map[string]os.FileMode
+		expectedError bool
+	}{
+		{
+			description: "mode is applied to path in container",
+			contents: map[string]string{
+				"/dev/dri/card0": "",
+			},
+			paths: []string{"/dev/dri/card0"},
+			expectedModes: map[string]os.FileMode{
+				"{{ .containerRoot }}/dev/dri/card0": 0755,
+			},
+		},
+		{
+			description: "absolute symlink to host is resolved in container",
+			contents: map[string]string{
+				"/dev/dri":               "symlink={{ .hostRoot }}",
+				"/{{ .hostRoot }}/card0": "",
+				"{{ .hostRoot }}/card0":  "",
+			},
+			paths: []string{"/dev/dri/card0"},
+			expectedModes: map[string]os.FileMode{
+				"{{ .containerRoot }}/{{ .hostRoot }}/card0": 0755,
+				"{{ .hostRoot }}/card0":                      0600,
+			},
+		},
+		{
+			description: "relative symlink to host is resolved in container",
+			contents: map[string]string{
+				"/dev/dri/card0":        "symlink=../../../../../../../../../{{ .hostRoot }}/card0",
+				"{{ .hostRoot }}/card0": "",
+			},
+			paths: []string{"/dev/dri/card0"},
+			expectedModes: map[string]os.FileMode{
+				"{{ .hostRoot }}/card0": 0600,
+			},
+		},
+		{
+			description: "final symlink to host is resolved in container",
+			contents: map[string]string{
+				"/dev/dri/card0":        "symlink={{ .hostRoot }}/card0",
+				"{{ .hostRoot }}/card0": "",
+			},
+			paths: []string{"/dev/dri/card0"},
+			expectedModes: map[string]os.FileMode{
+				"{{ .hostRoot }}/card0": 0600,
+			},
+		},
+		{
+			description: "symlink loop is skipped",
+			contents: map[string]string{
+				"/dev/dri/card0": "symlink=/dev/dri/card1",
+				"/dev/dri/card1": "symlink=/dev/dri/card0",
+			},
+			paths: []string{"/dev/dri/card0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			hostRoot := filepath.Join(tmpDir, "host-root")
+			containerRoot := filepath.Join(tmpDir, "container-root")
+			replacer := strings.NewReplacer("{{ .hostRoot }}", hostRoot, "{{ .containerRoot }}", containerRoot)
+
+			for name, contents := range tc.contents {
+				target := replacer.Replace(name)
+				if !strings.HasPrefix(name, "{{ .hostRoot }}") {
+					target = filepath.Join(containerRoot, target)
+				}
+				require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+
+				if strings.HasPrefix(contents, "symlink=") {
+					require.NoError(t, os.Symlink(replacer.Replace(strings.TrimPrefix(contents, "symlink=")), target))
+					continue
+				}
+				require.NoError(t, os.WriteFile(target, []byte(contents), 0600))
+			}
+
+			c := command{
+				logger: logger,
+			}
+			err := c.chmodPaths(containerroot.Root(containerRoot), tc.paths, 0755)
+			require.NoError(t, err)
+
+			for path, expectedMode := range tc.expectedModes {
+				info, err := os.Stat(replacer.Replace(path))
+				require.NoError(t, err)
+				require.Equal(t, expectedMode, info.Mode().Perm(), path)
+			}
+		})
+	}
+}
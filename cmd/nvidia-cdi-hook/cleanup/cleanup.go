@@ -0,0 +1,91 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cleanup
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerstate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type config struct {
+	stateDir      string
+	containerSpec string
+}
+
+// NewCommand constructs a cleanup command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the cleanup command
+func (m command) build() *cli.Command {
+	cfg := config{}
+
+	// Create the 'cleanup' command
+	c := cli.Command{
+		Name:  "cleanup",
+		Usage: "Remove the host artifacts that were tracked for a container. This is intended to be run as a poststop hook.",
+		Action: func(c *cli.Context) error {
+			return m.run(c, &cfg)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "state-dir",
+			Usage:       "Specify the directory in which per-container state is tracked",
+			Value:       containerstate.DefaultRoot,
+			Destination: &cfg.stateDir,
+		},
+		&cli.StringFlag{
+			Name:        "container-spec",
+			Usage:       "Specify the path to the OCI container state. If empty or '-' the state will be read from STDIN",
+			Destination: &cfg.containerSpec,
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(c *cli.Context, cfg *config) error {
+	s, err := oci.LoadContainerState(cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load container state: %v", err)
+	}
+	logger.AddContainerFields(m.logger, s.ID, s.Bundle)
+	if s.ID == "" {
+		return fmt.Errorf("empty container ID detected")
+	}
+
+	m.logger.Debugf("Cleaning up state for container %v", s.ID)
+	if err := containerstate.New(cfg.stateDir).Cleanup(s.ID); err != nil {
+		return fmt.Errorf("failed to clean up container %v: %w", s.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerstate"
+)
+
+func TestCleanup(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	stateDir := t.TempDir()
+	store := containerstate.New(stateDir)
+	artifactsDir, err := store.Dir("ctr")
+	require.NoError(t, err)
+	artifact := filepath.Join(artifactsDir, "ld.so.cache")
+	require.NoError(t, os.WriteFile(artifact, nil, 0600))
+	require.NoError(t, store.Track("ctr", artifact))
+
+	testCases := []struct {
+		description   string
+		state         string
+		expectedError string
+	}{
+		{
+			description:   "empty container ID is rejected",
+			state:         `{"ociVersion": "1.0.2", "id": "", "status": "stopped", "bundle": "/bundle"}`,
+			expectedError: "empty container ID detected",
+		},
+		{
+			description: "container without state is ignored",
+			state:       `{"ociVersion": "1.0.2", "id": "other", "status": "stopped", "bundle": "/bundle"}`,
+		},
+		{
+			description: "tracked artifacts are removed",
+			state:       `{"ociVersion": "1.0.2", "id": "ctr", "status": "stopped", "bundle": "/bundle"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			containerSpec := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(containerSpec, []byte(tc.state), 0600))
+
+			m := command{logger: logger}
+			err := m.run(nil, &config{stateDir: stateDir, containerSpec: containerSpec})
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	require.NoFileExists(t, artifact)
+	require.NoDirExists(t, filepath.Join(stateDir, "ctr"))
+}
@@ -23,11 +23,11 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/moby/sys/symlink"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/sys/unix"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
-	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/symlinks"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 )
 
@@ -83,6 +83,7 @@ func (m command) run(c *cli.Context, cfg *config) error {
 	if err != nil {
 		return fmt.Errorf("failed to load container state: %v", err)
 	}
+	logger.AddContainerFields(m.logger, s.ID, s.Bundle)
 
 	containerRoot, err := s.GetContainerRoot()
 	if err != nil {
@@ -122,32 +123,29 @@ func (m command) run(c *cli.Context, cfg *config) error {
 // Note that if the link path resolves to an absolute path oudside of the
 // specified root, this is treated as an absolute path in this root.
 func (m command) createLink(containerRoot string, targetPath string, link string) error {
-	linkPath := filepath.Join(containerRoot, link)
-
-	exists, err := linkExists(targetPath, linkPath)
-	if err != nil {
-		return fmt.Errorf("failed to check if link exists: %w", err)
-	}
-	if exists {
-		m.logger.Debugf("Link %s already exists", linkPath)
-		return nil
-	}
+	root := containerroot.Root(containerRoot)
 
 	// We resolve the parent of the symlink that we're creating in the container root.
 	// If we resolve the full link path, an existing link at the location itself
 	// is also resolved here and we are unable to force create the link.
-	resolvedLinkParent, err := symlink.FollowSymlinkInScope(filepath.Dir(linkPath), containerRoot)
+	linkParent, err := root.MkdirAll(filepath.Dir(link), 0755)
 	if err != nil {
-		return fmt.Errorf("failed to follow path for link %v relative to %v: %w", link, containerRoot, err)
+		return fmt.Errorf("failed to create parent directory for link %v relative to %v: %w", link, containerRoot, err)
 	}
-	resolvedLinkPath := filepath.Join(resolvedLinkParent, filepath.Base(linkPath))
+	defer linkParent.Close()
+	linkName := filepath.Base(link)
 
-	m.logger.Infof("Symlinking %v to %v", resolvedLinkPath, targetPath)
-	err = os.MkdirAll(filepath.Dir(resolvedLinkPath), 0755)
+	exists, err := linkExists(linkParent, linkName, targetPath)
 	if err != nil {
-		return fmt.Errorf("failed to create directory: %v", err)
+		return fmt.Errorf("failed to check if link exists: %w", err)
+	}
+	if exists {
+		m.logger.Debugf("Link %s already exists", link)
+		return nil
 	}
-	err = symlinks.ForceCreate(targetPath, resolvedLinkPath)
+
+	m.logger.Infof("Symlinking %v to %v", filepath.Join(linkParent.Name(), linkName), targetPath)
+	err = forceCreateLink(linkParent, linkName, targetPath)
 	if err != nil {
 		return fmt.Errorf("failed to create symlink: %v", err)
 	}
@@ -155,18 +153,55 @@ func (m command) createLink(containerRoot string, targetPath string, link string
 	return nil
 }
 
-// linkExists checks whether the specified link exists.
+// linkExists checks whether the specified link exists in the directory.
 // A link exists if the path exists, is a symlink, and points to the specified target.
-func linkExists(target string, link string) (bool, error) {
-	currentTarget, err := symlinks.Resolve(link)
-	if errors.Is(err, os.ErrNotExist) {
+func linkExists(dir *os.File, name string, target string) (bool, error) {
+	currentTarget, err := readlinkat(dir, name)
+	if errors.Is(err, unix.ENOENT) || errors.Is(err, unix.EINVAL) {
 		return false, nil
 	}
 	if err != nil {
-		return false, fmt.Errorf("failed to resolve existing symlink %s: %w", link, err)
+		return false, fmt.Errorf("failed to resolve existing symlink %s: %w", name, err)
 	}
 	if currentTarget == target {
 		return true, nil
 	}
 	return false, nil
 }
+
+// forceCreateLink creates the specified symlink in the directory.
+// If a file (or empty directory) exists at the path it is removed.
+// Since the operations are performed relative to the directory handle, an
+// existing symlink at the path is never followed.
+func forceCreateLink(dir *os.File, name string, target string) error {
+	var stat unix.Stat_t
+	err := unix.Fstatat(int(dir.Fd()), name, &stat, unix.AT_SYMLINK_NOFOLLOW)
+	if err != nil && !errors.Is(err, unix.ENOENT) {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+	if err == nil {
+		flags := 0
+		if stat.Mode&unix.S_IFMT == unix.S_IFDIR {
+			flags = unix.AT_REMOVEDIR
+		}
+		if err := unix.Unlinkat(int(dir.Fd()), name, flags); err != nil {
+			return fmt.Errorf("failed to remove existing file: %w", err)
+		}
+	}
+	return unix.Symlinkat(target, int(dir.Fd()), name)
+}
+
+// readlinkat returns the target of the symlink with the specified name in the
+// directory.
+func readlinkat(dir *os.File, name string) (string, error) {
+	for size := 128; ; size *= 2 {
+		buffer := make([]byte, size)
+		n, err := unix.Readlinkat(int(dir.Fd()), name, buffer)
+		if err != nil {
+			return "", err
+		}
+		if n < size {
+			return string(buffer[:n]), nil
+		}
+	}
+}
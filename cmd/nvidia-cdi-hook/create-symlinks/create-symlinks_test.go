@@ -22,23 +22,27 @@ func TestLinkExist(t *testing.T) {
 		),
 	)
 
-	exists, err := linkExists("d", filepath.Join(tmpDir, "/a/b/c"))
+	dir, err := os.Open(filepath.Join(tmpDir, "/a/b"))
+	require.NoError(t, err)
+	defer dir.Close()
+
+	exists, err := linkExists(dir, "c", "d")
 	require.NoError(t, err)
 	require.True(t, exists)
 
-	exists, err = linkExists("/a/b/f", filepath.Join(tmpDir, "/a/b/e"))
+	exists, err = linkExists(dir, "e", "/a/b/f")
 	require.NoError(t, err)
 	require.True(t, exists)
 
-	exists, err = linkExists("different-target", filepath.Join(tmpDir, "/a/b/c"))
+	exists, err = linkExists(dir, "c", "different-target")
 	require.NoError(t, err)
 	require.False(t, exists)
 
-	exists, err = linkExists("/a/b/d", filepath.Join(tmpDir, "/a/b/c"))
+	exists, err = linkExists(dir, "c", "/a/b/d")
 	require.NoError(t, err)
 	require.False(t, exists)
 
-	exists, err = linkExists("foo", filepath.Join(tmpDir, "/a/b/does-not-exist"))
+	exists, err = linkExists(dir, "does-not-exist", "foo")
 	require.NoError(t, err)
 	require.False(t, exists)
 }
@@ -245,6 +249,7 @@ func TestCreateLinkOutOfBounds(t *testing.T) {
 		makeFs(containerRoot,
 			dirOrLink{path: "/lib"},
 			dirOrLink{path: "/lib/foo", target: hostRoot},
+			dirOrLink{path: hostRoot},
 		),
 	)
 
@@ -263,6 +268,84 @@ func TestCreateLinkOutOfBounds(t *testing.T) {
 	require.DirExists(t, filepath.Join(hostRoot, "libfoo.so"))
 }
 
+func TestCreateLinkMaliciousContainerRoot(t *testing.T) {
+	testCases := []struct {
+		description       string
+		containerContents []dirOrLink
+		link              string
+		expectError       bool
+		expectedLinkPath  string
+	}{
+		{
+			description: "relative parent symlink is resolved in container root",
+			containerContents: []dirOrLink{
+				{path: "/lib/foo", target: "../../../../../../../../../../{{ .hostRoot }}"},
+				{path: "{{ .hostRoot }}"},
+			},
+			link:             "/lib/foo/libfoo.so",
+			expectedLinkPath: "{{ .containerRoot }}/{{ .hostRoot }}/libfoo.so",
+		},
+		{
+			description: "existing link to host file is replaced and not followed",
+			containerContents: []dirOrLink{
+				{path: "/lib"},
+				{path: "/lib/libfoo.so", target: "{{ .hostRoot }}/libfoo.so"},
+			},
+			link:             "/lib/libfoo.so",
+			expectedLinkPath: "{{ .containerRoot }}/lib/libfoo.so",
+		},
+		{
+			description: "dangling parent symlink is an error",
+			containerContents: []dirOrLink{
+				{path: "/lib/foo", target: "{{ .hostRoot }}"},
+			},
+			link:        "/lib/foo/libfoo.so",
+			expectError: true,
+		},
+		{
+			description: "symlink loop in parent is an error",
+			containerContents: []dirOrLink{
+				{path: "/lib/a", target: "/lib/b"},
+				{path: "/lib/b", target: "/lib/a"},
+			},
+			link:        "/lib/a/libfoo.so",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			hostRoot := filepath.Join(tmpDir, "/host-root")
+			containerRoot := filepath.Join(tmpDir, "/container-root")
+			replacer := strings.NewReplacer("{{ .hostRoot }}", hostRoot, "{{ .containerRoot }}", containerRoot)
+
+			require.NoError(t, makeFs(hostRoot, dirOrLink{path: "libfoo.so"}))
+			var contents []dirOrLink
+			for _, c := range tc.containerContents {
+				contents = append(contents, dirOrLink{path: replacer.Replace(c.path), target: replacer.Replace(c.target)})
+			}
+			require.NoError(t, makeFs(containerRoot, contents...))
+
+			err := getTestCommand().createLink(containerRoot, "libfoo.so.1", tc.link)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				target, err := symlinks.Resolve(replacer.Replace(tc.expectedLinkPath))
+				require.NoError(t, err)
+				require.Equal(t, "libfoo.so.1", target)
+			}
+
+			// The host root is never modified.
+			require.DirExists(t, filepath.Join(hostRoot, "libfoo.so"))
+			entries, err := os.ReadDir(hostRoot)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+		})
+	}
+}
+
 type dirOrLink struct {
 	path   string
 	target string
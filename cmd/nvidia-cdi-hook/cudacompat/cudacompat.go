@@ -17,33 +17,27 @@
 package cudacompat
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strconv"
-	"strings"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/cudacompat"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldconfig"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 )
 
-const (
-	cudaCompatPath = "/usr/local/cuda/compat"
-	// cudaCompatLdsoconfdFilenamePattern specifies the pattern for the filename
-	// in ld.so.conf.d that includes a reference to the CUDA compat path.
-	// The 00-compat prefix is chosen to ensure that these libraries have a
-	// higher precedence than other libraries on the system.
-	cudaCompatLdsoconfdFilenamePattern = "00-compat-*.conf"
-)
-
 type command struct {
 	logger logger.Interface
 }
 
 type options struct {
 	hostDriverVersion string
+	compatRoots       cli.StringSlice
+	decisionFile      string
 	containerSpec     string
 }
 
@@ -74,9 +68,20 @@ func (m command) build() *cli.Command {
 	c.Flags = []cli.Flag{
 		&cli.StringFlag{
 			Name:        "host-driver-version",
-			Usage:       "Specify the host driver version. If the CUDA compat libraries detected in the container do not have a higher MAJOR version, the hook is a no-op.",
+			Usage:       "Specify the host driver version. If none of the CUDA compat libraries detected in the container have a newer MAJOR.MINOR.PATCH version, the hook is a no-op.",
 			Destination: &cfg.hostDriverVersion,
 		},
+		&cli.StringSliceFlag{
+			Name:        "compat-root",
+			Usage:       "Specify a path in the container to search for CUDA compat libraries. Of the libraries that are valid for the host driver, the newest is selected. If multiple roots contain libraries with the same version, the first root specified takes precedence.",
+			Value:       cli.NewStringSlice(cudacompat.DefaultRoots...),
+			Destination: &cfg.compatRoots,
+		},
+		&cli.StringFlag{
+			Name:        "decision-file",
+			Usage:       "Specify a file to write a JSON explanation of the compat library selection to. If '-' the explanation is written to STDOUT.",
+			Destination: &cfg.decisionFile,
+		},
 		&cli.StringFlag{
 			Name:        "container-spec",
 			Hidden:      true,
@@ -102,13 +107,14 @@ func (m command) run(_ *cli.Context, cfg *options) error {
 	if err != nil {
 		return fmt.Errorf("failed to load container state: %w", err)
 	}
+	logger.AddContainerFields(m.logger, s.ID, s.Bundle)
 
 	containerRootDir, err := s.GetContainerRoot()
 	if err != nil {
 		return fmt.Errorf("failed to determined container root: %w", err)
 	}
 
-	containerForwardCompatDir, err := m.getContainerForwardCompatDir(containerRoot(containerRootDir), cfg.hostDriverVersion)
+	containerForwardCompatDir, err := m.getContainerForwardCompatDir(containerroot.Root(containerRootDir), cfg.compatRoots.Value(), cfg.hostDriverVersion, cfg.decisionFile)
 	if err != nil {
 		return fmt.Errorf("failed to get container forward compat directory: %w", err)
 	}
@@ -116,106 +122,44 @@ func (m command) run(_ *cli.Context, cfg *options) error {
 		return nil
 	}
 
-	return m.createLdsoconfdFile(containerRoot(containerRootDir), cudaCompatLdsoconfdFilenamePattern, containerForwardCompatDir)
+	return ldconfig.CreateLdsoconfdFile(m.logger, containerroot.Root(containerRootDir), cudacompat.LdsoconfdFilenamePattern, containerForwardCompatDir)
 }
 
-func (m command) getContainerForwardCompatDir(containerRoot containerRoot, hostDriverVersion string) (string, error) {
-	if hostDriverVersion == "" {
-		m.logger.Debugf("Host driver version not specified")
-		return "", nil
-	}
-	if !containerRoot.hasPath(cudaCompatPath) {
-		m.logger.Debugf("No CUDA forward compatibility libraries directory in container")
-		return "", nil
-	}
-	if !containerRoot.hasPath("/etc/ld.so.cache") {
-		m.logger.Debugf("The container does not have an LDCache")
-		return "", nil
-	}
-
-	libs, err := containerRoot.globFiles(filepath.Join(cudaCompatPath, "libcuda.so.*.*"))
+// getContainerForwardCompatDir selects the directory containing the CUDA
+// forward compat libraries that should be used with the host driver.
+// If a decision file is specified, an explanation of the selection is written
+// to it.
+func (m command) getContainerForwardCompatDir(containerRoot containerroot.Root, compatRoots []string, hostDriverVersion string, decisionFile string) (string, error) {
+	decision, err := cudacompat.Select(m.logger, containerRoot, compatRoots, hostDriverVersion)
 	if err != nil {
-		m.logger.Warningf("Failed to find CUDA compat library: %w", err)
-		return "", nil
+		return "", err
 	}
-
-	if len(libs) == 0 {
-		m.logger.Debugf("No CUDA forward compatibility libraries container")
-		return "", nil
-	}
-
-	if len(libs) != 1 {
-		m.logger.Warningf("Unexpected number of CUDA compat libraries in container: %v", libs)
-		return "", nil
-	}
-
-	compatDriverVersion := strings.TrimPrefix(filepath.Base(libs[0]), "libcuda.so.")
-	compatMajor, err := extractMajorVersion(compatDriverVersion)
-	if err != nil {
-		return "", fmt.Errorf("failed to extract major version from %q: %v", compatDriverVersion, err)
-	}
-
-	driverMajor, err := extractMajorVersion(hostDriverVersion)
-	if err != nil {
-		return "", fmt.Errorf("failed to extract major version from %q: %v", hostDriverVersion, err)
+	m.logger.Debugf("CUDA forward compatibility: %v", decision)
+	if err := writeDecision(decisionFile, decision); err != nil {
+		m.logger.Warningf("Failed to write CUDA forward compatibility decision: %v", err)
 	}
-
-	if driverMajor >= compatMajor {
-		m.logger.Debugf("Compat major version is not greater than the host driver major version (%v >= %v)", hostDriverVersion, compatDriverVersion)
-		return "", nil
-	}
-
-	resolvedCompatDir := strings.TrimPrefix(filepath.Dir(libs[0]), string(containerRoot))
-	return resolvedCompatDir, nil
+	return decision.SelectedDir(), nil
 }
 
-// createLdsoconfdFile creates a file at /etc/ld.so.conf.d/ in the specified root.
-// The file is created at /etc/ld.so.conf.d/{{ .pattern }} using `CreateTemp` and
-// contains the specified directories on each line.
-func (m command) createLdsoconfdFile(in containerRoot, pattern string, dirs ...string) error {
-	if len(dirs) == 0 {
-		m.logger.Debugf("No directories to add to /etc/ld.so.conf")
+// writeDecision writes the specified decision as JSON to the specified file.
+// If the filename is empty, this is a no-op. If the filename is '-' the
+// decision is written to STDOUT.
+func writeDecision(filename string, decision *cudacompat.Decision) error {
+	if filename == "" {
 		return nil
 	}
 
-	ldsoconfdDir, err := in.resolve("/etc/ld.so.conf.d")
-	if err != nil {
-		return err
-	}
-	if err := os.MkdirAll(ldsoconfdDir, 0755); err != nil {
-		return fmt.Errorf("failed to create ld.so.conf.d: %w", err)
-	}
-
-	configFile, err := os.CreateTemp(ldsoconfdDir, pattern)
-	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
-	}
-	defer configFile.Close()
-
-	m.logger.Debugf("Adding directories %v to %v", dirs, configFile.Name())
-
-	added := make(map[string]bool)
-	for _, dir := range dirs {
-		if added[dir] {
-			continue
-		}
-		_, err = fmt.Fprintf(configFile, "%s\n", dir)
+	output := os.Stdout
+	if filename != "-" {
+		f, err := os.Create(filename)
 		if err != nil {
-			return fmt.Errorf("failed to update config file: %w", err)
+			return fmt.Errorf("failed to create decision file: %w", err)
 		}
-		added[dir] = true
-	}
-
-	// The created file needs to be world readable for the cases where the container is run as a non-root user.
-	if err := configFile.Chmod(0644); err != nil {
-		return fmt.Errorf("failed to chmod config file: %w", err)
+		defer f.Close()
+		output = f
 	}
 
-	return nil
-}
-
-// extractMajorVersion parses a version string and returns the major version as an int.
-func extractMajorVersion(version string) (int, error) {
-	majorString := strings.SplitN(version, ".", 2)[0]
-	return strconv.Atoi(majorString)
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(decision)
 }
@@ -24,6 +24,10 @@ import (
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/cudacompat"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldconfig"
 )
 
 func TestCompatLibs(t *testing.T) {
@@ -32,6 +36,7 @@ func TestCompatLibs(t *testing.T) {
 	testCases := []struct {
 		description                       string
 		contents                          map[string]string
+		compatRoots                       []string
 		hostDriverVersion                 string
 		expectedContainerForwardCompatDir string
 	}{
@@ -65,14 +70,75 @@ func TestCompatLibs(t *testing.T) {
 			expectedContainerForwardCompatDir: "",
 		},
 		{
-			description: "compat lib has same major version; ldcache",
+			description: "compat lib has same major version but is newer; ldcache",
 			contents: map[string]string{
 				"/etc/ld.so.cache": "",
 				"/usr/local/cuda/compat/libcuda.so.222.88.99": "",
 			},
 			hostDriverVersion:                 "222.55.66",
+			expectedContainerForwardCompatDir: "/usr/local/cuda/compat",
+		},
+		{
+			description: "compat lib has same version; ldcache",
+			contents: map[string]string{
+				"/etc/ld.so.cache": "",
+				"/usr/local/cuda/compat/libcuda.so.222.55.66": "",
+			},
+			hostDriverVersion:                 "222.55.66",
+			expectedContainerForwardCompatDir: "",
+		},
+		{
+			description: "compat lib has same major version but is older; ldcache",
+			contents: map[string]string{
+				"/etc/ld.so.cache":                           "",
+				"/usr/local/cuda/compat/libcuda.so.222.55.6": "",
+			},
+			hostDriverVersion:                 "222.55.66",
+			expectedContainerForwardCompatDir: "",
+		},
+		{
+			description: "newest valid compat root is selected; ldcache",
+			contents: map[string]string{
+				"/etc/ld.so.cache": "",
+				"/usr/local/cuda-12.4/compat/libcuda.so.550.54.15": "",
+				"/usr/local/cuda-12.8/compat/libcuda.so.570.86.10": "",
+				"/usr/local/cuda-12.6/compat/libcuda.so.560.35.03": "",
+			},
+			compatRoots:                       []string{"/usr/local/cuda-12.4/compat", "/usr/local/cuda-12.8/compat", "/usr/local/cuda-12.6/compat"},
+			hostDriverVersion:                 "535.104.05",
+			expectedContainerForwardCompatDir: "/usr/local/cuda-12.8/compat",
+		},
+		{
+			description: "compat roots older than the host driver are ignored; ldcache",
+			contents: map[string]string{
+				"/etc/ld.so.cache": "",
+				"/usr/local/cuda-12.4/compat/libcuda.so.550.54.15": "",
+				"/usr/local/cuda-12.8/compat/libcuda.so.570.86.10": "",
+			},
+			compatRoots:                       []string{"/usr/local/cuda-12.4/compat", "/usr/local/cuda-12.8/compat"},
+			hostDriverVersion:                 "570.124.06",
 			expectedContainerForwardCompatDir: "",
 		},
+		{
+			description: "missing compat roots are skipped; ldcache",
+			contents: map[string]string{
+				"/etc/ld.so.cache": "",
+				"/usr/local/cuda-12.4/compat/libcuda.so.550.54.15": "",
+			},
+			compatRoots:                       []string{"/usr/local/cuda-12.8/compat", "/usr/local/cuda-12.4/compat"},
+			hostDriverVersion:                 "535.104.05",
+			expectedContainerForwardCompatDir: "/usr/local/cuda-12.4/compat",
+		},
+		{
+			description: "multiple libraries in a single root; ldcache",
+			contents: map[string]string{
+				"/etc/ld.so.cache": "",
+				"/usr/local/cuda/compat/libcuda.so.550.54.15": "",
+				"/usr/local/cuda/compat/libcuda.so.570.86.10": "",
+			},
+			hostDriverVersion:                 "560.35.03",
+			expectedContainerForwardCompatDir: "/usr/local/cuda/compat",
+		},
 		{
 			description: "numeric comparison is used; ldcache",
 			contents: map[string]string{
@@ -130,7 +196,7 @@ func TestCompatLibs(t *testing.T) {
 			c := command{
 				logger: logger,
 			}
-			containerForwardCompatDir, err := c.getContainerForwardCompatDir(containerRoot(containerRootDir), tc.hostDriverVersion)
+			containerForwardCompatDir, err := c.getContainerForwardCompatDir(containerroot.Root(containerRootDir), tc.compatRoots, tc.hostDriverVersion, "")
 			require.NoError(t, err)
 			require.EqualValues(t, tc.expectedContainerForwardCompatDir, containerForwardCompatDir)
 		})
@@ -160,7 +226,7 @@ func TestUpdateLdconfig(t *testing.T) {
 			c := command{
 				logger: logger,
 			}
-			err := c.createLdsoconfdFile(containerRoot(containerRootDir), cudaCompatLdsoconfdFilenamePattern, tc.folders...)
+			err := ldconfig.CreateLdsoconfdFile(c.logger, containerroot.Root(containerRootDir), cudacompat.LdsoconfdFilenamePattern, tc.folders...)
 			require.NoError(t, err)
 
 			matches, err := filepath.Glob(filepath.Join(containerRootDir, "/etc/ld.so.conf.d/00-compat-*.conf"))
@@ -180,3 +246,28 @@ func TestUpdateLdconfig(t *testing.T) {
 	}
 
 }
+
+func TestUpdateLdconfigSymlinkedToHost(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	tmpDir := t.TempDir()
+	hostRoot := filepath.Join(tmpDir, "host-root")
+	containerRootDir := filepath.Join(tmpDir, "container-root")
+	require.NoError(t, os.MkdirAll(filepath.Join(hostRoot, "ld.so.conf.d"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(containerRootDir, hostRoot), 0755))
+	require.NoError(t, os.Symlink("../../../../../../../.."+hostRoot, filepath.Join(containerRootDir, "etc")))
+
+	c := command{
+		logger: logger,
+	}
+	err := ldconfig.CreateLdsoconfdFile(c.logger, containerroot.Root(containerRootDir), cudacompat.LdsoconfdFilenamePattern, "/usr/local/cuda/compat")
+	require.NoError(t, err)
+
+	matches, err := filepath.Glob(filepath.Join(containerRootDir, hostRoot, "/ld.so.conf.d/00-compat-*.conf"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+
+	hostMatches, err := filepath.Glob(filepath.Join(hostRoot, "/ld.so.conf.d/*"))
+	require.NoError(t, err)
+	require.Empty(t, hostMatches)
+}
@@ -21,7 +21,10 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	log "github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 
 	cli "github.com/urfave/cli/v2"
 
@@ -37,8 +40,14 @@ type options struct {
 	Quiet bool
 }
 
+// binaryName is the name used to look up the log file for the NVIDIA CDI Hook
+// in the shared logging config.
+const binaryName = "nvidia-cdi-hook"
+
 func main() {
 	logger := logrus.New()
+	closeLog := func() error { return nil }
+	var tracer *tracing.Tracer
 
 	// Create a options struct to hold the parsed environment variables or command line flags
 	opts := options{}
@@ -70,14 +79,21 @@ func main() {
 
 	// Set log-level for all subcommands
 	c.Before = func(c *cli.Context) error {
-		logLevel := logrus.InfoLevel
+		logger.SetLevel(logrus.InfoLevel)
+		// Since hooks must not fail due to logging or tracing, errors in
+		// loading the config are ignored.
+		if cfg, err := config.GetConfig(); err != nil {
+			logger.Debugf("Ignoring config: %v", err)
+		} else {
+			closeLog = configureLogging(logger, cfg)
+			tracer = configureTracing(logger, cfg, c.Args().First())
+		}
 		if opts.Debug {
-			logLevel = logrus.DebugLevel
+			logger.SetLevel(logrus.DebugLevel)
 		}
 		if opts.Quiet {
-			logLevel = logrus.ErrorLevel
+			logger.SetLevel(logrus.ErrorLevel)
 		}
-		logger.SetLevel(logLevel)
 		return nil
 	}
 
@@ -88,6 +104,36 @@ func main() {
 	err := c.Run(os.Args)
 	if err != nil {
 		logger.Errorf("%v", err)
+	}
+	tracer.Flush()
+	if err := closeLog(); err != nil {
+		logger.Warningf("Failed to close log file: %v", err)
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
+
+// configureLogging applies the shared logging config to the specified logger.
+// Since hooks must not fail due to logging, errors are logged and ignored.
+// The returned function closes the log file, if any.
+func configureLogging(logger *logrus.Logger, cfg *config.Config) func() error {
+	noop := func() error { return nil }
+
+	closeLog, err := log.Configure(logger, cfg.Logging.Format, cfg.Logging.Level, cfg.Logging.GetFile(binaryName))
+	if err != nil {
+		logger.Warningf("Ignoring logging config: %v", err)
+		return noop
+	}
+	return closeLog
+}
+
+// configureTracing starts a span for the specified hook if tracing is
+// configured. The span is a child of the span propagated by the NVIDIA
+// Container Runtime, if any. The returned tracer is nil if tracing is not
+// configured.
+func configureTracing(logger *logrus.Logger, cfg *config.Config, hook string) *tracing.Tracer {
+	tracer := tracing.NewFromConfig(logger, binaryName, cfg.Tracing)
+	tracer.Start(binaryName + " " + hook)
+	return tracer
+}
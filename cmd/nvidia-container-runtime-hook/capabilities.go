@@ -2,6 +2,12 @@ package main
 
 import (
 	"log"
+	"path/filepath"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 )
 
 func capabilityToCLI(cap string) string {
@@ -25,3 +31,128 @@ func capabilityToCLI(cap string) string {
 	}
 	return ""
 }
+
+// driverCapabilityFiles maps the libraries and binaries of the driver
+// (without their .so suffix) to the driver capability that requires them.
+// These match the files injected by the nvidia-container-cli for each
+// capability.
+var driverCapabilityFiles = map[string]image.DriverCapability{
+	"libnvidia-ml":        image.DriverCapabilityUtility,
+	"libnvidia-cfg":       image.DriverCapabilityUtility,
+	"nvidia-smi":          image.DriverCapabilityUtility,
+	"nvidia-debugdump":    image.DriverCapabilityUtility,
+	"nvidia-persistenced": image.DriverCapabilityUtility,
+
+	"libcuda":                   image.DriverCapabilityCompute,
+	"libcudadebugger":           image.DriverCapabilityCompute,
+	"libnvidia-opencl":          image.DriverCapabilityCompute,
+	"libnvidia-gpucomp":         image.DriverCapabilityCompute,
+	"libnvidia-ptxjitcompiler":  image.DriverCapabilityCompute,
+	"libnvidia-fatbinaryloader": image.DriverCapabilityCompute,
+	"libnvidia-compiler":        image.DriverCapabilityCompute,
+	"libnvidia-nvvm":            image.DriverCapabilityCompute,
+	"libnvidia-pkcs11":          image.DriverCapabilityCompute,
+	"libnvidia-pkcs11-openssl3": image.DriverCapabilityCompute,
+	"nvidia-cuda-mps-control":   image.DriverCapabilityCompute,
+	"nvidia-cuda-mps-server":    image.DriverCapabilityCompute,
+
+	"libvdpau_nvidia":       image.DriverCapabilityVideo,
+	"libnvidia-encode":      image.DriverCapabilityVideo,
+	"libnvidia-opticalflow": image.DriverCapabilityVideo,
+	"libnvcuvid":            image.DriverCapabilityVideo,
+
+	"libnvidia-eglcore":         image.DriverCapabilityGraphics,
+	"libnvidia-glcore":          image.DriverCapabilityGraphics,
+	"libnvidia-tls":             image.DriverCapabilityGraphics,
+	"libnvidia-glsi":            image.DriverCapabilityGraphics,
+	"libnvidia-fbc":             image.DriverCapabilityGraphics,
+	"libnvidia-ifr":             image.DriverCapabilityGraphics,
+	"libnvidia-rtcore":          image.DriverCapabilityGraphics,
+	"libnvoptix":                image.DriverCapabilityGraphics,
+	"libGLX_nvidia":             image.DriverCapabilityGraphics,
+	"libEGL_nvidia":             image.DriverCapabilityGraphics,
+	"libGLESv2_nvidia":          image.DriverCapabilityGraphics,
+	"libGLESv1_CM_nvidia":       image.DriverCapabilityGraphics,
+	"libnvidia-glvkspirv":       image.DriverCapabilityGraphics,
+	"libnvidia-cbl":             image.DriverCapabilityGraphics,
+	"libnvidia-allocator":       image.DriverCapabilityGraphics,
+	"libnvidia-vulkan-producer": image.DriverCapabilityGraphics,
+	"libnvidia-egl-gbm":         image.DriverCapabilityGraphics,
+	"libnvidia-egl-wayland":     image.DriverCapabilityGraphics,
+	"libnvidia-egl-xcb":         image.DriverCapabilityGraphics,
+	"libnvidia-egl-xlib":        image.DriverCapabilityGraphics,
+	"libnvidia-api":             image.DriverCapabilityGraphics,
+
+	"libglxserver_nvidia": image.DriverCapabilityDisplay,
+	"nvidia_drv":          image.DriverCapabilityDisplay,
+
+	"libnvidia-ngx":      image.DriverCapabilityNgx,
+	"nvidia-ngx-updater": image.DriverCapabilityNgx,
+}
+
+// filterEditsByDriverCapabilities removes the mounts and device nodes that are
+// not required for the specified driver capabilities from the edits. Files that
+// are not associated with a specific capability, such as the GSP firmware, are
+// always kept.
+func filterEditsByDriverCapabilities(edits *specs.ContainerEdits, capabilities image.DriverCapabilities) {
+	if edits == nil || capabilities.IsAll() {
+		return
+	}
+
+	var mounts []*specs.Mount
+	for _, m := range edits.Mounts {
+		if !isRequiredForDriverCapabilities(capabilities, mountDriverCapabilities(m.ContainerPath)...) {
+			continue
+		}
+		mounts = append(mounts, m)
+	}
+	edits.Mounts = mounts
+
+	var deviceNodes []*specs.DeviceNode
+	for _, dn := range edits.DeviceNodes {
+		if !isRequiredForDriverCapabilities(capabilities, deviceNodeDriverCapabilities(dn.Path)...) {
+			continue
+		}
+		deviceNodes = append(deviceNodes, dn)
+	}
+	edits.DeviceNodes = deviceNodes
+}
+
+// isRequiredForDriverCapabilities checks whether a file that is required by any
+// of the specified driver capabilities is required. A file without associated
+// capabilities is always required.
+func isRequiredForDriverCapabilities(capabilities image.DriverCapabilities, required ...image.DriverCapability) bool {
+	return len(required) == 0 || capabilities.Any(required...)
+}
+
+// mountDriverCapabilities returns the driver capabilities that require the
+// specified mount.
+func mountDriverCapabilities(path string) []image.DriverCapability {
+	stem, _, _ := strings.Cut(filepath.Base(path), ".so")
+	if capability, ok := driverCapabilityFiles[stem]; ok {
+		return []image.DriverCapability{capability}
+	}
+	// The ICD files for Vulkan, EGL, and GLVND as well as the X11 config files
+	// are identified by their directory.
+	dir := filepath.Dir(path)
+	switch {
+	case strings.Contains(dir, "/vulkan/"), strings.Contains(dir, "/egl/"), strings.Contains(dir, "/glvnd/"):
+		return []image.DriverCapability{image.DriverCapabilityGraphics}
+	case strings.Contains(dir, "/X11/"):
+		return []image.DriverCapability{image.DriverCapabilityDisplay}
+	}
+	return nil
+}
+
+// deviceNodeDriverCapabilities returns the driver capabilities that require
+// the specified device node. GPU device nodes and the control device node are
+// required for all capabilities.
+func deviceNodeDriverCapabilities(path string) []image.DriverCapability {
+	switch {
+	case strings.HasPrefix(path, "/dev/nvidia-uvm"):
+		return []image.DriverCapability{image.DriverCapabilityCompute}
+	case path == "/dev/nvidia-modeset", strings.HasPrefix(path, "/dev/dri/"):
+		return []image.DriverCapability{image.DriverCapabilityGraphics, image.DriverCapabilityDisplay}
+	}
+	return nil
+}
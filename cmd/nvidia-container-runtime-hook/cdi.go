@@ -0,0 +1,185 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containeredits"
+)
+
+const (
+	automaticCDIKind = "runtime.nvidia.com/gpu"
+	imexChannelKind  = "nvidia.com/imex-channel"
+)
+
+// configureCDI configures the container in CDI mode. This allows the hook to
+// be invoked directly by a container engine (e.g. using the docker --gpus
+// flag) on systems where the NVIDIA Container Runtime is configured to use
+// CDI.
+//
+// The requested devices, MIG devices, and IMEX channels are translated to
+// fully-qualified CDI device names which are resolved using the CDI registry.
+// If the default kind refers to the automatically generated devices, the edits
+// are generated using nvcdi instead. Since the edits are applied to the created
+// container, this is only supported on hosts that use cgroup v1.
+func (c *hookConfig) configureCDI(container containerConfig, rootfs string) error {
+	logger := &logInterceptor{}
+
+	applier, err := c.newEditsApplier(logger, container, rootfs)
+	if errors.Is(err, containeredits.ErrCgroupV2) {
+		return fmt.Errorf("%w; request the devices using their CDI device names (e.g. docker run --device nvidia.com/gpu=all) or use the NVIDIA Container Runtime instead", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkRequirements(logger, container, rootfs); err != nil {
+		return err
+	}
+
+	kind := c.NVIDIAContainerRuntimeConfig.Modes.CDI.DefaultKind
+	if kind == automaticCDIKind {
+		edits, err := c.getInProcessEdits(logger, container.Nvidia)
+		if err != nil {
+			return err
+		}
+		return applier.Apply(edits)
+	}
+
+	span := hookTracer.Start("cdi.NewCache")
+	cache, err := cdi.NewCache(
+		cdi.WithSpecDirs(c.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs...),
+		cdi.WithAutoRefresh(false),
+	)
+	span.End()
+	if err != nil {
+		return fmt.Errorf("failed to create CDI registry: %w", err)
+	}
+
+	devices := getCDIDeviceNames(kind, container.Nvidia, cache.ListDevices())
+	if len(devices) == 0 {
+		return nil
+	}
+	logger.Infof("Injecting CDI devices %v", devices)
+
+	edits, err := getCDIEdits(cache, devices)
+	if err != nil {
+		return err
+	}
+	return applier.Apply(edits)
+}
+
+// getCDIDeviceNames translates the devices requested for a container to
+// fully-qualified CDI device names. Requests for the mig-config or
+// mig-monitor devices, or IMEX channels, of all GPUs are expanded using the
+// specified list of available CDI devices.
+func getCDIDeviceNames(kind string, nvidia *nvidiaConfig, available []string) []string {
+	var devices []string
+	seen := make(map[string]bool)
+	add := func(names ...string) {
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			devices = append(devices, name)
+		}
+	}
+
+	for _, id := range nvidia.Devices {
+		switch {
+		case id == "" || id == "none" || id == "void":
+		case parser.IsQualifiedName(id):
+			add(id)
+		default:
+			add(kind + "=" + id)
+		}
+	}
+
+	add(getCDIMIGCapabilityDeviceNames(kind, nvidia.MigConfigDevices, "-mig-config", available)...)
+	add(getCDIMIGCapabilityDeviceNames(kind, nvidia.MigMonitorDevices, "-mig-monitor", available)...)
+
+	for _, channel := range nvidia.ImexChannels {
+		if channel != "all" {
+			add(imexChannelKind + "=" + channel)
+			continue
+		}
+		for _, name := range available {
+			if strings.HasPrefix(name, imexChannelKind+"=") && name != imexChannelKind+"=all" {
+				add(name)
+			}
+		}
+	}
+
+	return devices
+}
+
+// getCDIMIGCapabilityDeviceNames returns the names of the MIG capability
+// devices with the specified suffix for the comma-separated list of GPUs.
+func getCDIMIGCapabilityDeviceNames(kind string, requested string, suffix string, available []string) []string {
+	if requested == "" {
+		return nil
+	}
+
+	var devices []string
+	for _, gpu := range strings.Split(requested, ",") {
+		gpu = strings.TrimSpace(gpu)
+		if gpu != "all" {
+			devices = append(devices, kind+"="+gpu+suffix)
+			continue
+		}
+		for _, name := range available {
+			if strings.HasPrefix(name, kind+"=") && strings.HasSuffix(name, suffix) {
+				devices = append(devices, name)
+			}
+		}
+	}
+	return devices
+}
+
+// getCDIEdits returns the combined container edits for the specified CDI
+// devices. As is the case when injecting devices into an OCI spec, the edits
+// of each spec that defines a device are only included once.
+func getCDIEdits(cache *cdi.Cache, devices []string) (*specs.ContainerEdits, error) {
+	edits := &cdi.ContainerEdits{ContainerEdits: &specs.ContainerEdits{}}
+
+	var unresolved []string
+	seenSpecs := make(map[*cdi.Spec]bool)
+	for _, name := range devices {
+		device := cache.GetDevice(name)
+		if device == nil {
+			unresolved = append(unresolved, name)
+			continue
+		}
+		if spec := device.GetSpec(); !seenSpecs[spec] {
+			seenSpecs[spec] = true
+			edits.Append(&cdi.ContainerEdits{ContainerEdits: &spec.ContainerEdits})
+		}
+		edits.Append(&cdi.ContainerEdits{ContainerEdits: &device.ContainerEdits})
+	}
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("unresolvable CDI devices %v", strings.Join(unresolved, ", "))
+	}
+	return edits.ContainerEdits, nil
+}
@@ -0,0 +1,163 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containeredits"
+)
+
+func TestGetCDIDeviceNames(t *testing.T) {
+	available := []string{
+		"nvidia.com/gpu=0",
+		"nvidia.com/gpu=0-mig-config",
+		"nvidia.com/gpu=0-mig-monitor",
+		"nvidia.com/gpu=1",
+		"nvidia.com/gpu=1-mig-config",
+		"nvidia.com/gpu=all",
+		"nvidia.com/imex-channel=0",
+		"nvidia.com/imex-channel=1",
+		"nvidia.com/imex-channel=all",
+	}
+
+	testCases := []struct {
+		description     string
+		nvidia          *nvidiaConfig
+		expectedDevices []string
+	}{
+		{
+			description: "no devices",
+			nvidia:      &nvidiaConfig{Devices: []string{"none"}},
+		},
+		{
+			description:     "device IDs use the default kind",
+			nvidia:          &nvidiaConfig{Devices: []string{"all", "0", "GPU-abc", "0:1"}},
+			expectedDevices: []string{"nvidia.com/gpu=all", "nvidia.com/gpu=0", "nvidia.com/gpu=GPU-abc", "nvidia.com/gpu=0:1"},
+		},
+		{
+			description:     "qualified names are used as is",
+			nvidia:          &nvidiaConfig{Devices: []string{"example.com/device=foo", "0", "nvidia.com/gpu=0"}},
+			expectedDevices: []string{"example.com/device=foo", "nvidia.com/gpu=0"},
+		},
+		{
+			description: "MIG capability devices",
+			nvidia: &nvidiaConfig{
+				Devices:           []string{"0"},
+				MigConfigDevices:  "all",
+				MigMonitorDevices: "0",
+			},
+			expectedDevices: []string{
+				"nvidia.com/gpu=0",
+				"nvidia.com/gpu=0-mig-config",
+				"nvidia.com/gpu=1-mig-config",
+				"nvidia.com/gpu=0-mig-monitor",
+			},
+		},
+		{
+			description: "IMEX channels",
+			nvidia: &nvidiaConfig{
+				Devices:      []string{"0"},
+				ImexChannels: []string{"3", "all"},
+			},
+			expectedDevices: []string{
+				"nvidia.com/gpu=0",
+				"nvidia.com/imex-channel=3",
+				"nvidia.com/imex-channel=0",
+				"nvidia.com/imex-channel=1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devices := getCDIDeviceNames("nvidia.com/gpu", tc.nvidia, available)
+			require.EqualValues(t, tc.expectedDevices, devices)
+		})
+	}
+}
+
+func TestGetCDIEdits(t *testing.T) {
+	specDir := t.TempDir()
+	spec := `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+- name: "1"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia1
+containerEdits:
+  deviceNodes:
+  - path: /dev/nvidiactl
+  mounts:
+  - hostPath: /usr/lib/libcuda.so.1
+    containerPath: /usr/lib/libcuda.so.1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "nvidia.yaml"), []byte(spec), 0600))
+
+	cache, err := cdi.NewCache(cdi.WithSpecDirs(specDir), cdi.WithAutoRefresh(false))
+	require.NoError(t, err)
+
+	edits, err := getCDIEdits(cache, []string{"nvidia.com/gpu=0", "nvidia.com/gpu=1"})
+	require.NoError(t, err)
+	require.EqualValues(t,
+		&specs.ContainerEdits{
+			DeviceNodes: []*specs.DeviceNode{
+				{Path: "/dev/nvidiactl"},
+				{Path: "/dev/nvidia0"},
+				{Path: "/dev/nvidia1"},
+			},
+			Mounts: []*specs.Mount{
+				{HostPath: "/usr/lib/libcuda.so.1", ContainerPath: "/usr/lib/libcuda.so.1"},
+			},
+		},
+		edits,
+	)
+
+	_, err = getCDIEdits(cache, []string{"nvidia.com/gpu=0", "nvidia.com/gpu=2"})
+	require.EqualError(t, err, "unresolvable CDI devices nvidia.com/gpu=2")
+}
+
+func TestConfigureCDICgroupV2(t *testing.T) {
+	defer func(newApplier func(...containeredits.Option) (*containeredits.Applier, error)) {
+		newContainerEditsApplier = newApplier
+	}(newContainerEditsApplier)
+	newContainerEditsApplier = func(...containeredits.Option) (*containeredits.Applier, error) {
+		return nil, containeredits.ErrCgroupV2
+	}
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs = []string{t.TempDir()}
+	hook := &hookConfig{Config: cfg}
+
+	err = hook.configureCDI(containerConfig{ID: "ctr", Pid: 1, Nvidia: &nvidiaConfig{Devices: []string{"all"}}}, "/rootfs")
+	require.ErrorIs(t, err, containeredits.ErrCgroupV2)
+	require.ErrorContains(t, err, "request the devices using their CDI device names")
+}
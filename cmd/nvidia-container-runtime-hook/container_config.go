@@ -30,10 +30,13 @@ type nvidiaConfig struct {
 }
 
 type containerConfig struct {
-	Pid    int
-	Rootfs string
-	Image  image.CUDA
-	Nvidia *nvidiaConfig
+	ID          string
+	Pid         int
+	Bundle      string
+	Rootfs      string
+	Image       image.CUDA
+	Annotations map[string]string
+	Nvidia      *nvidiaConfig
 }
 
 // Root from OCI runtime spec
@@ -63,15 +66,17 @@ type LinuxCapabilities struct {
 // We use pointers to structs, similarly to the latest version of runtime-spec:
 // https://github.com/opencontainers/runtime-spec/blob/v1.0.0/specs-go/config.go#L5-L28
 type Spec struct {
-	Version *string       `json:"ociVersion"`
-	Process *Process      `json:"process,omitempty"`
-	Root    *Root         `json:"root,omitempty"`
-	Mounts  []specs.Mount `json:"mounts,omitempty"`
+	Version     *string           `json:"ociVersion"`
+	Process     *Process          `json:"process,omitempty"`
+	Root        *Root             `json:"root,omitempty"`
+	Mounts      []specs.Mount     `json:"mounts,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // HookState holds state information about the hook
 type HookState struct {
-	Pid int `json:"pid,omitempty"`
+	ID  string `json:"id,omitempty"`
+	Pid int    `json:"pid,omitempty"`
 	// After 17.06, runc is using the runtime spec:
 	// github.com/docker/runc/blob/17.06/libcontainer/configs/config.go#L262-L263
 	// github.com/opencontainers/runtime-spec/blob/v1.0.0/specs-go/state.go#L3-L17
@@ -317,9 +322,12 @@ func (hookConfig *hookConfig) getContainerConfig() (config containerConfig) {
 
 	privileged := isPrivileged(s)
 	return containerConfig{
-		Pid:    h.Pid,
-		Rootfs: s.Root.Path,
-		Image:  image,
-		Nvidia: hookConfig.getNvidiaConfig(image, privileged),
+		ID:          h.ID,
+		Pid:         h.Pid,
+		Bundle:      b,
+		Rootfs:      s.Root.Path,
+		Image:       image,
+		Annotations: s.Annotations,
+		Nvidia:      hookConfig.getNvidiaConfig(image, privileged),
 	}
 }
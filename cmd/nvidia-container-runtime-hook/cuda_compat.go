@@ -0,0 +1,79 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"log"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/cudacompat"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldconfig"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+// applyCUDACompatPolicy selects the CUDA Forward Compatibility libraries in the
+// container using the same policy as the enable-cuda-compat CDI hook.
+// This is only done if the ldconfig CUDA compat mode is configured. The
+// selected folder is added to /etc/ld.so.conf.d in the container so that it is
+// picked up when the nvidia-container-cli runs ldconfig.
+//
+// The return value indicates whether the policy was applied. If this is false,
+// the handling of CUDA Forward Compatibility is left to the
+// nvidia-container-cli.
+func (c *hookConfig) applyCUDACompatPolicy(rootfs string) bool {
+	if c.NVIDIAContainerRuntimeConfig.Modes.Legacy.CUDACompatMode != config.CUDACompatModeLdconfig {
+		return false
+	}
+
+	hostDriverVersion, err := c.getHostDriverVersion()
+	if err != nil {
+		log.Printf("Failed to determine host driver version: %v", err)
+		return false
+	}
+
+	containerRoot := containerroot.Root(rootfs)
+	decision, err := cudacompat.Select(&logInterceptor{}, containerRoot, cudacompat.DefaultRoots, hostDriverVersion)
+	if err != nil {
+		log.Printf("Failed to select CUDA forward compatibility libraries: %v", err)
+		return false
+	}
+	if *debugflag {
+		log.Printf("CUDA forward compatibility: %v", decision)
+	}
+
+	// If no compat libraries were selected, nothing is added to the container.
+	dir := decision.SelectedDir()
+	if dir == "" {
+		return true
+	}
+	if err := ldconfig.CreateLdsoconfdFile(&logInterceptor{}, containerRoot, cudacompat.LdsoconfdFilenamePattern, dir); err != nil {
+		log.Printf("Failed to update ld.so.conf.d: %v", err)
+		return false
+	}
+	return true
+}
+
+// getHostDriverVersion returns the version of the driver installed at the
+// configured driver root.
+func (c *hookConfig) getHostDriverVersion() (string, error) {
+	driver := root.New(
+		root.WithLogger(&logInterceptor{}),
+		root.WithDriverRoot(c.NVIDIAContainerCLIConfig.Root),
+	)
+	return cudacompat.HostDriverVersion(driver)
+}
@@ -0,0 +1,143 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/parser"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devicepolicy"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// applyDevicePolicy applies the configured device policy to the devices, MIG
+// devices, and IMEX channels requested for the container. Since this is done
+// before the container is configured, the policy applies regardless of how
+// the requested devices are injected. As is the case for the NVIDIA Container
+// Runtime, devices that are not specified as fully-qualified CDI device names
+// are evaluated as devices of the default CDI kind. If the policy filters
+// denied requests, the denied devices are removed from the request.
+func (c *hookConfig) applyDevicePolicy(logger logger.Interface, container containerConfig) error {
+	policy, err := devicepolicy.New(logger, c.NVIDIAContainerRuntimeConfig.Modes.CDI.Policy)
+	if err != nil {
+		return fmt.Errorf("failed to construct device policy: %w", err)
+	}
+	if policy == nil {
+		return nil
+	}
+
+	policyContainer := devicepolicy.NewContainer(container.Annotations)
+	kind := c.NVIDIAContainerRuntimeConfig.Modes.CDI.DefaultKind
+	nvidia := container.Nvidia
+
+	devicesSource := devicepolicy.SourceEnvvar
+	if c.AcceptDeviceListAsVolumeMounts && len(container.Image.VisibleDevicesFromMounts()) > 0 {
+		devicesSource = devicepolicy.SourceMount
+	}
+	nvidia.Devices, err = applyDevicePolicyToRequests(policy, policyContainer, devicesSource, nvidia.Devices, func(id string) string {
+		if parser.IsQualifiedName(id) {
+			return id
+		}
+		return kind + "=" + id
+	})
+	if err != nil {
+		return err
+	}
+
+	// MIG devices can only be requested using environment variables.
+	migConfigDevices, err := applyDevicePolicyToRequests(policy, policyContainer, devicepolicy.SourceEnvvar, splitDeviceList(nvidia.MigConfigDevices), func(gpu string) string {
+		return kind + "=" + gpu + "-mig-config"
+	})
+	if err != nil {
+		return err
+	}
+	nvidia.MigConfigDevices = strings.Join(migConfigDevices, ",")
+
+	migMonitorDevices, err := applyDevicePolicyToRequests(policy, policyContainer, devicepolicy.SourceEnvvar, splitDeviceList(nvidia.MigMonitorDevices), func(gpu string) string {
+		return kind + "=" + gpu + "-mig-monitor"
+	})
+	if err != nil {
+		return err
+	}
+	nvidia.MigMonitorDevices = strings.Join(migMonitorDevices, ",")
+
+	imexChannelsSource := devicepolicy.SourceEnvvar
+	if c.AcceptDeviceListAsVolumeMounts && len(container.Image.ImexChannelsFromMounts()) > 0 {
+		imexChannelsSource = devicepolicy.SourceMount
+	}
+	nvidia.ImexChannels, err = applyDevicePolicyToRequests(policy, policyContainer, imexChannelsSource, nvidia.ImexChannels, func(channel string) string {
+		return imexChannelKind + "=" + channel
+	})
+	return err
+}
+
+// applyDevicePolicyToRequests applies the policy to the specified requests and
+// returns the requests that are allowed. The specified function returns the
+// CDI device name that a request is evaluated as.
+func applyDevicePolicyToRequests(policy *devicepolicy.Policy, container devicepolicy.Container, source devicepolicy.Source, requests []string, toDeviceName func(string) string) ([]string, error) {
+	var devices []string
+	for _, request := range requests {
+		if isEmptyDeviceRequest(request) {
+			continue
+		}
+		devices = append(devices, toDeviceName(request))
+	}
+	if len(devices) == 0 {
+		return requests, nil
+	}
+
+	allowedDevices, err := policy.Apply(container, source, devices)
+	if err != nil {
+		return nil, err
+	}
+	isAllowed := make(map[string]bool)
+	for _, device := range allowedDevices {
+		isAllowed[device] = true
+	}
+
+	var allowed []string
+	for _, request := range requests {
+		if isEmptyDeviceRequest(request) || isAllowed[toDeviceName(request)] {
+			allowed = append(allowed, request)
+		}
+	}
+	return allowed, nil
+}
+
+// isEmptyDeviceRequest checks whether the specified request does not select a
+// device.
+func isEmptyDeviceRequest(request string) bool {
+	switch request {
+	case "", "none", "void":
+		return true
+	}
+	return false
+}
+
+// splitDeviceList splits a comma-separated list of devices.
+func splitDeviceList(devices string) []string {
+	if devices == "" {
+		return nil
+	}
+	var split []string
+	for _, device := range strings.Split(devices, ",") {
+		split = append(split, strings.TrimSpace(device))
+	}
+	return split
+}
@@ -0,0 +1,125 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestApplyDevicePolicy(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		policy         config.DevicePolicyConfig
+		annotations    map[string]string
+		nvidia         *nvidiaConfig
+		expectedNvidia *nvidiaConfig
+		expectedError  string
+	}{
+		{
+			description: "no policy allows all requests",
+			nvidia: &nvidiaConfig{
+				Devices:      []string{"0", "management.nvidia.com/gpu=all"},
+				ImexChannels: []string{"0"},
+			},
+			expectedNvidia: &nvidiaConfig{
+				Devices:      []string{"0", "management.nvidia.com/gpu=all"},
+				ImexChannels: []string{"0"},
+			},
+		},
+		{
+			description: "denied requests are filtered",
+			policy: config.DevicePolicyConfig{
+				OnDeny: "filter",
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Kinds: []string{"management.nvidia.com/*"}},
+					{Action: "deny", Names: []string{"1", "1-mig-config", "*-mig-monitor"}},
+					{Action: "deny", Classes: []string{"imex-channel"}},
+				},
+			},
+			nvidia: &nvidiaConfig{
+				Devices:           []string{"0", "1", "management.nvidia.com/gpu=all"},
+				MigConfigDevices:  "0, 1",
+				MigMonitorDevices: "all",
+				ImexChannels:      []string{"0", "1"},
+			},
+			expectedNvidia: &nvidiaConfig{
+				Devices:          []string{"0"},
+				MigConfigDevices: "0",
+			},
+		},
+		{
+			description: "denied requests are rejected",
+			policy: config.DevicePolicyConfig{
+				DefaultAction: "deny",
+				Rules: []config.DevicePolicyRule{
+					{Action: "allow", Kinds: []string{"nvidia.com/gpu"}, Sources: []string{"envvar"}},
+				},
+			},
+			nvidia: &nvidiaConfig{
+				Devices:      []string{"0"},
+				ImexChannels: []string{"0"},
+			},
+			expectedError: "device request denied by policy: nvidia.com/imex-channel=0",
+		},
+		{
+			description: "annotations can deny requests",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Annotations: map[string]string{"example.com/tenant": "untrusted"}},
+				},
+			},
+			annotations: map[string]string{"example.com/tenant": "untrusted"},
+			nvidia: &nvidiaConfig{
+				Devices: []string{"all"},
+			},
+			expectedError: "device request denied by policy: nvidia.com/gpu=all",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg, err := config.GetDefault()
+			require.NoError(t, err)
+			cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.Policy = tc.policy
+			hook := &hookConfig{cfg}
+
+			i, err := image.New()
+			require.NoError(t, err)
+			container := containerConfig{
+				Image:       i,
+				Annotations: tc.annotations,
+				Nvidia:      tc.nvidia,
+			}
+
+			err = hook.applyDevicePolicy(logger, container)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedNvidia, container.Nvidia)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 )
 
 const (
@@ -55,8 +56,17 @@ func getHookConfig() (*hookConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %v", err)
 	}
+	if *profileflag != "" {
+		if err := cfg.ApplyProfile(*profileflag); err != nil {
+			return nil, fmt.Errorf("failed to apply profile: %w", err)
+		}
+	}
 	config := &hookConfig{cfg}
 
+	if root.IsAuto(config.NVIDIAContainerCLIConfig.Root) {
+		config.NVIDIAContainerCLIConfig.Root = resolveAutoDriverRoot(*driverRootflag)
+	}
+
 	allSupportedDriverCapabilities := image.SupportedDriverCapabilities
 	if config.SupportedDriverCapabilities == "all" {
 		config.SupportedDriverCapabilities = allSupportedDriverCapabilities.String()
@@ -107,11 +117,16 @@ func (c *hookConfig) getSwarmResourceEnvvars() []string {
 
 // nvidiaContainerCliCUDACompatModeFlags returns required --cuda-compat-mode
 // flag(s) depending on the hook and runtime configurations.
-func (c *hookConfig) nvidiaContainerCliCUDACompatModeFlags() []string {
+// If the CUDA compat policy has already been applied by the hook, the
+// handling of CUDA compat libraries in the nvidia-container-cli is disabled.
+func (c *hookConfig) nvidiaContainerCliCUDACompatModeFlags(cudaCompatPolicyApplied bool) []string {
 	var flag string
 	switch c.NVIDIAContainerRuntimeConfig.Modes.Legacy.CUDACompatMode {
 	case config.CUDACompatModeLdconfig:
 		flag = "--cuda-compat-mode=ldconfig"
+		if cudaCompatPolicyApplied {
+			flag = "--cuda-compat-mode=disabled"
+		}
 	case config.CUDACompatModeMount:
 		flag = "--cuda-compat-mode=mount"
 	case config.CUDACompatModeDisabled, config.CUDACompatModeHook:
@@ -127,3 +142,18 @@ func (c *hookConfig) nvidiaContainerCliCUDACompatModeFlags() []string {
 	}
 	return []string{flag}
 }
+
+// resolveAutoDriverRoot returns the driver root to use if the configured root
+// is 'auto'. The driver root discovered by the NVIDIA Container Runtime is used
+// if specified. Otherwise, as is the case if the hook is not invoked through
+// the NVIDIA Container Runtime, the driver root is discovered here.
+func resolveAutoDriverRoot(discovered string) string {
+	if discovered != "" && !root.IsAuto(discovered) {
+		return discovered
+	}
+	driverRoot, err := root.DiscoverDriverRoot()
+	if err != nil {
+		log.Printf("WARNING: failed to discover driver root: %v; using %v", err, driverRoot)
+	}
+	return driverRoot
+}
@@ -19,6 +19,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -156,3 +157,67 @@ func TestGetSwarmResourceEnvvars(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHookConfigProfile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+accept-nvidia-visible-devices-envvar-when-unprivileged = true
+
+[profiles.restricted]
+mode = "cdi"
+accept-nvidia-visible-devices-envvar-when-unprivileged = false
+`), 0600))
+
+	defer func(config *string, profile *string) {
+		configflag = config
+		profileflag = profile
+	}(configflag, profileflag)
+	configflag = &configFile
+
+	testCases := []struct {
+		description                      string
+		profile                          string
+		expectedError                    string
+		expectedMode                     string
+		expectedAcceptEnvvarUnprivileged bool
+	}{
+		{
+			description:                      "no profile",
+			expectedMode:                     "auto",
+			expectedAcceptEnvvarUnprivileged: true,
+		},
+		{
+			description:                      "profile is applied",
+			profile:                          "restricted",
+			expectedMode:                     "cdi",
+			expectedAcceptEnvvarUnprivileged: false,
+		},
+		{
+			description:                      "builtin profile is applied",
+			profile:                          "legacy",
+			expectedMode:                     "legacy",
+			expectedAcceptEnvvarUnprivileged: true,
+		},
+		{
+			description:   "undefined profile is an error",
+			profile:       "undefined",
+			expectedError: `failed to apply profile: undefined profile "undefined"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			profile := tc.profile
+			profileflag = &profile
+
+			cfg, err := getHookConfig()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMode, cfg.NVIDIAContainerRuntimeConfig.Mode)
+			require.Equal(t, tc.expectedAcceptEnvvarUnprivileged, cfg.AcceptEnvvarUnprivileged)
+		})
+	}
+}
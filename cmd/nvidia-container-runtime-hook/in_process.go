@@ -0,0 +1,249 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ocispecs "github.com/opencontainers/runtime-spec/specs-go"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containeredits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// configureInProcess configures the container without invoking the
+// nvidia-container-cli. The edits for the requested devices are generated
+// using nvcdi as is done for the automatic CDI spec generation in the NVIDIA
+// Container Runtime. These are then applied to the created container with the
+// existing nvidia-cdi-hook commands run to create symlinks and update the
+// ldcache.
+func (c *hookConfig) configureInProcess(container containerConfig, rootfs string) error {
+	logger := &logInterceptor{}
+
+	// The applier is created first so that an unsupported configuration is
+	// reported before any devices are discovered.
+	applier, err := c.newEditsApplier(logger, container, rootfs)
+	if errors.Is(err, containeredits.ErrCgroupV2) {
+		return fmt.Errorf("%w; the in-process-legacy-hook feature only supports cgroup v1 and must be disabled to use the nvidia-container-cli", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkRequirements(logger, container, rootfs); err != nil {
+		return err
+	}
+
+	edits, err := c.getInProcessEdits(logger, container.Nvidia)
+	if err != nil {
+		return err
+	}
+
+	return applier.Apply(edits)
+}
+
+// checkRequirements checks the requirements of the container image against
+// the requested devices.
+func (c *hookConfig) checkRequirements(logger logger.Interface, container containerConfig, rootfs string) error {
+	driver := root.New(
+		root.WithLogger(logger),
+		root.WithDriverRoot(c.NVIDIAContainerCLIConfig.Root),
+	)
+	if err := modifier.CheckRequirements(logger, c.Config, container.Image, driver, rootfs, container.Nvidia.Devices); err != nil {
+		return fmt.Errorf("requirements not met: %w", err)
+	}
+	return nil
+}
+
+// newContainerEditsApplier constructs the applier for container edits. This is
+// a variable so that it can be overridden in tests.
+var newContainerEditsApplier = containeredits.New
+
+// newEditsApplier creates the applier for the container edits of the created
+// container.
+func (c *hookConfig) newEditsApplier(logger logger.Interface, container containerConfig, rootfs string) (*containeredits.Applier, error) {
+	state, err := json.Marshal(ocispecs.State{
+		Version: ocispecs.Version,
+		ID:      container.ID,
+		Status:  ocispecs.StateCreating,
+		Pid:     container.Pid,
+		Bundle:  container.Bundle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal container state: %w", err)
+	}
+
+	applier, err := newContainerEditsApplier(
+		containeredits.WithLogger(logger),
+		containeredits.WithPID(container.Pid),
+		containeredits.WithRootfs(rootfs),
+		containeredits.WithContainerState(state),
+		containeredits.WithNoCgroups(c.NVIDIAContainerCLIConfig.NoCgroups),
+		containeredits.WithTracer(hookTracer),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container edits applier: %w", err)
+	}
+	return applier, nil
+}
+
+// getInProcessEdits returns the combined container edits for the devices and
+// IMEX channels requested for the container. As is the case for the
+// nvidia-container-cli, only the libraries, binaries, and device nodes that are
+// required for the requested driver capabilities are included.
+func (c *hookConfig) getInProcessEdits(logger logger.Interface, nvidia *nvidiaConfig) (*specs.ContainerEdits, error) {
+	options := []nvcdi.Option{
+		nvcdi.WithLogger(logger),
+		nvcdi.WithNVIDIACDIHookPath(c.NVIDIACTKConfig.Path),
+		nvcdi.WithDriverRoot(c.NVIDIAContainerCLIConfig.Root),
+		nvcdi.WithTracer(hookTracer),
+	}
+	if c.NVIDIAContainerRuntimeConfig.Modes.Legacy.CUDACompatMode == config.CUDACompatModeDisabled || c.Features.DisableCUDACompatLibHook.IsEnabled() {
+		options = append(options, nvcdi.WithDisabledHook(nvcdi.HookEnableCudaCompat))
+	}
+
+	cdilib, err := nvcdi.New(append(options, nvcdi.WithMode(nvcdi.ModeNvml))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct CDI library: %w", err)
+	}
+
+	commonEdits, err := cdilib.GetCommonEdits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get common CDI spec edits: %w", err)
+	}
+
+	deviceSpecs, err := selectDeviceSpecs(cdilib, nvidia)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CDI device specs: %w", err)
+	}
+
+	if len(nvidia.ImexChannels) > 0 {
+		imexlib, err := nvcdi.New(append(options, nvcdi.WithMode(nvcdi.ModeImex))...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct CDI library for IMEX channels: %w", err)
+		}
+		channelSpecs, err := selectImexChannelSpecs(imexlib, nvidia.ImexChannels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CDI device specs for IMEX channels: %w", err)
+		}
+		deviceSpecs = append(deviceSpecs, channelSpecs...)
+	}
+
+	edits := commonEdits
+	for _, deviceSpec := range deviceSpecs {
+		edits.Append(&cdi.ContainerEdits{ContainerEdits: &deviceSpec.ContainerEdits})
+	}
+	filterEditsByDriverCapabilities(edits.ContainerEdits, image.NewDriverCapabilities(nvidia.DriverCapabilities))
+	return edits.ContainerEdits, nil
+}
+
+// selectDeviceSpecs returns the device specs for the devices, MIG config
+// devices, and MIG monitor devices requested for the container.
+func selectDeviceSpecs(cdilib nvcdi.Interface, nvidia *nvidiaConfig) ([]specs.Device, error) {
+	var ids []string
+	var requestsAll bool
+	for _, id := range nvidia.Devices {
+		switch id {
+		case "all":
+			requestsAll = true
+		case "", "none", "void":
+		default:
+			ids = append(ids, id)
+		}
+	}
+
+	var allDeviceSpecs []specs.Device
+	if requestsAll || nvidia.MigConfigDevices != "" || nvidia.MigMonitorDevices != "" {
+		var err error
+		allDeviceSpecs, err = cdilib.GetAllDeviceSpecs()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var selected []specs.Device
+	if requestsAll {
+		for _, deviceSpec := range allDeviceSpecs {
+			if nvcdi.IsExcludedFromAllDevice(deviceSpec) {
+				continue
+			}
+			selected = append(selected, deviceSpec)
+		}
+	} else if len(ids) > 0 {
+		deviceSpecs, err := cdilib.GetDeviceSpecsByID(ids...)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, deviceSpecs...)
+	}
+
+	selected = append(selected, selectMIGCapabilityDeviceSpecs(allDeviceSpecs, nvidia.MigConfigDevices, nvcaps.MigConfigCap)...)
+	selected = append(selected, selectMIGCapabilityDeviceSpecs(allDeviceSpecs, nvidia.MigMonitorDevices, nvcaps.MigMonitorCap)...)
+
+	return selected, nil
+}
+
+// selectMIGCapabilityDeviceSpecs selects the devices that grant the specified
+// MIG capability for the comma-separated list of requested GPUs. The names of
+// these devices are those of the associated GPU with a -mig-config or
+// -mig-monitor suffix.
+func selectMIGCapabilityDeviceSpecs(deviceSpecs []specs.Device, requested string, migCap nvcaps.MigCap) []specs.Device {
+	if requested == "" {
+		return nil
+	}
+	gpus := make(map[string]bool)
+	for _, gpu := range strings.Split(requested, ",") {
+		gpus[strings.TrimSpace(gpu)] = true
+	}
+
+	var selected []specs.Device
+	for _, deviceSpec := range deviceSpecs {
+		if c, ok := nvcdi.GetMIGCapability(deviceSpec); !ok || c != migCap {
+			continue
+		}
+		gpu, ok := strings.CutSuffix(deviceSpec.Name, "-mig-"+string(migCap))
+		if !ok {
+			continue
+		}
+		if gpus["all"] || gpus[gpu] {
+			selected = append(selected, deviceSpec)
+		}
+	}
+	return selected
+}
+
+// selectImexChannelSpecs returns the device specs for the requested IMEX
+// channels.
+func selectImexChannelSpecs(imexlib nvcdi.Interface, channels []string) ([]specs.Device, error) {
+	for _, channel := range channels {
+		if channel == "all" {
+			return imexlib.GetAllDeviceSpecs()
+		}
+	}
+	return imexlib.GetDeviceSpecsByID(channels...)
+}
@@ -0,0 +1,251 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containeredits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// fakeCDILib implements the parts of the nvcdi.Interface that are used to
+// select device specs.
+type fakeCDILib struct {
+	nvcdi.Interface
+	deviceSpecs []specs.Device
+}
+
+func (l *fakeCDILib) GetAllDeviceSpecs() ([]specs.Device, error) {
+	return l.deviceSpecs, nil
+}
+
+func (l *fakeCDILib) GetDeviceSpecsByID(ids ...string) ([]specs.Device, error) {
+	var selected []specs.Device
+	for _, id := range ids {
+		for _, deviceSpec := range l.deviceSpecs {
+			if deviceSpec.Name == id {
+				selected = append(selected, deviceSpec)
+			}
+		}
+	}
+	return selected, nil
+}
+
+// migCapabilityDeviceSpec returns a device spec that grants the specified MIG
+// capability.
+func migCapabilityDeviceSpec(name string, migCap nvcaps.MigCap) specs.Device {
+	return specs.Device{
+		Name: name,
+		ContainerEdits: specs.ContainerEdits{
+			Mounts: []*specs.Mount{{HostPath: migCap.ProcPath(), ContainerPath: migCap.ProcPath()}},
+		},
+	}
+}
+
+func TestSelectDeviceSpecs(t *testing.T) {
+	lib := &fakeCDILib{
+		deviceSpecs: []specs.Device{
+			{Name: "0"},
+			{Name: "1"},
+			migCapabilityDeviceSpec("0-mig-config", nvcaps.MigConfigCap),
+			migCapabilityDeviceSpec("0-mig-monitor", nvcaps.MigMonitorCap),
+			migCapabilityDeviceSpec("1-mig-config", nvcaps.MigConfigCap),
+			migCapabilityDeviceSpec("1-mig-monitor", nvcaps.MigMonitorCap),
+			{Name: "2-mig-config"},
+		},
+	}
+
+	testCases := []struct {
+		description   string
+		nvidia        *nvidiaConfig
+		expectedNames []string
+	}{
+		{
+			description: "no devices",
+			nvidia:      &nvidiaConfig{Devices: []string{"none"}},
+		},
+		{
+			description:   "all devices excludes MIG capability devices",
+			nvidia:        &nvidiaConfig{Devices: []string{"all"}},
+			expectedNames: []string{"0", "1", "2-mig-config"},
+		},
+		{
+			description:   "devices by ID",
+			nvidia:        &nvidiaConfig{Devices: []string{"1"}},
+			expectedNames: []string{"1"},
+		},
+		{
+			description: "MIG config and monitor devices",
+			nvidia: &nvidiaConfig{
+				Devices:           []string{"0"},
+				MigConfigDevices:  "all",
+				MigMonitorDevices: "1",
+			},
+			expectedNames: []string{"0", "0-mig-config", "1-mig-config", "1-mig-monitor"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			deviceSpecs, err := selectDeviceSpecs(lib, tc.nvidia)
+			require.NoError(t, err)
+
+			var names []string
+			for _, deviceSpec := range deviceSpecs {
+				names = append(names, deviceSpec.Name)
+			}
+			require.EqualValues(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestSelectImexChannelSpecs(t *testing.T) {
+	lib := &fakeCDILib{
+		deviceSpecs: []specs.Device{{Name: "0"}, {Name: "1"}},
+	}
+
+	deviceSpecs, err := selectImexChannelSpecs(lib, []string{"1"})
+	require.NoError(t, err)
+	require.EqualValues(t, []specs.Device{{Name: "1"}}, deviceSpecs)
+
+	deviceSpecs, err = selectImexChannelSpecs(lib, []string{"all"})
+	require.NoError(t, err)
+	require.Len(t, deviceSpecs, 2)
+}
+
+func TestFilterEditsByDriverCapabilities(t *testing.T) {
+	mounts := func(paths ...string) []*specs.Mount {
+		var mounts []*specs.Mount
+		for _, path := range paths {
+			mounts = append(mounts, &specs.Mount{HostPath: path, ContainerPath: path})
+		}
+		return mounts
+	}
+	deviceNodes := func(paths ...string) []*specs.DeviceNode {
+		var deviceNodes []*specs.DeviceNode
+		for _, path := range paths {
+			deviceNodes = append(deviceNodes, &specs.DeviceNode{Path: path})
+		}
+		return deviceNodes
+	}
+	edits := func() *specs.ContainerEdits {
+		return &specs.ContainerEdits{
+			Mounts: mounts(
+				"/usr/lib/x86_64-linux-gnu/libcuda.so.570.86.15",
+				"/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.570.86.15",
+				"/usr/lib/x86_64-linux-gnu/libnvidia-encode.so.570.86.15",
+				"/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.570.86.15",
+				"/usr/lib/x86_64-linux-gnu/libnvidia-ngx.so.570.86.15",
+				"/usr/share/vulkan/icd.d/nvidia_icd.json",
+				"/usr/lib/xorg/modules/drivers/nvidia_drv.so",
+				"/usr/bin/nvidia-smi",
+				"/usr/bin/nvidia-cuda-mps-control",
+				"/lib/firmware/nvidia/570.86.15/gsp_ga10x.bin",
+			),
+			DeviceNodes: deviceNodes(
+				"/dev/nvidiactl",
+				"/dev/nvidia0",
+				"/dev/nvidia-uvm",
+				"/dev/nvidia-uvm-tools",
+				"/dev/nvidia-modeset",
+				"/dev/dri/card1",
+				"/dev/dri/renderD128",
+			),
+		}
+	}
+
+	testCases := []struct {
+		description         string
+		capabilities        string
+		expectedMounts      []*specs.Mount
+		expectedDeviceNodes []*specs.DeviceNode
+	}{
+		{
+			description:         "all capabilities",
+			capabilities:        "all",
+			expectedMounts:      edits().Mounts,
+			expectedDeviceNodes: edits().DeviceNodes,
+		},
+		{
+			description:  "compute and utility",
+			capabilities: "compute,utility",
+			expectedMounts: mounts(
+				"/usr/lib/x86_64-linux-gnu/libcuda.so.570.86.15",
+				"/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.570.86.15",
+				"/usr/bin/nvidia-smi",
+				"/usr/bin/nvidia-cuda-mps-control",
+				"/lib/firmware/nvidia/570.86.15/gsp_ga10x.bin",
+			),
+			expectedDeviceNodes: deviceNodes(
+				"/dev/nvidiactl",
+				"/dev/nvidia0",
+				"/dev/nvidia-uvm",
+				"/dev/nvidia-uvm-tools",
+			),
+		},
+		{
+			description:  "graphics and display",
+			capabilities: "graphics,display",
+			expectedMounts: mounts(
+				"/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.570.86.15",
+				"/usr/share/vulkan/icd.d/nvidia_icd.json",
+				"/usr/lib/xorg/modules/drivers/nvidia_drv.so",
+				"/lib/firmware/nvidia/570.86.15/gsp_ga10x.bin",
+			),
+			expectedDeviceNodes: deviceNodes(
+				"/dev/nvidiactl",
+				"/dev/nvidia0",
+				"/dev/nvidia-modeset",
+				"/dev/dri/card1",
+				"/dev/dri/renderD128",
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			e := edits()
+			filterEditsByDriverCapabilities(e, image.NewDriverCapabilities(tc.capabilities))
+			require.EqualValues(t, tc.expectedMounts, e.Mounts)
+			require.EqualValues(t, tc.expectedDeviceNodes, e.DeviceNodes)
+		})
+	}
+}
+
+func TestConfigureInProcessCgroupV2(t *testing.T) {
+	defer func(newApplier func(...containeredits.Option) (*containeredits.Applier, error)) {
+		newContainerEditsApplier = newApplier
+	}(newContainerEditsApplier)
+	newContainerEditsApplier = func(...containeredits.Option) (*containeredits.Applier, error) {
+		return nil, containeredits.ErrCgroupV2
+	}
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	hook := &hookConfig{Config: cfg}
+
+	err = hook.configureInProcess(containerConfig{ID: "ctr", Pid: 1, Nvidia: &nvidiaConfig{Devices: []string{"all"}}}, "/rootfs")
+	require.ErrorIs(t, err, containeredits.ErrCgroupV2)
+	require.ErrorContains(t, err, "the in-process-legacy-hook feature only supports cgroup v1")
+}
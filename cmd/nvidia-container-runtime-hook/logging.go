@@ -0,0 +1,149 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
+)
+
+// binaryName is the name used to look up the log file for the NVIDIA Container
+// Runtime Hook in the shared logging config.
+const binaryName = "nvidia-container-runtime-hook"
+
+// hookLog writes the output of the standard logger to the log file configured
+// for the hook. This is nil if no log file is configured.
+var hookLog *lineWriter
+
+// configureLogging writes the output of the standard logger to the log file
+// configured for the hook in the shared logging config, if any. Each line is
+// written as a record that includes the ID and bundle of the container at the
+// level indicated by its prefix, meaning that the configured log level is
+// honored. The output to STDERR is not modified so that errors are still
+// reported by the container engine.
+func (c *hookConfig) configureLogging(container containerConfig) {
+	filename := c.Logging.GetFile(binaryName)
+	if filename == "" {
+		return
+	}
+
+	fileLogger := logrus.New()
+	fileLogger.SetOutput(io.Discard)
+	// The log file is closed when the hook exits.
+	if _, err := logger.Configure(fileLogger, c.Logging.Format, c.Logging.Level, filename); err != nil {
+		log.Printf("Ignoring logging config: %v", err)
+		return
+	}
+	logger.AddContainerFields(fileLogger, container.ID, container.Bundle)
+
+	hookLog = &lineWriter{logger: fileLogger}
+	log.SetOutput(io.MultiWriter(os.Stderr, hookLog))
+}
+
+// flushLog writes the last line of the output of the standard logger to the
+// log file. If the hook is panicking, this line is the panic message and is
+// logged at the error level.
+func flushLog(panicking bool) {
+	if hookLog == nil {
+		return
+	}
+	hookLog.flush(panicking)
+}
+
+// stdTimestamp matches the timestamp prepended to each line by the standard
+// logger. This is stripped since records include their own timestamp.
+var stdTimestamp = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} `)
+
+var (
+	// hookTracer records the spans for the hook. This is nil if tracing is not
+	// configured.
+	hookTracer *tracing.Tracer
+	// prestartSpan is the span recording the prestart hook.
+	prestartSpan *tracing.Span
+)
+
+// configureTracing starts a span for the prestart hook if tracing is
+// configured. The span is a child of the span propagated by the NVIDIA
+// Container Runtime, if any.
+func (c *hookConfig) configureTracing(container containerConfig) {
+	hookTracer = tracing.NewFromConfig(&logInterceptor{}, binaryName, c.Tracing)
+	prestartSpan = hookTracer.Start(binaryName + " prestart")
+	prestartSpan.SetAttribute(tracing.ContainerIDAttribute, container.ID)
+	prestartSpan.SetAttribute(tracing.BundleAttribute, container.Bundle)
+}
+
+// logLevelPrefixes maps the prefixes used to indicate the level of a line
+// written to the standard logger to the corresponding log level. Lines without
+// a prefix are logged at the info level.
+var logLevelPrefixes = map[string]logrus.Level{
+	"ERROR: ":   logrus.ErrorLevel,
+	"WARNING: ": logrus.WarnLevel,
+	"DEBUG: ":   logrus.DebugLevel,
+}
+
+// lineWriter logs each line written to it as a record. Since a panic is only
+// raised once its message has been written, the last line is held back until
+// the next line is written or until the log is flushed when the hook exits.
+type lineWriter struct {
+	logger  *logrus.Logger
+	pending string
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	message := stdTimestamp.ReplaceAllString(string(p), "")
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		w.flush(false)
+		w.pending = line
+	}
+	return len(p), nil
+}
+
+// flush logs the pending line, if any. This is logged at the error level if
+// the hook is panicking.
+func (w *lineWriter) flush(panicking bool) {
+	if w.pending == "" {
+		return
+	}
+	level, line := parseLogLevel(w.pending)
+	if panicking {
+		level = logrus.ErrorLevel
+	}
+	w.logger.Log(level, line)
+	w.pending = ""
+}
+
+// parseLogLevel returns the log level indicated by the prefix of the specified
+// line and the line with this prefix removed.
+func parseLogLevel(line string) (logrus.Level, string) {
+	for prefix, level := range logLevelPrefixes {
+		if trimmed, ok := strings.CutPrefix(line, prefix); ok {
+			return level, trimmed
+		}
+	}
+	return logrus.InfoLevel, line
+}
@@ -0,0 +1,112 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+func TestConfigureLogging(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "nvidia-container-runtime-hook.log")
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	cfg.Logging = config.LoggingConfig{
+		Format: "json",
+		Files: map[string]string{
+			binaryName: filename,
+		},
+	}
+	hook := &hookConfig{cfg}
+
+	defer func() {
+		log.SetOutput(os.Stderr)
+		hookLog = nil
+	}()
+	hook.configureLogging(containerConfig{ID: "ctr", Bundle: "/run/bundle"})
+
+	log.Printf("first line\nsecond line")
+	flushLog(false)
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	require.Len(t, lines, 2)
+	for i, expected := range []string{"first line", "second line"} {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &record))
+		require.Equal(t, expected, record["msg"])
+		require.Equal(t, "info", record["level"])
+		require.Equal(t, "ctr", record["container-id"])
+		require.Equal(t, "/run/bundle", record["bundle"])
+	}
+}
+
+func TestConfigureLoggingLevel(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "nvidia-container-runtime-hook.log")
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	cfg.Logging = config.LoggingConfig{
+		Format: "json",
+		Level:  "warning",
+		Files: map[string]string{
+			binaryName: filename,
+		},
+	}
+	hook := &hookConfig{cfg}
+
+	defer func() {
+		log.SetOutput(os.Stderr)
+		hookLog = nil
+	}()
+	hook.configureLogging(containerConfig{ID: "ctr", Bundle: "/run/bundle"})
+
+	log.Printf("DEBUG: debug line")
+	log.Printf("info line")
+	log.Printf("WARNING: warning line")
+	log.Printf("panic line")
+	flushLog(true)
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	require.Len(t, lines, 2)
+	for i, expected := range []struct {
+		msg   string
+		level string
+	}{
+		{"warning line", "warning"},
+		{"panic line", "error"},
+	} {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &record))
+		require.Equal(t, expected.msg, record["msg"])
+		require.Equal(t, expected.level, record["level"])
+	}
+}
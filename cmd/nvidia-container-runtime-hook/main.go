@@ -23,6 +23,12 @@ var (
 	debugflag   = flag.Bool("debug", false, "enable debug output")
 	versionflag = flag.Bool("version", false, "enable version output")
 	configflag  = flag.String("config", "", "configuration file")
+	// driverRootflag is set by the NVIDIA Container Runtime to the driver root
+	// that it discovered if the configured root is 'auto'.
+	driverRootflag = flag.String("driver-root", "", "the driver root to use if the configured root is 'auto'")
+	// profileflag is set by the NVIDIA Container Runtime to the profile that
+	// was selected for the container.
+	profileflag = flag.String("profile", "", "the config profile to apply")
 )
 
 func exit() {
@@ -30,11 +36,17 @@ func exit() {
 		if _, ok := err.(runtime.Error); ok {
 			log.Println(err)
 		}
+		flushLog(true)
 		if *debugflag {
 			log.Printf("%s", debug.Stack())
 		}
+		prestartSpan.RecordError(fmt.Errorf("%v", err))
+		flushLog(false)
+		hookTracer.Flush()
 		os.Exit(1)
 	}
+	flushLog(false)
+	hookTracer.Flush()
 	os.Exit(0)
 }
 
@@ -76,18 +88,40 @@ func doPrestart() {
 	cli := hook.NVIDIAContainerCLIConfig
 
 	container := hook.getContainerConfig()
+	hook.configureLogging(container)
+	hook.configureTracing(container)
 	nvidia := container.Nvidia
 	if nvidia == nil {
 		// Not a GPU container, nothing to do.
 		return
 	}
 
-	if !hook.NVIDIAContainerRuntimeHookConfig.SkipModeDetection && info.ResolveAutoMode(&logInterceptor{}, hook.NVIDIAContainerRuntimeConfig.Mode, container.Image) != "legacy" {
-		log.Panicln("invoking the NVIDIA Container Runtime Hook directly (e.g. specifying the docker --gpus flag) is not supported. Please use the NVIDIA Container Runtime (e.g. specify the --runtime=nvidia flag) instead.")
+	if err := hook.applyDevicePolicy(&logInterceptor{}, container); err != nil {
+		log.Panicln("failed to apply device policy:", err)
 	}
 
 	rootfs := getRootfsPath(container)
 
+	if !hook.NVIDIAContainerRuntimeHookConfig.SkipModeDetection {
+		switch mode := info.ResolveAutoMode(&logInterceptor{}, hook.NVIDIAContainerRuntimeConfig.Mode, container.Image); mode {
+		case "legacy":
+		case "cdi":
+			if err := hook.configureCDI(container, rootfs); err != nil {
+				log.Panicln("failed to configure container using CDI:", err)
+			}
+			return
+		default:
+			log.Panicf("invoking the NVIDIA Container Runtime Hook directly (e.g. specifying the docker --gpus flag) is not supported in %v mode. Please use the NVIDIA Container Runtime (e.g. specify the --runtime=nvidia flag) instead.\n", mode)
+		}
+	}
+
+	if hook.Features.InProcessLegacyHook.IsEnabled() {
+		if err := hook.configureInProcess(container, rootfs); err != nil {
+			log.Panicln("failed to configure container:", err)
+		}
+		return
+	}
+
 	args := []string{getCLIPath(cli)}
 	if cli.Root != "" {
 		args = append(args, fmt.Sprintf("--root=%s", cli.Root))
@@ -114,7 +148,8 @@ func doPrestart() {
 	}
 	args = append(args, "configure")
 
-	args = append(args, hook.nvidiaContainerCliCUDACompatModeFlags()...)
+	cudaCompatPolicyApplied := hook.applyCUDACompatPolicy(rootfs)
+	args = append(args, hook.nvidiaContainerCliCUDACompatModeFlags(cudaCompatPolicyApplied)...)
 
 	if ldconfigPath := cli.NormalizeLDConfigPath(); ldconfigPath != "" {
 		args = append(args, fmt.Sprintf("--ldconfig=%s", ldconfigPath))
@@ -150,6 +185,10 @@ func doPrestart() {
 	args = append(args, rootfs)
 
 	env := append(os.Environ(), cli.Environment...)
+	// The log and spans are flushed before exec-ing since deferred calls are
+	// not run.
+	flushLog(false)
+	hookTracer.Flush()
 	//nolint:gosec // TODO: Can we harden this so that there is less risk of command injection?
 	err = syscall.Exec(args[0], args, env)
 	log.Panicln("exec failed:", err)
@@ -179,7 +179,7 @@ func TestDuplicateHook(t *testing.T) {
 // testing.
 func addNVIDIAHook(spec *specs.Spec) error {
 	logger, _ := testlog.NewNullLogger()
-	m := modifier.NewStableRuntimeModifier(logger, nvidiaHook)
+	m := modifier.NewStableRuntimeModifier(logger, nvidiaHook, "", "")
 	return m.Modify(spec)
 }
 
@@ -44,12 +44,15 @@ type Options struct {
 	// mount.
 	ExecutablePath string
 	// EnabledCDI indicates whether CDI should be enabled.
-	EnableCDI     bool
-	RuntimeName   string
-	RuntimeDir    string
-	SetAsDefault  bool
-	RestartMode   string
-	HostRootMount string
+	EnableCDI   bool
+	RuntimeName string
+	// RuntimeProfiles are the config profiles for which dedicated runtimes
+	// are configured.
+	RuntimeProfiles []string
+	RuntimeDir      string
+	SetAsDefault    bool
+	RestartMode     string
+	HostRootMount   string
 }
 
 // ParseArgs parses the command line arguments to the CLI
@@ -110,6 +113,7 @@ func (o Options) UpdateConfig(cfg engine.Interface) error {
 		operator.WithNvidiaRuntimeName(o.RuntimeName),
 		operator.WithSetAsDefault(o.SetAsDefault),
 		operator.WithRoot(o.RuntimeDir),
+		operator.WithProfiles(o.RuntimeProfiles...),
 	)
 	for name, runtime := range runtimes {
 		err := cfg.AddRuntime(name, runtime.Path, runtime.SetAsDefault)
@@ -131,6 +135,7 @@ func (o Options) RevertConfig(cfg engine.Interface) error {
 		operator.WithNvidiaRuntimeName(o.RuntimeName),
 		operator.WithSetAsDefault(o.SetAsDefault),
 		operator.WithRoot(o.RuntimeDir),
+		operator.WithProfiles(o.RuntimeProfiles...),
 	)
 	for name := range runtimes {
 		err := cfg.RemoveRuntime(name)
@@ -144,6 +149,19 @@ func (o Options) RevertConfig(cfg engine.Interface) error {
 
 // Restart restarts the specified service
 func (o Options) Restart(service string, withSignal func(string) error) error {
+	err := o.restart(service, withSignal)
+	switch {
+	case o.RestartMode == restartModeNone:
+		RuntimeRestarts.Inc(service, o.RestartMode, "skipped")
+	case err != nil:
+		RuntimeRestarts.Inc(service, o.RestartMode, "failure")
+	default:
+		RuntimeRestarts.Inc(service, o.RestartMode, "success")
+	}
+	return err
+}
+
+func (o Options) restart(service string, withSignal func(string) error) error {
 	switch o.RestartMode {
 	case restartModeNone:
 		logrus.Warningf("Skipping restart of %v due to --restart-mode=%v", service, o.RestartMode)
@@ -0,0 +1,26 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package container
+
+import "github.com/NVIDIA/nvidia-container-toolkit/internal/metrics"
+
+// RuntimeRestarts counts the restarts of the container runtime by outcome.
+var RuntimeRestarts = metrics.NewCounter(
+	"nvidia_ctk_installer_runtime_restarts_total",
+	"The number of container runtime restarts triggered by the installer.",
+	"service", "mode", "result",
+)
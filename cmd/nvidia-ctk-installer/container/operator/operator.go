@@ -28,6 +28,8 @@ type Runtime struct {
 	name         string
 	Path         string
 	SetAsDefault bool
+	// Profile is the config profile selected by the runtime, if any.
+	Profile string
 }
 
 // Runtimes defines a set of runtimes to be configured for use in the GPU Operator
@@ -37,6 +39,7 @@ type config struct {
 	root              string
 	nvidiaRuntimeName string
 	setAsDefault      bool
+	profiles          []string
 }
 
 // GetRuntimes returns the set of runtimes to be configured for use with the GPU Operator.
@@ -57,6 +60,12 @@ func GetRuntimes(opts ...Option) Runtimes {
 	for _, mode := range modes {
 		runtimes.add(c.modeRuntime(mode))
 	}
+	for _, profile := range c.profiles {
+		if _, exists := runtimes["nvidia-"+profile]; exists {
+			continue
+		}
+		runtimes.add(c.profileRuntime(profile))
+	}
 	return runtimes
 }
 
@@ -82,6 +91,9 @@ func (c config) nvidiaRuntime() Runtime {
 		"nvidia-cdi":    {},
 		"nvidia-legacy": {},
 	}
+	for _, profile := range c.profiles {
+		predefinedRuntimes["nvidia-"+profile] = struct{}{}
+	}
 	name := c.nvidiaRuntimeName
 	if _, isPredefinedRuntime := predefinedRuntimes[name]; isPredefinedRuntime {
 		name = defaultRuntimeName
@@ -94,6 +106,14 @@ func (c config) modeRuntime(mode string) Runtime {
 	return c.newRuntime("nvidia-"+mode, "nvidia-container-runtime."+mode)
 }
 
+// profileRuntime creates a runtime for the specified config profile. The
+// profile is selected by the name of the runtime executable.
+func (c config) profileRuntime(profile string) Runtime {
+	runtime := c.newRuntime("nvidia-"+profile, "nvidia-container-runtime."+profile)
+	runtime.Profile = profile
+	return runtime
+}
+
 // newRuntime creates a runtime based on the configuration
 func (c config) newRuntime(name string, binary string) Runtime {
 	return Runtime{
@@ -126,3 +146,10 @@ func WithSetAsDefault(set bool) Option {
 		c.setAsDefault = set
 	}
 }
+
+// WithProfiles adds a runtime for each of the specified config profiles.
+func WithProfiles(profiles ...string) Option {
+	return func(c *config) {
+		c.profiles = profiles
+	}
+}
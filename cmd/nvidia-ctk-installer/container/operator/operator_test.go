@@ -27,6 +27,7 @@ func TestOptions(t *testing.T) {
 	testCases := []struct {
 		setAsDefault           bool
 		nvidiaRuntimeName      string
+		profiles               []string
 		expectedDefaultRuntime string
 		expectedRuntimes       Runtimes
 	}{
@@ -123,6 +124,32 @@ func TestOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			setAsDefault:           true,
+			nvidiaRuntimeName:      "nvidia-restricted",
+			profiles:               []string{"restricted", "cdi"},
+			expectedDefaultRuntime: "nvidia-restricted",
+			expectedRuntimes: Runtimes{
+				"nvidia": Runtime{
+					name: "nvidia",
+					Path: "/usr/bin/nvidia-container-runtime",
+				},
+				"nvidia-cdi": Runtime{
+					name: "nvidia-cdi",
+					Path: "/usr/bin/nvidia-container-runtime.cdi",
+				},
+				"nvidia-legacy": Runtime{
+					name: "nvidia-legacy",
+					Path: "/usr/bin/nvidia-container-runtime.legacy",
+				},
+				"nvidia-restricted": Runtime{
+					name:         "nvidia-restricted",
+					Path:         "/usr/bin/nvidia-container-runtime.restricted",
+					SetAsDefault: true,
+					Profile:      "restricted",
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -131,6 +158,7 @@ func TestOptions(t *testing.T) {
 				WithNvidiaRuntimeName(tc.nvidiaRuntimeName),
 				WithSetAsDefault(tc.setAsDefault),
 				WithRoot("/usr/bin"),
+				WithProfiles(tc.profiles...),
 			)
 
 			require.EqualValues(t, tc.expectedRuntimes, runtimes)
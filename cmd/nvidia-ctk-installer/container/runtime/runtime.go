@@ -116,6 +116,8 @@ func (opts *Options) Validate(logger logger.Interface, c *cli.Context, runtime s
 	// We set this option here to ensure that it is available in future calls.
 	opts.RuntimeDir = toolkitRoot
 
+	opts.RuntimeProfiles = to.RuntimeProfiles.Value()
+
 	if !c.IsSet("enable-cdi-in-runtime") {
 		opts.EnableCDI = to.CDI.Enabled
 	}
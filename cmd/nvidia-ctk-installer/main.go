@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sys/unix"
@@ -41,6 +42,8 @@ type options struct {
 	pidFile    string
 	sourceRoot string
 
+	metricsAddress string
+
 	toolkitOptions toolkit.Options
 	runtimeOptions runtime.Options
 }
@@ -136,6 +139,12 @@ func (a app) build() *cli.App {
 			Destination: &options.pidFile,
 			EnvVars:     []string{"TOOLKIT_PID_FILE", "PID_FILE"},
 		},
+		&cli.StringFlag{
+			Name:        "metrics-address",
+			Usage:       "the address (e.g. :9402) at which Prometheus metrics are served. If this is empty, no metrics are served",
+			Destination: &options.metricsAddress,
+			EnvVars:     []string{"METRICS_ADDRESS"},
+		},
 	}
 
 	c.Flags = append(c.Flags, toolkit.Flags(&options.toolkitOptions)...)
@@ -183,6 +192,10 @@ func (a *app) Run(c *cli.Context, o *options) error {
 	}
 	defer a.shutdown(o.pidFile)
 
+	if o.metricsAddress != "" {
+		a.serveMetrics(o.metricsAddress)
+	}
+
 	if len(o.toolkitOptions.ContainerRuntimeRuntimes.Value()) == 0 {
 		lowlevelRuntimePaths, err := runtime.GetLowlevelRuntimePaths(&o.runtimeOptions, o.runtime)
 		if err != nil {
@@ -193,7 +206,9 @@ func (a *app) Run(c *cli.Context, o *options) error {
 		o.toolkitOptions.ContainerRuntimeRuntimes = *cli.NewStringSlice(lowlevelRuntimePaths...)
 	}
 
+	start := time.Now()
 	err = a.toolkit.Install(c, &o.toolkitOptions)
+	installDuration.Set(time.Since(start).Seconds())
 	if err != nil {
 		return fmt.Errorf("unable to install toolkit: %v", err)
 	}
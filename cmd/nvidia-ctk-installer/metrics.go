@@ -0,0 +1,62 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk-installer/container"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk-installer/toolkit"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/metrics"
+)
+
+var (
+	installDuration = metrics.NewGauge(
+		"nvidia_ctk_installer_install_duration_seconds",
+		"The time taken to install the NVIDIA Container Toolkit.",
+	)
+
+	installerMetrics = metrics.NewRegistry(
+		installDuration,
+		container.RuntimeRestarts,
+		toolkit.CDIGenerationSeconds,
+		toolkit.CDISpecDevices,
+	)
+)
+
+// serveMetrics serves the installer metrics in the Prometheus text format at
+// /metrics on the specified address. The server runs until the installer
+// exits.
+func (a *app) serveMetrics(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", installerMetrics)
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	a.logger.Infof("Serving metrics on %v", address)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.logger.Warningf("Failed to serve metrics: %v", err)
+		}
+	}()
+}
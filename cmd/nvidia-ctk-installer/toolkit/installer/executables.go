@@ -33,9 +33,12 @@ import (
 type executable struct {
 	requiresKernelModule bool
 	path                 string
-	symlink              string
-	args                 []string
-	env                  map[string]string
+	// name is the name of the installed wrapper. If this is not set, the name
+	// of the executable is used.
+	name    string
+	symlink string
+	args    []string
+	env     map[string]string
 }
 
 func (t *toolkitInstaller) collectExecutables(destDir string) ([]Installer, error) {
@@ -51,7 +54,7 @@ func (t *toolkitInstaller) collectExecutables(destDir string) ([]Installer, erro
 			path: "nvidia-cdi-hook",
 		},
 	}
-	for _, runtime := range operator.GetRuntimes() {
+	for _, runtime := range operator.GetRuntimes(operator.WithProfiles(t.runtimeProfiles...)) {
 		e := executable{
 			path:                 runtime.Path,
 			requiresKernelModule: true,
@@ -59,6 +62,13 @@ func (t *toolkitInstaller) collectExecutables(destDir string) ([]Installer, erro
 				"XDG_CONFIG_HOME": configHome,
 			},
 		}
+		// A runtime for a profile wraps the nvidia-container-runtime and
+		// selects the profile explicitly.
+		if runtime.Profile != "" {
+			e.path = "nvidia-container-runtime"
+			e.name = runtime.Path
+			e.args = []string{"--profile=" + runtime.Profile}
+		}
 		executables = append(executables, e)
 	}
 	executables = append(executables,
@@ -92,6 +102,7 @@ func (t *toolkitInstaller) collectExecutables(destDir string) ([]Installer, erro
 
 		w := &wrapper{
 			Source:            executablePath,
+			Name:              executable.name,
 			WrappedExecutable: dotRealFilename,
 			CheckModules:      executable.requiresKernelModule,
 			Args:              executable.args,
@@ -120,7 +131,10 @@ func (t *toolkitInstaller) collectExecutables(destDir string) ([]Installer, erro
 }
 
 type wrapper struct {
-	Source            string
+	Source string
+	// Name is the name of the wrapper. If this is not set, the name of the
+	// source is used.
+	Name              string
 	Envvars           map[string]string
 	WrappedExecutable string
 	CheckModules      bool
@@ -148,7 +162,11 @@ func (w *wrapper) Install(destDir string) error {
 	if err != nil {
 		return fmt.Errorf("failed to render wrapper: %w", err)
 	}
-	wrapperFile := filepath.Join(destDir, filepath.Base(w.Source))
+	name := w.Name
+	if name == "" {
+		name = filepath.Base(w.Source)
+	}
+	wrapperFile := filepath.Join(destDir, name)
 	return installContent(content, wrapperFile, mode|0111)
 }
 
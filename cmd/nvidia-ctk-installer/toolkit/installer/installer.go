@@ -37,6 +37,9 @@ type toolkitInstaller struct {
 	logger       logger.Interface
 	ignoreErrors bool
 	sourceRoot   string
+	// runtimeProfiles are the config profiles for which runtimes are
+	// installed.
+	runtimeProfiles []string
 
 	artifactRoot *artifactRoot
 
@@ -45,3 +45,10 @@ func WithSourceRoot(sourceRoot string) Option {
 		ti.sourceRoot = sourceRoot
 	}
 }
+
+// WithRuntimeProfiles sets the config profiles for which runtimes are installed.
+func WithRuntimeProfiles(profiles ...string) Option {
+	return func(ti *toolkitInstaller) {
+		ti.runtimeProfiles = profiles
+	}
+}
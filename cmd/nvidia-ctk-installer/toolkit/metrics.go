@@ -0,0 +1,34 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package toolkit
+
+import "github.com/NVIDIA/nvidia-container-toolkit/internal/metrics"
+
+var (
+	// CDIGenerationSeconds records the time taken to generate the CDI spec
+	// for management containers.
+	CDIGenerationSeconds = metrics.NewGauge(
+		"nvidia_ctk_installer_cdi_generation_duration_seconds",
+		"The time taken to generate the CDI spec for management containers.",
+	)
+	// CDISpecDevices records the number of devices in each generated CDI spec.
+	CDISpecDevices = metrics.NewGauge(
+		"nvidia_ctk_installer_cdi_spec_devices",
+		"The number of devices in each CDI spec generated by the installer.",
+		"spec",
+	)
+)
@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"tags.cncf.io/container-device-interface/pkg/cdi"
@@ -41,6 +43,10 @@ const (
 	configFilename = "config.toml"
 )
 
+// profileNamePattern matches the config profile names that can be used to
+// construct a runtime handler name.
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
 type cdiOptions struct {
 	Enabled   bool
 	outputDir string
@@ -64,6 +70,10 @@ type Options struct {
 
 	ContainerRuntimeRuntimes cli.StringSlice
 
+	// RuntimeProfiles specifies the config profiles for which dedicated
+	// runtimes (named nvidia-<profile>) are installed.
+	RuntimeProfiles cli.StringSlice
+
 	ContainerRuntimeHookSkipModeDetection bool
 
 	ContainerCLIDebug string
@@ -142,6 +152,12 @@ func Flags(opts *Options) []cli.Flag {
 			Destination: &opts.ContainerRuntimeRuntimes,
 			EnvVars:     []string{"NVIDIA_CONTAINER_RUNTIME_RUNTIMES"},
 		},
+		&cli.StringSliceFlag{
+			Name:        "runtime-profiles",
+			Usage:       "specify the config profiles for which a dedicated nvidia-<profile> runtime is installed",
+			Destination: &opts.RuntimeProfiles,
+			EnvVars:     []string{"RUNTIME_PROFILES"},
+		},
 		&cli.BoolFlag{
 			Name:        "nvidia-container-runtime-hook.skip-mode-detection",
 			Value:       true,
@@ -259,6 +275,12 @@ func (t *Installer) ValidateOptions(opts *Options) error {
 		opts.CDI.Enabled = false
 	}
 
+	for _, profile := range opts.RuntimeProfiles.Value() {
+		if !profileNamePattern.MatchString(profile) {
+			return fmt.Errorf("invalid --runtime-profiles value: %v", profile)
+		}
+	}
+
 	isDisabled := false
 	for _, mode := range opts.createDeviceNodes.Value() {
 		if mode != "" && mode != "none" && mode != "control" {
@@ -301,6 +323,7 @@ func (t *Installer) Install(cli *cli.Context, opts *Options) error {
 		installer.WithLogger(t.logger),
 		installer.WithSourceRoot(t.sourceRoot),
 		installer.WithIgnoreErrors(opts.ignoreErrors),
+		installer.WithRuntimeProfiles(opts.RuntimeProfiles.Value()...),
 	)
 	if err != nil {
 		if !opts.ignoreErrors {
@@ -496,6 +519,11 @@ func (t *Installer) generateCDISpec(opts *Options, nvidiaCDIHookPath string) err
 		return nil
 	}
 	t.logger.Info("Generating CDI spec for management containers")
+	start := time.Now()
+	defer func() {
+		CDIGenerationSeconds.Set(time.Since(start).Seconds())
+	}()
+
 	cdilib, err := nvcdi.New(
 		nvcdi.WithLogger(t.logger),
 		nvcdi.WithMode(nvcdi.ModeManagement),
@@ -532,6 +560,7 @@ func (t *Installer) generateCDISpec(opts *Options, nvidiaCDIHookPath string) err
 	if err != nil {
 		return fmt.Errorf("failed to save CDI spec for management containers: %v", err)
 	}
+	CDISpecDevices.Set(float64(len(spec.Raw().Devices)), name)
 
 	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/urfave/cli/v2"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
@@ -37,6 +39,12 @@ import (
 
 const (
 	allDeviceName = "all"
+
+	imexChannelClass = "imex-channel"
+
+	// driverRootAnnotation records the auto-discovered driver root in the
+	// generated spec.
+	driverRootAnnotation = "nvidia.com/driver-root"
 )
 
 type command struct {
@@ -58,11 +66,22 @@ type options struct {
 	configSearchPaths  cli.StringSlice
 	librarySearchPaths cli.StringSlice
 
+	gpuAffinityDevices bool
+	cleanupHook        bool
+
 	csv struct {
 		files          cli.StringSlice
 		ignorePatterns cli.StringSlice
 	}
 
+	imex struct {
+		channels     cli.StringSlice
+		channelOwner string
+		allDevice    bool
+		uid          uint32
+		gid          uint32
+	}
+
 	// the following are used for dependency injection during spec generation.
 	nvmllib nvml.Interface
 }
@@ -130,7 +149,7 @@ func (m command) build() *cli.Command {
 		},
 		&cli.StringFlag{
 			Name:        "driver-root",
-			Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be included in the CDI specification.",
+			Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be included in the CDI specification. If set to 'auto', the known containerized driver locations (e.g. /run/nvidia/driver) and the host root are probed and the first root containing a driver is used.",
 			Destination: &opts.driverRoot,
 		},
 		&cli.StringSliceFlag{
@@ -167,7 +186,7 @@ func (m command) build() *cli.Command {
 		},
 		&cli.StringSliceFlag{
 			Name:        "csv.file",
-			Usage:       "The path to the list of CSV files to use when generating the CDI specification in CSV mode.",
+			Usage:       "The path to the list of CSV files to use when generating the CDI specification in CSV mode. Structured mount spec files (.yaml, .yml, or .json) are also supported.",
 			Value:       cli.NewStringSlice(csv.DefaultFileList()...),
 			Destination: &opts.csv.files,
 		},
@@ -176,6 +195,32 @@ func (m command) build() *cli.Command {
 			Usage:       "Specify a pattern the CSV mount specifications.",
 			Destination: &opts.csv.ignorePatterns,
 		},
+		&cli.StringSliceFlag{
+			Name:        "imex.channel",
+			Aliases:     []string{"imex.channels"},
+			Usage:       "The IMEX channel IDs (e.g. 0) or ranges of IDs (e.g. 0-2047) to generate a CDI specification for in IMEX mode. The device nodes for these channels need not exist. If no channels are specified, the existing channels are used.",
+			Destination: &opts.imex.channels,
+		},
+		&cli.StringFlag{
+			Name:        "imex.channel-owner",
+			Usage:       "The owner of the IMEX channel device nodes in the container specified as UID:GID. This only applies to IMEX mode.",
+			Destination: &opts.imex.channelOwner,
+		},
+		&cli.BoolFlag{
+			Name:        "imex.all-device",
+			Usage:       "Generate an 'all' device that includes all IMEX channels. Since this grants access to all IMEX channels, it is not generated by default. This only applies to IMEX mode.",
+			Destination: &opts.imex.allDevice,
+		},
+		&cli.BoolFlag{
+			Name:        "gpu-affinity-devices",
+			Usage:       "Generate a GPU_NAME-gpu-affinity device for each GPU that also includes the RDMA devices (NICs) attached to the same PCIe switch as the GPU. This only applies to NVML mode.",
+			Destination: &opts.gpuAffinityDevices,
+		},
+		&cli.BoolFlag{
+			Name:        "cleanup-hook",
+			Usage:       "Add a poststop hook that removes the host artifacts tracked for a container once it has stopped.",
+			Destination: &opts.cleanupHook,
+		},
 	}
 
 	return &c
@@ -202,6 +247,17 @@ func (m command) validateFlags(c *cli.Context, opts *options) error {
 		}
 	}
 
+	if opts.mode == string(nvcdi.ModeImex) && c != nil && !c.IsSet("class") {
+		opts.class = imexChannelClass
+	}
+	if opts.imex.channelOwner != "" {
+		uid, gid, err := parseOwner(opts.imex.channelOwner)
+		if err != nil {
+			return fmt.Errorf("invalid IMEX channel owner: %w", err)
+		}
+		opts.imex.uid, opts.imex.gid = uid, gid
+	}
+
 	opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
 
 	if outputFileFormat := formatFromFilename(opts.output); outputFileFormat != "" {
@@ -252,7 +308,36 @@ func formatFromFilename(filename string) string {
 	return ""
 }
 
+// parseOwner parses an owner of the form UID:GID.
+func parseOwner(owner string) (uint32, uint32, error) {
+	uidString, gidString, found := strings.Cut(owner, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("expected UID:GID; got %q", owner)
+	}
+	uid, err := strconv.ParseUint(uidString, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid UID %q: %w", uidString, err)
+	}
+	gid, err := strconv.ParseUint(gidString, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid GID %q: %w", gidString, err)
+	}
+	return uint32(uid), uint32(gid), nil
+}
+
 func (m command) generateSpec(opts *options) (spec.Interface, error) {
+	var annotations map[string]string
+	if root.IsAuto(opts.driverRoot) {
+		driverRoot, err := root.DiscoverDriverRoot(root.WithAutoDiscoveryLogger(m.logger))
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover driver root: %v", err)
+		}
+		opts.driverRoot = driverRoot
+		annotations = map[string]string{
+			driverRootAnnotation: driverRoot,
+		}
+	}
+
 	var deviceNamers []nvcdi.DeviceNamer
 	for _, strategy := range opts.deviceNameStrategies.Value() {
 		deviceNamer, err := nvcdi.NewDeviceNamer(strategy)
@@ -262,7 +347,7 @@ func (m command) generateSpec(opts *options) (spec.Interface, error) {
 		deviceNamers = append(deviceNamers, deviceNamer)
 	}
 
-	cdilib, err := nvcdi.New(
+	cdilibOptions := []nvcdi.Option{
 		nvcdi.WithLogger(m.logger),
 		nvcdi.WithDriverRoot(opts.driverRoot),
 		nvcdi.WithDevRoot(opts.devRoot),
@@ -276,7 +361,15 @@ func (m command) generateSpec(opts *options) (spec.Interface, error) {
 		nvcdi.WithCSVIgnorePatterns(opts.csv.ignorePatterns.Value()),
 		// We set the following to allow for dependency injection:
 		nvcdi.WithNvmlLib(opts.nvmllib),
-	)
+		nvcdi.WithImexChannels(opts.imex.channels.Value()...),
+		nvcdi.WithGPUAffinityDevices(opts.gpuAffinityDevices),
+		nvcdi.WithCleanupHook(opts.cleanupHook),
+	}
+	if opts.imex.channelOwner != "" {
+		cdilibOptions = append(cdilibOptions, nvcdi.WithImexChannelOwner(opts.imex.uid, opts.imex.gid))
+	}
+
+	cdilib, err := nvcdi.New(cdilibOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CDI library: %v", err)
 	}
@@ -291,16 +384,26 @@ func (m command) generateSpec(opts *options) (spec.Interface, error) {
 		return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
 	}
 
+	var mergedDeviceOptions []transform.MergedDeviceOption
+	// An 'all' device for IMEX channels is only generated if requested.
+	if opts.mode != string(nvcdi.ModeImex) || opts.imex.allDevice {
+		mergedDeviceOptions = append(mergedDeviceOptions,
+			transform.WithName(allDeviceName),
+			transform.WithSkipIfExists(true),
+			// The mig-config, mig-monitor, and gpu-affinity devices must be
+			// requested explicitly.
+			transform.WithFilter(nvcdi.IsExcludedFromAllDevice),
+		)
+	}
+
 	return spec.New(
 		spec.WithVendor(opts.vendor),
 		spec.WithClass(opts.class),
 		spec.WithDeviceSpecs(deviceSpecs),
 		spec.WithEdits(*commonEdits.ContainerEdits),
+		spec.WithAnnotations(annotations),
 		spec.WithFormat(opts.format),
-		spec.WithMergedDeviceOptions(
-			transform.WithName(allDeviceName),
-			transform.WithSkipIfExists(true),
-		),
+		spec.WithMergedDeviceOptions(mergedDeviceOptions...),
 		spec.WithPermissions(0644),
 	)
 }
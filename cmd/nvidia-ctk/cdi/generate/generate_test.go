@@ -141,6 +141,9 @@ containerEdits:
 				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
 					return 0, nvml.SUCCESS
 				}
+				(d.(*dgxa100.Device)).GetVirtualizationModeFunc = func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return nvml.GPU_VIRTUALIZATION_MODE_NONE, nvml.SUCCESS
+				}
 			}
 			tc.options.nvmllib = server
 
@@ -155,3 +158,31 @@ containerEdits:
 		})
 	}
 }
+
+func TestParseOwner(t *testing.T) {
+	testCases := []struct {
+		owner         string
+		expectedUID   uint32
+		expectedGID   uint32
+		expectedError bool
+	}{
+		{owner: "1000:1001", expectedUID: 1000, expectedGID: 1001},
+		{owner: "0:0"},
+		{owner: "1000", expectedError: true},
+		{owner: "user:group", expectedError: true},
+		{owner: "-1:0", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.owner, func(t *testing.T) {
+			uid, gid, err := parseOwner(tc.owner)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedUID, uid)
+			require.Equal(t, tc.expectedGID, gid)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 	transformroot "github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform/root"
 )
 
@@ -40,9 +41,10 @@ type transformOptions struct {
 
 type options struct {
 	transformOptions
-	from       string
-	to         string
-	relativeTo string
+	from        string
+	to          string
+	relativeTo  string
+	mappingFile string
 }
 
 // NewCommand constructs a generate-cdi command with the specified logger
@@ -80,6 +82,11 @@ func (m command) build() *cli.Command {
 			Value:       "-",
 			Destination: &opts.input,
 		},
+		&cli.StringFlag{
+			Name:        "mapping-file",
+			Usage:       "Specify a YAML or JSON file containing an ordered list of prefix rules to apply. This cannot be combined with --from, --to, or --relative-to.",
+			Destination: &opts.mappingFile,
+		},
 		&cli.StringFlag{
 			Name:        "output",
 			Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
@@ -103,6 +110,14 @@ func (m command) build() *cli.Command {
 }
 
 func (m command) validateFlags(c *cli.Context, opts *options) error {
+	if opts.mappingFile != "" {
+		for _, flag := range []string{"from", "to", "relative-to"} {
+			if c.IsSet(flag) {
+				return fmt.Errorf("--%v cannot be specified with --mapping-file", flag)
+			}
+		}
+		return nil
+	}
 	switch opts.relativeTo {
 	case "host":
 	case "container":
@@ -118,11 +133,12 @@ func (m command) run(c *cli.Context, opts *options) error {
 		return fmt.Errorf("failed to load CDI specification: %w", err)
 	}
 
-	err = transformroot.New(
-		transformroot.WithRoot(opts.from),
-		transformroot.WithTargetRoot(opts.to),
-		transformroot.WithRelativeTo(opts.relativeTo),
-	).Transform(spec.Raw())
+	transformer, err := opts.getTransformer()
+	if err != nil {
+		return err
+	}
+
+	err = transformer.Transform(spec.Raw())
 	if err != nil {
 		return fmt.Errorf("failed to transform CDI specification: %w", err)
 	}
@@ -130,6 +146,26 @@ func (m command) run(c *cli.Context, opts *options) error {
 	return opts.Save(spec)
 }
 
+func (o options) getTransformer() (transform.Transformer, error) {
+	if o.mappingFile == "" {
+		return transformroot.New(
+			transformroot.WithRoot(o.from),
+			transformroot.WithTargetRoot(o.to),
+			transformroot.WithRelativeTo(o.relativeTo),
+		), nil
+	}
+
+	mapping, err := transformroot.LoadMapping(o.mappingFile)
+	if err != nil {
+		return nil, err
+	}
+	transformer, err := transformroot.NewFromMapping(mapping)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mapping file %v: %w", o.mappingFile, err)
+	}
+	return transformer, nil
+}
+
 // Load lodas the input CDI specification
 func (o transformOptions) Load() (spec.Interface, error) {
 	contents, err := o.getContents()
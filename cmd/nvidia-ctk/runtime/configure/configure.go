@@ -22,6 +22,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	toolkitconfig "github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/containerd"
@@ -74,10 +75,11 @@ type config struct {
 	hookFilePath   string
 
 	nvidiaRuntime struct {
-		name         string
-		path         string
-		hookPath     string
-		setAsDefault bool
+		name           string
+		path           string
+		hookPath       string
+		setAsDefault   bool
+		configFilePath string
 	}
 
 	// cdi-specific options
@@ -159,6 +161,12 @@ func (m command) build() *cli.Command {
 			Value:       defaultNVIDIARuntimeHookExpecutablePath,
 			Destination: &config.nvidiaRuntime.hookPath,
 		},
+		&cli.StringFlag{
+			Name:        "nvidia-runtime-config",
+			Usage:       "specify the path to the config file of the NVIDIA Container Runtime. This is used to validate the low-level runtimes that are configured",
+			Value:       toolkitconfig.GetConfigFilePath(),
+			Destination: &config.nvidiaRuntime.configFilePath,
+		},
 		&cli.BoolFlag{
 			Name:        "nvidia-set-as-default",
 			Aliases:     []string{"set-as-default"},
@@ -287,6 +295,8 @@ func (m command) configureConfigFile(c *cli.Context, config *config) error {
 		return fmt.Errorf("unable to load config for runtime %v: %v", config.runtime, err)
 	}
 
+	m.validateLowLevelRuntimes(cfg, config.nvidiaRuntime.configFilePath)
+
 	err = cfg.AddRuntime(
 		config.nvidiaRuntime.name,
 		config.nvidiaRuntime.path,
@@ -0,0 +1,115 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"fmt"
+	"sort"
+
+	toolkitconfig "github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+)
+
+// validateLowLevelRuntimes checks the low-level runtime candidates of the
+// NVIDIA Container Runtime config at the specified path against the low-level
+// runtimes of the container engine. Problems are logged as warnings since the
+// runtimes may be installed after the engine is configured.
+func (m command) validateLowLevelRuntimes(cfg engine.Interface, toolkitConfigFilePath string) {
+	toolkitConfig, err := loadToolkitConfig(toolkitConfigFilePath)
+	if err != nil {
+		m.logger.Warningf("Skipping validation of low-level runtimes: failed to load config: %v", err)
+		return
+	}
+
+	problems := checkLowLevelRuntimes(
+		m.logger,
+		toolkitConfig.NVIDIAContainerRuntimeConfig,
+		engine.GetBinaryPathsForRuntimes(cfg),
+	)
+	for _, problem := range problems {
+		m.logger.Warningf("%v", problem)
+	}
+}
+
+// loadToolkitConfig loads the NVIDIA Container Runtime config from the
+// specified path. The default config is returned if the file does not exist.
+func loadToolkitConfig(path string) (*toolkitconfig.Config, error) {
+	cfg, err := toolkitconfig.New(
+		toolkitconfig.WithConfigFile(path),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Config()
+}
+
+// checkLowLevelRuntimes checks that for each of the runtimes option and the
+// entries of the low-level-runtimes option, one of the candidates exists and
+// that the candidate that is used corresponds to one of the specified engine
+// runtimes. The problems that are found are returned.
+func checkLowLevelRuntimes(logger logger.Interface, runtimeConfig toolkitconfig.RuntimeConfig, enginePaths []string) []error {
+	locator := lookup.NewExecutableLocator(logger, "/")
+	resolve := func(candidate string) string {
+		targets, err := locator.Locate(candidate)
+		if err != nil || len(targets) == 0 {
+			return ""
+		}
+		return targets[0]
+	}
+
+	engineRuntimes := make(map[string]bool)
+	for _, path := range enginePaths {
+		if resolved := resolve(path); resolved != "" {
+			path = resolved
+		}
+		engineRuntimes[path] = true
+	}
+
+	check := func(option string, candidates []string) error {
+		for _, candidate := range candidates {
+			path := resolve(candidate)
+			if path == "" {
+				continue
+			}
+			if len(engineRuntimes) > 0 && !engineRuntimes[path] {
+				return fmt.Errorf("low-level runtime %v selected by %v does not correspond to a runtime of the container engine %v", path, option, enginePaths)
+			}
+			return nil
+		}
+		return fmt.Errorf("none of the low-level runtime candidates %v of %v exist", candidates, option)
+	}
+
+	var problems []error
+	if err := check("runtimes", runtimeConfig.Runtimes); err != nil {
+		problems = append(problems, err)
+	}
+
+	var names []string
+	for name := range runtimeConfig.LowLevelRuntimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		option := fmt.Sprintf("low-level-runtimes.%v", name)
+		if err := check(option, runtimeConfig.LowLevelRuntimes[name]); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return problems
+}
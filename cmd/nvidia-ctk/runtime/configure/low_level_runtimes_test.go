@@ -0,0 +1,119 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	toolkitconfig "github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/docker"
+)
+
+func TestCheckLowLevelRuntimes(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	binDir := t.TempDir()
+	runcPath := filepath.Join(binDir, "runc")
+	crunPath := filepath.Join(binDir, "crun")
+	missingPath := filepath.Join(binDir, "missing")
+	for _, path := range []string{runcPath, crunPath} {
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0755))
+	}
+
+	testCases := []struct {
+		description      string
+		runtimeConfig    toolkitconfig.RuntimeConfig
+		enginePaths      []string
+		expectedProblems []string
+	}{
+		{
+			description: "first existing candidate matches engine runtime",
+			runtimeConfig: toolkitconfig.RuntimeConfig{
+				Runtimes: []string{missingPath, runcPath},
+			},
+			enginePaths: []string{runcPath, crunPath},
+		},
+		{
+			description: "no engine runtimes skips correspondence check",
+			runtimeConfig: toolkitconfig.RuntimeConfig{
+				Runtimes: []string{crunPath},
+			},
+		},
+		{
+			description: "selected candidate does not match engine runtime",
+			runtimeConfig: toolkitconfig.RuntimeConfig{
+				Runtimes: []string{crunPath, runcPath},
+			},
+			enginePaths: []string{runcPath},
+			expectedProblems: []string{
+				"low-level runtime " + crunPath + " selected by runtimes does not correspond to a runtime of the container engine [" + runcPath + "]",
+			},
+		},
+		{
+			description: "missing candidates are reported",
+			runtimeConfig: toolkitconfig.RuntimeConfig{
+				Runtimes: []string{runcPath},
+				LowLevelRuntimes: map[string][]string{
+					"runsc": {missingPath},
+					"crun":  {crunPath},
+				},
+			},
+			enginePaths: []string{runcPath, crunPath},
+			expectedProblems: []string{
+				"none of the low-level runtime candidates [" + missingPath + "] of low-level-runtimes.runsc exist",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var problems []string
+			for _, problem := range checkLowLevelRuntimes(logger, tc.runtimeConfig, tc.enginePaths) {
+				problems = append(problems, problem.Error())
+			}
+			require.EqualValues(t, tc.expectedProblems, problems)
+		})
+	}
+}
+
+func TestValidateLowLevelRuntimesUsesConfigFile(t *testing.T) {
+	logger, hook := testlog.NewNullLogger()
+
+	missingPath := filepath.Join(t.TempDir(), "missing")
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+[nvidia-container-runtime]
+runtimes = ["`+missingPath+`"]
+`), 0600))
+
+	cfg, err := docker.New(docker.WithPath(filepath.Join(t.TempDir(), "daemon.json")))
+	require.NoError(t, err)
+
+	m := command{logger: logger}
+	m.validateLowLevelRuntimes(cfg, configFile)
+
+	var warnings []string
+	for _, entry := range hook.AllEntries() {
+		warnings = append(warnings, entry.Message)
+	}
+	require.EqualValues(t, []string{"none of the low-level runtime candidates [" + missingPath + "] of runtimes exist"}, warnings)
+}
@@ -0,0 +1,125 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package convertmountspecs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	input  string
+	output string
+	format string
+}
+
+// NewCommand constructs a convert-mount-specs sub-command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "convert-mount-specs",
+		Usage: "Convert a CSV mount spec file as used in CSV mode to a structured (YAML or JSON) mount spec file",
+		Before: func(c *cli.Context) error {
+			return m.validateFlags(c, &opts)
+		},
+		Action: func(c *cli.Context) error {
+			return m.run(c, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "input",
+			Usage:       "the path to the CSV file to convert",
+			Required:    true,
+			Destination: &opts.input,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "the path to write the structured mount spec file to. If not specified, the file is written to STDOUT.",
+			Destination: &opts.output,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "the output format (yaml or json). If not specified, this is determined from the output file extension, with yaml used by default.",
+			Destination: &opts.format,
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(c *cli.Context, opts *options) error {
+	if opts.format == "" {
+		switch strings.ToLower(filepath.Ext(opts.output)) {
+		case ".json":
+			opts.format = "json"
+		default:
+			opts.format = "yaml"
+		}
+	}
+	switch opts.format {
+	case "yaml", "json":
+	default:
+		return fmt.Errorf("invalid format %q; expected yaml or json", opts.format)
+	}
+	return nil
+}
+
+func (m command) run(c *cli.Context, opts *options) error {
+	specs, err := csv.NewCSVFileParser(m.logger, opts.input).Parse()
+	if err != nil {
+		return fmt.Errorf("failed to parse %v: %v", opts.input, err)
+	}
+
+	var output io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %v", err)
+		}
+		defer f.Close()
+		output = f
+	}
+
+	if err := csv.WriteMountSpecFile(output, opts.format, specs); err != nil {
+		return fmt.Errorf("failed to write mount spec file: %v", err)
+	}
+	m.logger.Infof("Converted %d mount specs from %v", len(specs), opts.input)
+	return nil
+}
@@ -0,0 +1,106 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package deviceinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/deviceinfo"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	driverRoot string
+	output     string
+}
+
+// NewCommand constructs a device-info sub-command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "device-info",
+		Usage: "Query the driver and GPU properties used to check NVIDIA_REQUIRE_* requirements and optionally cache them in a file",
+		Action: func(c *cli.Context) error {
+			return m.run(c, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "driver-root",
+			Usage:       "the path to the driver root. This is used to locate the NVML library.",
+			Value:       "/",
+			Destination: &opts.driverRoot,
+			EnvVars:     []string{"NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"},
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "the file to write the device info to. This can be referenced by the nvidia-container-runtime.device-info-path config option. If not specified, the device info is written to STDOUT.",
+			Destination: &opts.output,
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(c *cli.Context, opts *options) error {
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(opts.driverRoot),
+	)
+
+	var nvmlOpts []nvml.LibraryOption
+	candidates, err := driver.Libraries().Locate("libnvidia-ml.so.1")
+	if err != nil {
+		m.logger.Warningf("Ignoring error in locating libnvidia-ml.so.1: %v", err)
+	} else {
+		nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+	}
+
+	info, err := deviceinfo.New(nvml.New(nvmlOpts...))
+	if err != nil {
+		return fmt.Errorf("failed to query device info: %v", err)
+	}
+
+	if opts.output != "" {
+		m.logger.Infof("Writing device info to %v", opts.output)
+		return info.Save(opts.output)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(info)
+}
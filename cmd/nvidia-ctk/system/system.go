@@ -19,8 +19,10 @@ package system
 import (
 	"github.com/urfave/cli/v2"
 
+	convertmountspecs "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/convert-mount-specs"
 	devchar "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-dev-char-symlinks"
 	devicenodes "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-device-nodes"
+	deviceinfo "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/device-info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -46,6 +48,8 @@ func (m command) build() *cli.Command {
 	system.Subcommands = []*cli.Command{
 		devchar.NewCommand(m.logger),
 		devicenodes.NewCommand(m.logger),
+		deviceinfo.NewCommand(m.logger),
+		convertmountspecs.NewCommand(m.logger),
 	}
 
 	return &system
@@ -68,8 +68,22 @@ type Config struct {
 	NVIDIAContainerRuntimeConfig     RuntimeConfig      `toml:"nvidia-container-runtime"`
 	NVIDIAContainerRuntimeHookConfig RuntimeHookConfig  `toml:"nvidia-container-runtime-hook"`
 
+	// Logging defines the logging options shared by all binaries.
+	Logging LoggingConfig `toml:"logging,omitempty"`
+	// Tracing defines the options for recording timing spans.
+	Tracing TracingConfig `toml:"tracing,omitempty"`
+
 	// Features allows for finer control over optional features.
 	Features features `toml:"features,omitempty"`
+
+	// Profiles defines named sets of overrides that can be selected for a
+	// specific runtime handler.
+	Profiles map[string]ProfileConfig `toml:"profiles,omitempty"`
+	// AnnotationProfiles lists the profiles that may be selected using the
+	// nvidia.com/runtime-profile annotation of a container. Since annotations
+	// are controlled by the owner of a container, no profiles may be selected
+	// using the annotation by default.
+	AnnotationProfiles []string `toml:"annotation-profiles,omitempty"`
 }
 
 // GetConfigFilePath returns the path to the config file for the configured system
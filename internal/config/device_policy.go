@@ -0,0 +1,64 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+// DevicePolicyConfig stores a declarative policy that restricts which CDI
+// devices a container may request. Rules are evaluated in order and the
+// first matching rule determines whether a requested device is allowed. If no
+// rule matches, the default action is applied.
+type DevicePolicyConfig struct {
+	// DefaultAction is the action (allow or deny) applied to requests that
+	// do not match any rule. If this is not set, requests are allowed.
+	DefaultAction string `toml:"default-action,omitempty"`
+	// OnDeny specifies how denied requests are handled. If this is set to
+	// reject (the default) container creation fails. If this is set to
+	// filter, the denied devices are removed from the request.
+	OnDeny string `toml:"on-deny,omitempty"`
+	// Rules defines the rules of the policy.
+	Rules []DevicePolicyRule `toml:"rules,omitempty"`
+}
+
+// A DevicePolicyRule matches device requests and specifies whether these
+// are allowed. All of the specified criteria must match for the rule to
+// apply. A criterion matches if any of its patterns match. Patterns support
+// the * and ? wildcards.
+type DevicePolicyRule struct {
+	// Description is included in the reason logged for a decision.
+	Description string `toml:"description,omitempty"`
+	// Action is the action (allow or deny) applied to matching requests.
+	Action string `toml:"action"`
+	// Kinds matches the kind (vendor/class) of the requested device.
+	Kinds []string `toml:"kinds,omitempty"`
+	// Classes matches the class of the requested device.
+	Classes []string `toml:"classes,omitempty"`
+	// Names matches the name of the requested device.
+	Names []string `toml:"names,omitempty"`
+	// Sources matches how the device was requested. Supported values are
+	// annotation, envvar, and mount.
+	Sources []string `toml:"sources,omitempty"`
+	// Images matches the image of the container. The image is determined
+	// from the annotations set by CRI runtimes and rules specifying images
+	// do not match containers for which the image is unknown. Since the
+	// annotations are controlled by the owner of a container, this is only
+	// supported for deny rules.
+	Images []string `toml:"images,omitempty"`
+	// Annotations matches the annotations of the container. Each of the
+	// specified annotations must be present with a value matching the
+	// pattern. Since the annotations are controlled by the owner of a
+	// container, this is only supported for deny rules.
+	Annotations map[string]string `toml:"annotations,omitempty"`
+}
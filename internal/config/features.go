@@ -42,6 +42,13 @@ type features struct {
 	// possibly bypassing other checks by an orchestration system such as
 	// kubernetes.
 	IgnoreImexChannelRequests *feature `toml:"ignore-imex-channel-requests,omitempty"`
+	// InProcessLegacyHook configures the NVIDIA Container Runtime Hook to
+	// apply the edits for the requested devices in process using the CDI
+	// specification generated by nvcdi instead of invoking the
+	// nvidia-container-cli. This is only supported on hosts that use cgroup
+	// v1 since the device cgroup rules cannot be applied to a created
+	// container on cgroup v2 hosts, and the hook fails on these hosts.
+	InProcessLegacyHook *feature `toml:"in-process-legacy-hook,omitempty"`
 }
 
 type feature bool
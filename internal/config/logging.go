@@ -0,0 +1,37 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+// LoggingConfig stores the logging options that are shared by the NVIDIA
+// Container Toolkit binaries. This allows the records of a single container
+// to be correlated across the NVIDIA Container Runtime, the NVIDIA Container
+// Runtime Hook, and the NVIDIA CDI hooks.
+type LoggingConfig struct {
+	// Format sets the format of log records. One of [text | json].
+	Format string `toml:"format,omitempty"`
+	// Level sets the log level. If this is not set, the level configured for
+	// the specific binary is used.
+	Level string `toml:"level,omitempty"`
+	// Files maps the name of a binary (e.g. nvidia-cdi-hook) to the file that
+	// its log records are written to.
+	Files map[string]string `toml:"files,omitempty"`
+}
+
+// GetFile returns the log file configured for the specified binary.
+func (c LoggingConfig) GetFile(binary string) string {
+	return c.Files[binary]
+}
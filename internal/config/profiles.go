@@ -0,0 +1,98 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ProfileConfig stores the overrides applied to the config when a named
+// profile is selected. Unset options leave the base config unchanged. This
+// allows a single runtime binary to be registered as multiple runtime
+// handlers, each with a different policy.
+type ProfileConfig struct {
+	// Mode overrides the nvidia-container-runtime.mode option.
+	Mode string `toml:"mode,omitempty"`
+	// SpecDirs overrides the nvidia-container-runtime.modes.cdi.spec-dirs
+	// option.
+	SpecDirs []string `toml:"spec-dirs,omitempty"`
+	// AnnotationPrefixes overrides the
+	// nvidia-container-runtime.modes.cdi.annotation-prefixes option. An
+	// empty list disables annotation-based device injection.
+	AnnotationPrefixes *[]string `toml:"annotation-prefixes,omitempty"`
+	// AcceptEnvvarUnprivileged overrides the
+	// accept-nvidia-visible-devices-envvar-when-unprivileged option.
+	AcceptEnvvarUnprivileged *bool `toml:"accept-nvidia-visible-devices-envvar-when-unprivileged,omitempty"`
+	// DevicePolicy replaces the nvidia-container-runtime.modes.cdi.policy
+	// option.
+	DevicePolicy *DevicePolicyConfig `toml:"policy,omitempty"`
+	// Features overrides the feature gates that are explicitly set.
+	Features features `toml:"features,omitempty"`
+}
+
+// builtinProfiles are the profiles that are available even if these are not
+// defined in the config. These correspond to the mode-specific runtimes.
+var builtinProfiles = map[string]ProfileConfig{
+	"cdi":    {Mode: "cdi"},
+	"legacy": {Mode: "legacy"},
+}
+
+// ApplyProfile applies the overrides of the specified profile to the config.
+// The cdi and legacy profiles override the mode unless these are defined
+// explicitly. An error is returned for undefined profiles.
+func (c *Config) ApplyProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		profile, ok = builtinProfiles[name]
+	}
+	if !ok {
+		return fmt.Errorf("undefined profile %q", name)
+	}
+
+	if profile.Mode != "" {
+		c.NVIDIAContainerRuntimeConfig.Mode = profile.Mode
+	}
+	if len(profile.SpecDirs) > 0 {
+		c.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs = profile.SpecDirs
+	}
+	if profile.AnnotationPrefixes != nil {
+		c.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes = *profile.AnnotationPrefixes
+	}
+	if profile.AcceptEnvvarUnprivileged != nil {
+		c.AcceptEnvvarUnprivileged = *profile.AcceptEnvvarUnprivileged
+	}
+	if profile.DevicePolicy != nil {
+		c.NVIDIAContainerRuntimeConfig.Modes.CDI.Policy = *profile.DevicePolicy
+	}
+	c.Features.override(profile.Features)
+
+	return nil
+}
+
+// override sets the features that are explicitly set in the specified
+// features.
+func (f *features) override(o features) {
+	fv := reflect.ValueOf(f).Elem()
+	ov := reflect.ValueOf(o)
+	for i := 0; i < ov.NumField(); i++ {
+		if ov.Field(i).IsNil() {
+			continue
+		}
+		fv.Field(i).Set(ov.Field(i))
+	}
+}
@@ -0,0 +1,119 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProfile(t *testing.T) {
+	contents := []string{
+		"accept-nvidia-visible-devices-envvar-when-unprivileged = true",
+		"[nvidia-container-runtime]",
+		"mode = \"auto\"",
+		"[nvidia-container-runtime.modes.cdi]",
+		"annotation-prefixes = [\"cdi.k8s.io/\"]",
+		"[features]",
+		"allow-ldconfig-from-container = true",
+		"[profiles.restricted]",
+		"mode = \"cdi\"",
+		"spec-dirs = [\"/etc/cdi/restricted\"]",
+		"annotation-prefixes = []",
+		"accept-nvidia-visible-devices-envvar-when-unprivileged = false",
+		"[profiles.restricted.features]",
+		"ignore-imex-channel-requests = true",
+		"[profiles.restricted.policy]",
+		"default-action = \"deny\"",
+		"[[profiles.restricted.policy.rules]]",
+		"action = \"allow\"",
+		"kinds = [\"nvidia.com/gpu\"]",
+		"[profiles.empty]",
+	}
+
+	testCases := []struct {
+		description   string
+		profile       string
+		expectedError string
+		validate      func(*testing.T, *Config)
+	}{
+		{
+			description: "restricted profile overrides options",
+			profile:     "restricted",
+			validate: func(t *testing.T, cfg *Config) {
+				require.Equal(t, "cdi", cfg.NVIDIAContainerRuntimeConfig.Mode)
+				require.Equal(t, []string{"/etc/cdi/restricted"}, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs)
+				require.Empty(t, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes)
+				require.False(t, cfg.AcceptEnvvarUnprivileged)
+				require.True(t, cfg.Features.IgnoreImexChannelRequests.IsEnabled())
+				require.True(t, cfg.Features.AllowLDConfigFromContainer.IsEnabled())
+				require.Equal(t,
+					DevicePolicyConfig{
+						DefaultAction: "deny",
+						Rules: []DevicePolicyRule{
+							{Action: "allow", Kinds: []string{"nvidia.com/gpu"}},
+						},
+					},
+					cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.Policy,
+				)
+			},
+		},
+		{
+			description: "empty profile leaves config unchanged",
+			profile:     "empty",
+			validate: func(t *testing.T, cfg *Config) {
+				require.Equal(t, "auto", cfg.NVIDIAContainerRuntimeConfig.Mode)
+				require.Equal(t, []string{"/etc/cdi", "/var/run/cdi"}, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs)
+				require.Equal(t, []string{"cdi.k8s.io/"}, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes)
+				require.True(t, cfg.AcceptEnvvarUnprivileged)
+				require.False(t, cfg.Features.IgnoreImexChannelRequests.IsEnabled())
+				require.Empty(t, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.Policy.Rules)
+			},
+		},
+		{
+			description: "builtin legacy profile sets mode",
+			profile:     "legacy",
+			validate: func(t *testing.T, cfg *Config) {
+				require.Equal(t, "legacy", cfg.NVIDIAContainerRuntimeConfig.Mode)
+			},
+		},
+		{
+			description:   "undefined profile returns error",
+			profile:       "undefined",
+			expectedError: `undefined profile "undefined"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tomlCfg, err := loadConfigTomlFrom(strings.NewReader(strings.Join(contents, "\n")))
+			require.NoError(t, err)
+			cfg, err := tomlCfg.Config()
+			require.NoError(t, err)
+
+			err = cfg.ApplyProfile(tc.profile)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tc.validate(t, cfg)
+		})
+	}
+}
@@ -25,6 +25,26 @@ type RuntimeConfig struct {
 	Runtimes []string    `toml:"runtimes"`
 	Mode     string      `toml:"mode"`
 	Modes    modesConfig `toml:"modes"`
+	// LowLevelRuntimes defines the low-level runtimes that can be selected
+	// for a container using the nvidia.com/low-level-runtime annotation. Each
+	// entry maps a name to a list of candidates that are resolved in the same
+	// way as the Runtimes option.
+	LowLevelRuntimes map[string][]string `toml:"low-level-runtimes,omitempty"`
+	// CUDACompatibilityMatrix optionally specifies a JSON file that overrides
+	// the embedded CUDA driver / toolkit compatibility matrix used when
+	// checking NVIDIA_REQUIRE_* requirements. This is not used in legacy mode
+	// where the requirements are checked by the nvidia-container-cli.
+	CUDACompatibilityMatrix string `toml:"cuda-compatibility-matrix,omitempty"`
+	// DeviceInfoPath optionally specifies a cached device-info file (as
+	// generated by nvidia-ctk system device-info) that is used instead of
+	// NVML to determine the properties of the requested devices when checking
+	// NVIDIA_REQUIRE_* requirements in the csv and cdi modes.
+	DeviceInfoPath string `toml:"device-info-path,omitempty"`
+	// MetricsTextfilePath optionally specifies a file for the node-exporter
+	// textfile collector that is updated with the metrics of each container
+	// create. Since each update locks, syncs, and replaces the file, this
+	// adds to the time taken to create a container.
+	MetricsTextfilePath string `toml:"metrics-textfile-path,omitempty"`
 }
 
 // modesConfig defines (optional) per-mode configs
@@ -41,6 +61,8 @@ type cdiModeConfig struct {
 	DefaultKind string `toml:"default-kind"`
 	// AnnotationPrefixes sets the allowed prefixes for CDI annotation-based device injection
 	AnnotationPrefixes []string `toml:"annotation-prefixes"`
+	// Policy restricts which CDI devices a container may request.
+	Policy DevicePolicyConfig `toml:"policy,omitempty"`
 }
 
 type csvModeConfig struct {
@@ -68,7 +90,8 @@ const (
 	CUDACompatModeHook = cudaCompatMode("hook")
 	// CUDACompatModeLdconfig adds the folders containing CUDA Forward Compat
 	// libraries to the ldconfig command invoked from the NVIDIA Container
-	// Runtime Hook.
+	// Runtime Hook. The folder is selected using the same policy as the
+	// enable-cuda-compat hook.
 	CUDACompatModeLdconfig = cudaCompatMode("ldconfig")
 	// CUDACompatModeMount mounts CUDA Forward Compat folders from the container
 	// to the container when using the NVIDIA Container Runtime Hook.
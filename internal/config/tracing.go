@@ -0,0 +1,26 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+// TracingConfig stores the options for recording timing spans for the
+// phases of container creation. Tracing is enabled if a file is specified.
+// Spans are exported using the OTLP JSON encoding.
+type TracingConfig struct {
+	// File is the path of a file to which spans are appended with one OTLP
+	// export request per line.
+	File string `toml:"file,omitempty"`
+}
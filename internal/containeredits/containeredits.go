@@ -0,0 +1,247 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containeredits
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
+)
+
+// An Applier applies CDI container edits to a container that has already been
+// created by the low-level runtime. This allows the edits for a container to be
+// applied from an OCI prestart hook instead of by modifying the OCI spec.
+//
+// Device cgroup rules are applied from the runtime namespace. Device nodes,
+// mounts, and hooks are applied in the mount namespace of the container before
+// the runtime pivots to the container root.
+type Applier struct {
+	logger    logger.Interface
+	pid       int
+	rootfs    string
+	state     []byte
+	noCgroups bool
+	tracer    *tracing.Tracer
+}
+
+// ErrCgroupV2 is returned if device cgroup rules are to be applied on a host
+// that uses cgroup v2. Device access for cgroup v2 is controlled by a BPF
+// program that is attached to the cgroup of the container by the low-level
+// runtime and applying edits to a created container is only supported for
+// cgroup v1.
+var ErrCgroupV2 = errors.New("device cgroup rules cannot be applied to a created container on hosts that use cgroup v2")
+
+// Option is a functional option for an Applier.
+type Option func(*Applier)
+
+// WithLogger sets the logger for the applier.
+func WithLogger(logger logger.Interface) Option {
+	return func(a *Applier) {
+		a.logger = logger
+	}
+}
+
+// WithPID sets the PID of the container init process.
+func WithPID(pid int) Option {
+	return func(a *Applier) {
+		a.pid = pid
+	}
+}
+
+// WithRootfs sets the path to the container root on the host.
+func WithRootfs(rootfs string) Option {
+	return func(a *Applier) {
+		a.rootfs = rootfs
+	}
+}
+
+// WithContainerState sets the OCI container state that is passed to hooks on
+// STDIN.
+func WithContainerState(state []byte) Option {
+	return func(a *Applier) {
+		a.state = state
+	}
+}
+
+// WithNoCgroups disables the application of device cgroup rules. Since the
+// container is then not granted access to the injected device nodes, this
+// should only be set if device access is not restricted for the container (for
+// example for rootless containers). Note that this does not add support for
+// hosts that use cgroup v2.
+func WithNoCgroups(noCgroups bool) Option {
+	return func(a *Applier) {
+		a.noCgroups = noCgroups
+	}
+}
+
+// WithTracer sets the tracer used to record a span for each hook that is run.
+// The trace context is propagated to the hooks.
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(a *Applier) {
+		a.tracer = tracer
+	}
+}
+
+// New creates an applier with the specified options.
+func New(opts ...Option) (*Applier, error) {
+	a := &Applier{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.logger == nil {
+		a.logger = &logger.NullLogger{}
+	}
+	if a.pid <= 0 {
+		return nil, fmt.Errorf("invalid container PID %d", a.pid)
+	}
+	if !filepath.IsAbs(a.rootfs) {
+		return nil, fmt.Errorf("container root %q is not an absolute path", a.rootfs)
+	}
+	if !a.noCgroups {
+		if err := checkDeviceCgroupSupport(); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Apply applies the specified edits to the container. Environment variables
+// and additional GIDs cannot be changed once the container has been created
+// and are ignored.
+func (a *Applier) Apply(edits *specs.ContainerEdits) error {
+	if edits == nil {
+		return nil
+	}
+	if len(edits.Env) > 0 || len(edits.AdditionalGIDs) > 0 {
+		a.logger.Debugf("Ignoring environment and additional GID edits")
+	}
+
+	var deviceNodes []*deviceNode
+	for _, dn := range edits.DeviceNodes {
+		resolved, err := resolveDeviceNode(dn)
+		if err != nil {
+			return fmt.Errorf("failed to resolve device node %v: %w", dn.Path, err)
+		}
+		deviceNodes = append(deviceNodes, resolved)
+	}
+
+	if !a.noCgroups && len(deviceNodes) > 0 {
+		if err := allowDevices(a.pid, deviceNodes); err != nil {
+			return fmt.Errorf("failed to apply device cgroup rules: %w", err)
+		}
+	}
+
+	return inMountNamespace(a.pid, func() error {
+		for _, dn := range deviceNodes {
+			a.logger.Debugf("Creating device node %v", dn.path)
+			if err := createDeviceNode(containerroot.Root(a.rootfs), dn); err != nil {
+				return fmt.Errorf("failed to create device node %v: %w", dn.path, err)
+			}
+		}
+		for _, m := range edits.Mounts {
+			a.logger.Debugf("Mounting %v at %v", m.HostPath, m.ContainerPath)
+			if err := mount(containerroot.Root(a.rootfs), m); err != nil {
+				return fmt.Errorf("failed to mount %v: %w", m.HostPath, err)
+			}
+		}
+		for _, h := range edits.Hooks {
+			if !runsBeforeStart(h) {
+				a.logger.Debugf("Skipping %v hook %v", h.HookName, h.Path)
+				continue
+			}
+			if err := a.runHook(h); err != nil {
+				return fmt.Errorf("failed to run %v hook %v: %w", h.HookName, h.Path, err)
+			}
+		}
+		return nil
+	})
+}
+
+// A deviceNode is a CDI device node for which the type, major, and minor
+// numbers have been determined.
+type deviceNode struct {
+	path        string
+	devType     string
+	major       int64
+	minor       int64
+	fileMode    uint32
+	permissions string
+	uid         *uint32
+	gid         *uint32
+}
+
+// runsBeforeStart checks whether the specified hook is run before the
+// user-specified process is started. Since the edits are applied from a
+// prestart hook, only these hooks are run.
+func runsBeforeStart(h *specs.Hook) bool {
+	switch h.HookName {
+	case "prestart", "createRuntime", "createContainer":
+		return true
+	}
+	return false
+}
+
+// runHook runs the specified hook with the container state on STDIN.
+func (a *Applier) runHook(h *specs.Hook) error {
+	span := a.tracer.Start("hook " + filepath.Base(h.Path))
+	defer span.End()
+	span.SetAttribute("args", strings.Join(h.Args, " "))
+
+	ctx := context.Background()
+	if h.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*h.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	//nolint:gosec // The hook path and arguments are generated by nvcdi.
+	cmd := exec.CommandContext(ctx, h.Path)
+	if len(h.Args) > 0 {
+		cmd.Args = h.Args
+	}
+	cmd.Env = h.Env
+	if traceparent := a.tracer.Traceparent(); traceparent != "" {
+		// A nil environment means that the environment of the current
+		// process is inherited.
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, tracing.EnvVar+"="+traceparent)
+	}
+	cmd.Stdin = bytes.NewReader(a.state)
+
+	a.logger.Debugf("Running hook %v", cmd.Args)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		err = fmt.Errorf("%w: %s", err, output)
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
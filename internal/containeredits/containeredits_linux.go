@@ -0,0 +1,360 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containeredits
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/sys/unix"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+)
+
+const (
+	procRoot   = "/proc"
+	cgroupRoot = "/sys/fs/cgroup"
+)
+
+// inMountNamespace runs the specified function in the mount namespace of the
+// process with the specified PID.
+//
+// Since setns(2) does not allow a thread that shares its filesystem attributes
+// to join a mount namespace, the function is run on a dedicated OS thread that
+// first unshares these. The thread is never unlocked, meaning that the Go
+// runtime terminates it instead of reusing it once the function returns.
+// Processes that are started by the function inherit the mount namespace.
+func inMountNamespace(pid int, fn func() error) error {
+	nsPath := filepath.Join(procRoot, fmt.Sprint(pid), "ns", "mnt")
+
+	errCh := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+
+		ns, err := os.Open(nsPath)
+		if err != nil {
+			errCh <- fmt.Errorf("failed to open mount namespace: %w", err)
+			return
+		}
+		defer ns.Close()
+
+		if err := unix.Unshare(unix.CLONE_FS); err != nil {
+			errCh <- fmt.Errorf("failed to unshare filesystem attributes: %w", err)
+			return
+		}
+		if err := unix.Setns(int(ns.Fd()), unix.CLONE_NEWNS); err != nil {
+			errCh <- fmt.Errorf("failed to join mount namespace: %w", err)
+			return
+		}
+		errCh <- fn()
+	}()
+	return <-errCh
+}
+
+// resolveDeviceNode determines the type, major, and minor numbers of the
+// specified device node. If these are not included in the CDI device node, they
+// are read from the device node on the host.
+func resolveDeviceNode(dn *specs.DeviceNode) (*deviceNode, error) {
+	resolved := &deviceNode{
+		path:        dn.Path,
+		devType:     dn.Type,
+		major:       dn.Major,
+		minor:       dn.Minor,
+		permissions: dn.Permissions,
+		uid:         dn.UID,
+		gid:         dn.GID,
+	}
+	if resolved.permissions == "" {
+		resolved.permissions = "rwm"
+	}
+	if dn.FileMode != nil {
+		resolved.fileMode = uint32(dn.FileMode.Perm())
+	}
+
+	if resolved.devType != "" && (resolved.major != 0 || resolved.minor != 0) && dn.FileMode != nil {
+		return resolved, nil
+	}
+
+	hostPath := dn.HostPath
+	if hostPath == "" {
+		hostPath = dn.Path
+	}
+	var stat unix.Stat_t
+	if err := unix.Stat(hostPath, &stat); err != nil {
+		if resolved.devType != "" && (resolved.major != 0 || resolved.minor != 0) {
+			resolved.fileMode = 0666
+			return resolved, nil
+		}
+		return nil, err
+	}
+
+	switch stat.Mode & unix.S_IFMT {
+	case unix.S_IFCHR:
+		resolved.devType = "c"
+	case unix.S_IFBLK:
+		resolved.devType = "b"
+	default:
+		return nil, fmt.Errorf("%v is not a device node", hostPath)
+	}
+	resolved.major = int64(unix.Major(stat.Rdev))
+	resolved.minor = int64(unix.Minor(stat.Rdev))
+	if dn.FileMode == nil {
+		resolved.fileMode = stat.Mode &^ unix.S_IFMT
+	}
+	return resolved, nil
+}
+
+// createDeviceNode creates the specified device node in the container root.
+// Existing files at the target path are left unmodified.
+func createDeviceNode(rootfs containerroot.Root, dn *deviceNode) error {
+	mode := uint32(unix.S_IFCHR)
+	if dn.devType == "b" {
+		mode = unix.S_IFBLK
+	}
+	dev := unix.Mkdev(uint32(dn.major), uint32(dn.minor))
+	handle, err := rootfs.Mknod(dn.path, mode|dn.fileMode, dev)
+	if errors.Is(err, os.ErrExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer handle.Close()
+
+	// The mode passed to mknod is subject to the umask.
+	if err := rootfs.Chmod(handle, os.FileMode(dn.fileMode)); err != nil {
+		return err
+	}
+	if dn.uid != nil || dn.gid != nil {
+		uid, gid := -1, -1
+		if dn.uid != nil {
+			uid = int(*dn.uid)
+		}
+		if dn.gid != nil {
+			gid = int(*dn.gid)
+		}
+		if err := rootfs.Chown(handle, uid, gid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mount applies the specified mount to the container root. Bind mounts are
+// supported with the common flags and propagation options. The mount target is
+// resolved to a handle in the container root and the mount is performed on the
+// procfs path of this handle so that it cannot be redirected.
+func mount(rootfs containerroot.Root, m *specs.Mount) error {
+	handle, err := createMountTarget(rootfs, m.HostPath, m.ContainerPath)
+	if err != nil {
+		return fmt.Errorf("failed to create mount target: %w", err)
+	}
+	defer handle.Close()
+	target := containerroot.FdPath(handle)
+
+	options := parseMountOptions(m.Options)
+	if options.flags&unix.MS_BIND == 0 {
+		return unix.Mount(m.HostPath, target, m.Type, options.flags, options.data)
+	}
+
+	if err := unix.Mount(m.HostPath, target, "", options.flags&(unix.MS_BIND|unix.MS_REC), ""); err != nil {
+		return err
+	}
+	// The procfs path of the handle refers to the file below the new mount.
+	// The mount is reopened so that the remount applies to the new mount.
+	mounted, err := rootfs.Open(m.ContainerPath)
+	if err != nil {
+		return fmt.Errorf("failed to open mount: %w", err)
+	}
+	defer mounted.Close()
+	target = containerroot.FdPath(mounted)
+
+	// The remaining flags of a bind mount only take effect on a remount.
+	if remountFlags := options.flags &^ (unix.MS_BIND | unix.MS_REC); remountFlags != 0 {
+		if err := unix.Mount("", target, "", unix.MS_BIND|unix.MS_REMOUNT|remountFlags, ""); err != nil {
+			return fmt.Errorf("failed to remount: %w", err)
+		}
+	}
+	if options.propagation != 0 {
+		if err := unix.Mount("", target, "", options.propagation, ""); err != nil {
+			return fmt.Errorf("failed to set mount propagation: %w", err)
+		}
+	}
+	return nil
+}
+
+// createMountTarget returns a handle to the target for a mount of the
+// specified source in the container root. For directories a directory is
+// created and for all other sources an empty file.
+func createMountTarget(rootfs containerroot.Root, source string, target string) (*os.File, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return rootfs.MkdirAll(target, 0755)
+	}
+	return rootfs.CreateFile(target, 0644)
+}
+
+type mountOptions struct {
+	flags       uintptr
+	propagation uintptr
+	data        string
+}
+
+// parseMountOptions converts the options of a CDI mount to mount flags.
+// Options that are not flags are passed as data.
+func parseMountOptions(options []string) mountOptions {
+	flags := map[string]uintptr{
+		"bind":     unix.MS_BIND,
+		"rbind":    unix.MS_BIND | unix.MS_REC,
+		"ro":       unix.MS_RDONLY,
+		"nosuid":   unix.MS_NOSUID,
+		"nodev":    unix.MS_NODEV,
+		"noexec":   unix.MS_NOEXEC,
+		"relatime": unix.MS_RELATIME,
+		"noatime":  unix.MS_NOATIME,
+	}
+	propagation := map[string]uintptr{
+		"private":     unix.MS_PRIVATE,
+		"rprivate":    unix.MS_PRIVATE | unix.MS_REC,
+		"slave":       unix.MS_SLAVE,
+		"rslave":      unix.MS_SLAVE | unix.MS_REC,
+		"shared":      unix.MS_SHARED,
+		"rshared":     unix.MS_SHARED | unix.MS_REC,
+		"unbindable":  unix.MS_UNBINDABLE,
+		"runbindable": unix.MS_UNBINDABLE | unix.MS_REC,
+	}
+
+	var parsed mountOptions
+	var data []string
+	for _, option := range options {
+		if f, ok := flags[option]; ok {
+			parsed.flags |= f
+			continue
+		}
+		if p, ok := propagation[option]; ok {
+			parsed.propagation = p
+			continue
+		}
+		if option == "rw" {
+			continue
+		}
+		data = append(data, option)
+	}
+	parsed.data = strings.Join(data, ",")
+	return parsed
+}
+
+// isCgroupV2Host checks whether the host uses cgroup v2. This is a variable so
+// that the check can be overridden in tests.
+var isCgroupV2Host = func() (bool, error) {
+	return isCgroupV2(cgroupRoot)
+}
+
+// checkDeviceCgroupSupport checks whether device cgroup rules can be applied on
+// the host. Device access for cgroup v2 is controlled by a BPF program that is
+// attached to the cgroup of the container by the low-level runtime. Since this
+// program is not updated by the applier, cgroup v2 is not supported.
+func checkDeviceCgroupSupport() error {
+	unified, err := isCgroupV2Host()
+	if err != nil {
+		return err
+	}
+	if unified {
+		return ErrCgroupV2
+	}
+	return nil
+}
+
+// isCgroupV2 checks whether the cgroup hierarchy mounted at the specified path
+// is a cgroup v2 (unified) hierarchy.
+func isCgroupV2(path string) (bool, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return false, fmt.Errorf("failed to determine cgroup version: %w", err)
+	}
+	return stat.Type == unix.CGROUP2_SUPER_MAGIC, nil
+}
+
+// allowDevices grants the process with the specified PID access to the
+// specified device nodes through the devices controller of its cgroup.
+// Only cgroup v1 is supported as described for checkDeviceCgroupSupport.
+func allowDevices(pid int, deviceNodes []*deviceNode) error {
+	cgroupFile, err := os.Open(filepath.Join(procRoot, fmt.Sprint(pid), "cgroup"))
+	if err != nil {
+		return err
+	}
+	defer cgroupFile.Close()
+
+	cgroupPath, err := getDevicesCgroupPath(cgroupFile)
+	if err != nil {
+		return err
+	}
+
+	allowFile, err := os.OpenFile(filepath.Join(cgroupRoot, "devices", cgroupPath, "devices.allow"), os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer allowFile.Close()
+
+	for _, dn := range deviceNodes {
+		// Each rule must be written separately.
+		if _, err := fmt.Fprintf(allowFile, "%s %d:%d %s", dn.devType, dn.major, dn.minor, dn.permissions); err != nil {
+			return fmt.Errorf("failed to allow device %v: %w", dn.path, err)
+		}
+	}
+	return nil
+}
+
+// getDevicesCgroupPath returns the path of the cgroup for the devices
+// controller from the contents of a /proc/[pid]/cgroup file.
+func getDevicesCgroupPath(r io.Reader) (string, error) {
+	var unified bool
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Lines are of the form hierarchy-ID:controller-list:cgroup-path.
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			unified = true
+			continue
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			if controller == "devices" {
+				return parts[2], nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if unified {
+		return "", ErrCgroupV2
+	}
+	return "", fmt.Errorf("no devices cgroup found")
+}
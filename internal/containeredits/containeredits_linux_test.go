@@ -0,0 +1,163 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containeredits
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestGetDevicesCgroupPath(t *testing.T) {
+	testCases := []struct {
+		description   string
+		contents      string
+		expectedPath  string
+		expectedError error
+	}{
+		{
+			description: "cgroup v1",
+			contents: `12:memory:/docker/abc
+11:devices:/docker/abc
+1:name=systemd:/docker/abc
+`,
+			expectedPath: "/docker/abc",
+		},
+		{
+			description: "cgroup v1 with co-mounted controllers",
+			contents: `4:cpu,devices:/kubepods/pod1/abc
+0::/kubepods/pod1/abc
+`,
+			expectedPath: "/kubepods/pod1/abc",
+		},
+		{
+			description:   "cgroup v2",
+			contents:      "0::/system.slice/docker-abc.scope\n",
+			expectedError: ErrCgroupV2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path, err := getDevicesCgroupPath(strings.NewReader(tc.contents))
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedPath, path)
+		})
+	}
+}
+
+func TestIsCgroupV2(t *testing.T) {
+	unified, err := isCgroupV2(t.TempDir())
+	require.NoError(t, err)
+	require.False(t, unified)
+
+	_, err = isCgroupV2("/does/not/exist")
+	require.Error(t, err)
+}
+
+func TestNewCgroupV2(t *testing.T) {
+	defer func(isCgroupV2 func() (bool, error)) {
+		isCgroupV2Host = isCgroupV2
+	}(isCgroupV2Host)
+	isCgroupV2Host = func() (bool, error) {
+		return true, nil
+	}
+
+	_, err := New(WithPID(1), WithRootfs("/rootfs"))
+	require.ErrorIs(t, err, ErrCgroupV2)
+
+	_, err = New(WithPID(1), WithRootfs("/rootfs"), WithNoCgroups(true))
+	require.NoError(t, err)
+}
+
+func TestParseMountOptions(t *testing.T) {
+	testCases := []struct {
+		description     string
+		options         []string
+		expectedOptions mountOptions
+	}{
+		{
+			description: "nvcdi library mount",
+			options:     []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+			expectedOptions: mountOptions{
+				flags:       unix.MS_RDONLY | unix.MS_NOSUID | unix.MS_NODEV | unix.MS_BIND | unix.MS_REC,
+				propagation: unix.MS_PRIVATE | unix.MS_REC,
+			},
+		},
+		{
+			description: "non-flag options are data",
+			options:     []string{"rw", "noexec", "size=64k", "mode=755"},
+			expectedOptions: mountOptions{
+				flags: unix.MS_NOEXEC,
+				data:  "size=64k,mode=755",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expectedOptions, parseMountOptions(tc.options))
+		})
+	}
+}
+
+func TestResolveDeviceNode(t *testing.T) {
+	uid := uint32(1000)
+	fileMode := os.FileMode(0660)
+
+	resolved, err := resolveDeviceNode(&specs.DeviceNode{
+		Path:     "/dev/nvidia-caps-imex-channels/channel3",
+		HostPath: "/does/not/exist",
+		Type:     "c",
+		Major:    234,
+		Minor:    3,
+		FileMode: &fileMode,
+		UID:      &uid,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t,
+		&deviceNode{
+			path:        "/dev/nvidia-caps-imex-channels/channel3",
+			devType:     "c",
+			major:       234,
+			minor:       3,
+			fileMode:    0660,
+			permissions: "rwm",
+			uid:         &uid,
+		},
+		resolved,
+	)
+
+	_, err = resolveDeviceNode(&specs.DeviceNode{Path: "/does/not/exist"})
+	require.Error(t, err)
+
+	_, err = resolveDeviceNode(&specs.DeviceNode{Path: t.TempDir()})
+	require.ErrorContains(t, err, "is not a device node")
+
+	if _, err := os.Stat("/dev/null"); err == nil {
+		resolved, err := resolveDeviceNode(&specs.DeviceNode{Path: "/dev/null", Permissions: "rw"})
+		require.NoError(t, err)
+		require.Equal(t, "c", resolved.devType)
+		require.EqualValues(t, 1, resolved.major)
+		require.EqualValues(t, 3, resolved.minor)
+		require.Equal(t, "rw", resolved.permissions)
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !linux
+// +build !linux
+
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containeredits
+
+import (
+	"fmt"
+	"runtime"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+)
+
+var errUnsupported = fmt.Errorf("applying container edits is not supported on %v", runtime.GOOS)
+
+func checkDeviceCgroupSupport() error {
+	return errUnsupported
+}
+
+func inMountNamespace(int, func() error) error {
+	return errUnsupported
+}
+
+func resolveDeviceNode(*specs.DeviceNode) (*deviceNode, error) {
+	return nil, errUnsupported
+}
+
+func createDeviceNode(containerroot.Root, *deviceNode) error {
+	return errUnsupported
+}
+
+func mount(containerroot.Root, *specs.Mount) error {
+	return errUnsupported
+}
+
+func allowDevices(int, []*deviceNode) error {
+	return errUnsupported
+}
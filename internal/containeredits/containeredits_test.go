@@ -0,0 +1,73 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containeredits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(WithRootfs("/rootfs"))
+	require.EqualError(t, err, "invalid container PID 0")
+
+	_, err = New(WithPID(1), WithRootfs("rootfs"))
+	require.EqualError(t, err, `container root "rootfs" is not an absolute path`)
+
+	// Cgroups are disabled so that the result does not depend on the cgroup
+	// version of the host.
+	a, err := New(WithPID(1), WithRootfs("/rootfs"), WithNoCgroups(true))
+	require.NoError(t, err)
+	require.NotNil(t, a.logger)
+}
+
+func TestRunsBeforeStart(t *testing.T) {
+	testCases := map[string]bool{
+		"prestart":        true,
+		"createRuntime":   true,
+		"createContainer": true,
+		"startContainer":  false,
+		"poststart":       false,
+		"poststop":        false,
+	}
+	for hookName, expected := range testCases {
+		t.Run(hookName, func(t *testing.T) {
+			require.Equal(t, expected, runsBeforeStart(&specs.Hook{HookName: hookName}))
+		})
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	a, err := New(WithPID(1), WithRootfs("/rootfs"), WithNoCgroups(true), WithContainerState([]byte(`{"id": "ctr"}`)))
+	require.NoError(t, err)
+
+	err = a.runHook(&specs.Hook{
+		HookName: "createContainer",
+		Path:     "/bin/sh",
+		Args:     []string{"sh", "-c", `grep -q '"id": "ctr"'`},
+	})
+	require.NoError(t, err)
+
+	err = a.runHook(&specs.Hook{
+		HookName: "createContainer",
+		Path:     "/bin/sh",
+		Args:     []string{"sh", "-c", "echo failed; exit 1"},
+	})
+	require.ErrorContains(t, err, "failed")
+}
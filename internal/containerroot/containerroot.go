@@ -0,0 +1,298 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containerroot
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"golang.org/x/sys/unix"
+)
+
+// A Root represents the root filesystem of a container.
+//
+// Paths are resolved relative to the root with symlinks (absolute, relative,
+// and looping) never allowed to escape it. Where the kernel supports it,
+// openat2 with RESOLVE_IN_ROOT is used for resolution. On older kernels, the
+// path is walked component-by-component in userspace instead.
+//
+// Operations return file handles instead of paths so that the resolved file
+// cannot be swapped out by the container between resolution and use.
+type Root string
+
+// String returns the path to the root on the host.
+func (r Root) String() string {
+	return string(r)
+}
+
+// Open returns an O_PATH handle to the specified path in the root.
+// Symlinks in the path, including the final component, are resolved in the
+// root.
+func (r Root) Open(path string) (*os.File, error) {
+	return securejoin.OpenInRoot(string(r), path)
+}
+
+// OpenFile opens the specified path in the root with the specified flags.
+// The path is resolved as for Open and the resulting handle is then reopened
+// through procfs.
+func (r Root) OpenFile(path string, flags int) (*os.File, error) {
+	handle, err := r.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer handle.Close()
+
+	return securejoin.Reopen(handle, flags)
+}
+
+// HasPath checks whether the specified path exists in the root.
+func (r Root) HasPath(path string) bool {
+	handle, err := r.Open(path)
+	if err != nil {
+		return false
+	}
+	_ = handle.Close()
+	return true
+}
+
+// MkdirAll creates the specified directory (and any missing parents) in the
+// root and returns a handle to it.
+//
+// The existing components of the path are resolved in the root as for Open and
+// the missing directories are then created relative to a handle to their
+// parent, so that the container cannot redirect the creation outside of the
+// root by swapping a path component for a symlink.
+func (r Root) MkdirAll(path string, perm os.FileMode) (*os.File, error) {
+	rootDir, err := r.openRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer rootDir.Close()
+
+	return securejoin.MkdirAllHandle(rootDir, path, perm)
+}
+
+// Chmod changes the mode of the file referred to by the specified handle.
+// The handle is expected to have been returned by Open and may be an O_PATH
+// handle.
+func (r Root) Chmod(handle *os.File, mode os.FileMode) error {
+	// A chmod on an O_PATH file descriptor is not supported (fchmod returns
+	// EBADF) and as such we use the magic link in procfs instead.
+	return os.Chmod(procSelfFd(handle), mode)
+}
+
+// Chown changes the owner of the file referred to by the specified handle.
+// As is the case for os.Chown, a uid or gid of -1 leaves that ID unchanged.
+func (r Root) Chown(handle *os.File, uid int, gid int) error {
+	err := unix.Fchownat(int(handle.Fd()), "", uid, gid, unix.AT_EMPTY_PATH|unix.AT_SYMLINK_NOFOLLOW)
+	if err != nil {
+		return &os.PathError{Op: "fchownat", Path: handle.Name(), Err: err}
+	}
+	return nil
+}
+
+// Mknod creates a device node with the specified mode and device number at
+// the specified path in the root and returns a handle to it. Missing parent
+// directories are created as for MkdirAll. The node is created relative to a
+// handle to its parent so that the final path component is never resolved;
+// an error satisfying errors.Is(err, os.ErrExist) is returned if a file (or
+// symlink) already exists at the path.
+func (r Root) Mknod(path string, mode uint32, dev uint64) (*os.File, error) {
+	parent, name, err := r.mkdirAllParent(path)
+	if err != nil {
+		return nil, err
+	}
+	defer parent.Close()
+
+	if err := unix.Mknodat(int(parent.Fd()), name, mode, int(dev)); err != nil {
+		return nil, &os.PathError{Op: "mknodat", Path: filepath.Join(parent.Name(), name), Err: err}
+	}
+	return openPathAt(parent, name)
+}
+
+// CreateFile returns a handle to the regular file at the specified path in the
+// root, creating an empty file with the specified permissions if nothing
+// exists at the path. Missing parent directories are created as for MkdirAll.
+// An existing path is resolved as for Open.
+func (r Root) CreateFile(path string, perm os.FileMode) (*os.File, error) {
+	if handle, err := r.Open(path); err == nil {
+		return handle, nil
+	}
+
+	parent, name, err := r.mkdirAllParent(path)
+	if err != nil {
+		return nil, err
+	}
+	defer parent.Close()
+
+	fd, err := unix.Openat(int(parent.Fd()), name, unix.O_RDONLY|unix.O_CREAT|unix.O_NOFOLLOW|unix.O_CLOEXEC, uint32(perm.Perm()))
+	if err != nil {
+		return nil, &os.PathError{Op: "openat", Path: filepath.Join(parent.Name(), name), Err: err}
+	}
+	_ = unix.Close(fd)
+	return openPathAt(parent, name)
+}
+
+// FdPath returns the procfs magic link for the specified handle. This can be
+// passed to calls such as mount(2) that do not accept a file descriptor so that
+// the file referred to by the handle is used without resolving its path again.
+func FdPath(handle *os.File) string {
+	return procSelfFd(handle)
+}
+
+// Path returns the path of the file referred to by the specified handle
+// relative to the root. The returned path is absolute in the root with all
+// symlinks resolved.
+func (r Root) Path(handle *os.File) (string, error) {
+	rootDir, err := r.openRoot()
+	if err != nil {
+		return "", err
+	}
+	defer rootDir.Close()
+
+	rootPath, err := os.Readlink(procSelfFd(rootDir))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root path: %w", err)
+	}
+	handlePath, err := os.Readlink(procSelfFd(handle))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve handle path: %w", err)
+	}
+
+	relative, err := filepath.Rel(rootPath, handlePath)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, "../") {
+		return "", fmt.Errorf("path %v is not in root %v", handlePath, rootPath)
+	}
+	return filepath.Join("/", relative), nil
+}
+
+// GlobFiles matches the specified pattern in the root.
+// Only the final path component of the pattern may contain wildcards. The
+// files that match must be regular files, with symlinks and directories
+// ignored. The returned paths are absolute in the root.
+func (r Root) GlobFiles(pattern string) ([]string, error) {
+	dirName, filePattern := filepath.Split(pattern)
+	if _, err := filepath.Match(filePattern, ""); err != nil {
+		return nil, err
+	}
+
+	dir, err := r.OpenFile(dirName, unix.O_RDONLY|unix.O_DIRECTORY)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+
+	resolvedDir, err := r.Path(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, name := range names {
+		if matched, _ := filepath.Match(filePattern, name); !matched {
+			continue
+		}
+		var stat unix.Stat_t
+		if err := unix.Fstatat(int(dir.Fd()), name, &stat, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+			return nil, err
+		}
+		// Ignore symlinks, directories, and device nodes.
+		if stat.Mode&unix.S_IFMT != unix.S_IFREG {
+			continue
+		}
+		files = append(files, filepath.Join(resolvedDir, name))
+	}
+	return files, nil
+}
+
+// CreateTemp creates a new file in the specified directory handle.
+// As is the case for os.CreateTemp, the filename is generated by replacing the
+// last "*" in the pattern with a random string. The file is created with
+// O_EXCL and O_NOFOLLOW so that an existing file (or symlink) is never opened.
+func CreateTemp(dir *os.File, pattern string) (*os.File, error) {
+	if strings.ContainsRune(pattern, os.PathSeparator) {
+		return nil, fmt.Errorf("pattern %q contains a path separator", pattern)
+	}
+	prefix, suffix := pattern, ""
+	if pos := strings.LastIndexByte(pattern, '*'); pos != -1 {
+		prefix, suffix = pattern[:pos], pattern[pos+1:]
+	}
+
+	for try := 0; try < 10000; try++ {
+		name := prefix + nextRandom() + suffix
+		fd, err := unix.Openat(int(dir.Fd()), name, unix.O_RDWR|unix.O_CREAT|unix.O_EXCL|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0600)
+		if errors.Is(err, unix.EEXIST) {
+			continue
+		}
+		if err != nil {
+			return nil, &os.PathError{Op: "openat", Path: filepath.Join(dir.Name(), name), Err: err}
+		}
+		return os.NewFile(uintptr(fd), filepath.Join(dir.Name(), name)), nil
+	}
+	return nil, &os.PathError{Op: "createtemp", Path: filepath.Join(dir.Name(), pattern), Err: os.ErrExist}
+}
+
+// nextRandom returns a random string for use in temporary file names.
+func nextRandom() string {
+	return strconv.FormatUint(uint64(rand.Uint32()), 10)
+}
+
+// mkdirAllParent creates the parent directory of the specified path in the
+// root and returns a handle to it along with the final path component.
+func (r Root) mkdirAllParent(path string) (*os.File, string, error) {
+	dir, name := filepath.Split(filepath.Join("/", path))
+	if name == "" {
+		return nil, "", fmt.Errorf("path %q does not refer to a file", path)
+	}
+	parent, err := r.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, "", err
+	}
+	return parent, name, nil
+}
+
+// openPathAt returns an O_PATH handle to the specified entry in the parent
+// directory without following a final symlink.
+func openPathAt(parent *os.File, name string) (*os.File, error) {
+	path := filepath.Join(parent.Name(), name)
+	fd, err := unix.Openat(int(parent.Fd()), name, unix.O_PATH|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "openat", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(fd), path), nil
+}
+
+// openRoot returns an O_PATH handle to the root itself.
+func (r Root) openRoot() (*os.File, error) {
+	return os.OpenFile(string(r), unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+}
+
+// procSelfFd returns the procfs magic link for the specified handle.
+func procSelfFd(handle *os.File) string {
+	return "/proc/self/fd/" + strconv.Itoa(int(handle.Fd()))
+}
@@ -0,0 +1,440 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containerroot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestOpen(t *testing.T) {
+	testCases := []struct {
+		description  string
+		contents     map[string]string
+		path         string
+		expectedPath string
+		expectError  bool
+	}{
+		{
+			description:  "regular file",
+			contents:     map[string]string{"/dev/nvidia0": ""},
+			path:         "/dev/nvidia0",
+			expectedPath: "/dev/nvidia0",
+		},
+		{
+			description: "absolute symlink to host path is resolved in root",
+			contents: map[string]string{
+				"/dev":                        "symlink={{ .hostRoot }}",
+				"{{ .hostRoot }}/nvidia0":     "",
+				"/{{ .hostRoot }}/nvidia0":    "",
+				"/some/other/path/to/nvidia0": "",
+			},
+			path:         "/dev/nvidia0",
+			expectedPath: "/{{ .hostRoot }}/nvidia0",
+		},
+		{
+			description: "absolute symlink to host path does not exist in root",
+			contents: map[string]string{
+				"/dev":                    "symlink={{ .hostRoot }}",
+				"{{ .hostRoot }}/nvidia0": "",
+			},
+			path:        "/dev/nvidia0",
+			expectError: true,
+		},
+		{
+			description: "relative symlink cannot escape root",
+			contents: map[string]string{
+				"/dev":                    "symlink=../../../../../../../{{ .hostRoot }}",
+				"{{ .hostRoot }}/nvidia0": "",
+			},
+			path:        "/dev/nvidia0",
+			expectError: true,
+		},
+		{
+			description: "relative symlink is clamped to root",
+			contents: map[string]string{
+				"/dev/nvidia0": "symlink=../../../../../../../nvidia0",
+				"/nvidia0":     "",
+			},
+			path:         "/dev/nvidia0",
+			expectedPath: "/nvidia0",
+		},
+		{
+			description: "dot-dot in path is clamped to root",
+			contents: map[string]string{
+				"/nvidia0": "",
+			},
+			path:         "../../../../../../../nvidia0",
+			expectedPath: "/nvidia0",
+		},
+		{
+			description: "symlink loop is an error",
+			contents: map[string]string{
+				"/dev/a": "symlink=/dev/b",
+				"/dev/b": "symlink=/dev/a",
+			},
+			path:        "/dev/a",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostRoot, containerRoot := makeRoots(t, tc.contents)
+
+			handle, err := Root(containerRoot).Open(tc.path)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer handle.Close()
+
+			path, err := Root(containerRoot).Path(handle)
+			require.NoError(t, err)
+			require.Equal(t, filepath.Clean(replaceRoots(tc.expectedPath, hostRoot, "")), path)
+		})
+	}
+}
+
+func TestMkdirAll(t *testing.T) {
+	testCases := []struct {
+		description  string
+		contents     map[string]string
+		path         string
+		expectedPath string
+		expectError  bool
+	}{
+		{
+			description:  "directory is created",
+			path:         "/etc/ld.so.conf.d",
+			expectedPath: "/etc/ld.so.conf.d",
+		},
+		{
+			description: "absolute symlink to host directory is resolved in root",
+			contents: map[string]string{
+				"/etc":                   "symlink={{ .hostRoot }}",
+				"{{ .hostRoot }}/empty":  "",
+				"/{{ .hostRoot }}/empty": "",
+			},
+			path:         "/etc/ld.so.conf.d",
+			expectedPath: "/{{ .hostRoot }}/ld.so.conf.d",
+		},
+		{
+			description: "relative symlink to host directory is resolved in root",
+			contents: map[string]string{
+				"/etc":                   "symlink=../../../../../../../../{{ .hostRoot }}",
+				"{{ .hostRoot }}/empty":  "",
+				"/{{ .hostRoot }}/empty": "",
+			},
+			path:         "/etc/ld.so.conf.d",
+			expectedPath: "/{{ .hostRoot }}/ld.so.conf.d",
+		},
+		{
+			description: "dangling symlink is an error",
+			contents: map[string]string{
+				"/etc":                  "symlink={{ .hostRoot }}",
+				"{{ .hostRoot }}/empty": "",
+			},
+			path:        "/etc/ld.so.conf.d",
+			expectError: true,
+		},
+		{
+			description: "parent directory components are clamped to root",
+			contents: map[string]string{
+				"/etc/empty": "",
+			},
+			path:         "/../../../etc/../etc/ld.so.conf.d",
+			expectedPath: "/etc/ld.so.conf.d",
+		},
+		{
+			description: "parent directory components in missing directories are an error",
+			path:        "/etc/missing/../ld.so.conf.d",
+			expectError: true,
+		},
+		{
+			description: "file component is an error",
+			contents: map[string]string{
+				"/etc": "",
+			},
+			path:        "/etc/ld.so.conf.d",
+			expectError: true,
+		},
+		{
+			description: "symlink loop is an error",
+			contents: map[string]string{
+				"/etc/a": "symlink=/etc/b",
+				"/etc/b": "symlink=/etc/a",
+			},
+			path:        "/etc/a/ld.so.conf.d",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostRoot, containerRoot := makeRoots(t, tc.contents)
+
+			handle, err := Root(containerRoot).MkdirAll(tc.path, 0755)
+			if tc.expectError {
+				require.Error(t, err)
+				requireHostRootUnchanged(t, hostRoot, tc.contents)
+				return
+			}
+			require.NoError(t, err)
+			defer handle.Close()
+
+			path, err := Root(containerRoot).Path(handle)
+			require.NoError(t, err)
+			require.Equal(t, filepath.Clean(replaceRoots(tc.expectedPath, hostRoot, "")), path)
+			require.DirExists(t, filepath.Join(containerRoot, path))
+			requireHostRootUnchanged(t, hostRoot, tc.contents)
+		})
+	}
+}
+
+func TestChmod(t *testing.T) {
+	hostRoot, containerRoot := makeRoots(t, map[string]string{
+		"/dev/dri":               "symlink={{ .hostRoot }}",
+		"{{ .hostRoot }}/card0":  "",
+		"/{{ .hostRoot }}/card0": "",
+	})
+	require.NoError(t, os.Chmod(filepath.Join(hostRoot, "card0"), 0600))
+
+	handle, err := Root(containerRoot).Open("/dev/dri/card0")
+	require.NoError(t, err)
+	defer handle.Close()
+
+	require.NoError(t, Root(containerRoot).Chmod(handle, 0755))
+
+	info, err := os.Stat(filepath.Join(containerRoot, hostRoot, "card0"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(hostRoot, "card0"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestGlobFiles(t *testing.T) {
+	testCases := []struct {
+		description   string
+		contents      map[string]string
+		pattern       string
+		expectedFiles []string
+		expectError   bool
+	}{
+		{
+			description: "regular files are matched",
+			contents: map[string]string{
+				"/usr/local/cuda/compat/libcuda.so.333.88.99": "",
+				"/usr/local/cuda/compat/libcuda.so.1":         "symlink=libcuda.so.333.88.99",
+				"/usr/local/cuda/compat/libcuda.so.dir.1/foo": "",
+			},
+			pattern:       "/usr/local/cuda/compat/libcuda.so.*",
+			expectedFiles: []string{"/usr/local/cuda/compat/libcuda.so.333.88.99"},
+		},
+		{
+			description: "absolute symlink to host is resolved in root",
+			contents: map[string]string{
+				"/usr/local/cuda":                        "symlink={{ .hostRoot }}",
+				"{{ .hostRoot }}/compat/libcuda.so.1.2":  "",
+				"/{{ .hostRoot }}/compat/libcuda.so.3.4": "",
+			},
+			pattern:       "/usr/local/cuda/compat/libcuda.so.*",
+			expectedFiles: []string{"/{{ .hostRoot }}/compat/libcuda.so.3.4"},
+		},
+		{
+			description: "relative symlink to host does not exist in root",
+			contents: map[string]string{
+				"/usr/local/cuda":                       "symlink=../../../../../../../../{{ .hostRoot }}",
+				"{{ .hostRoot }}/compat/libcuda.so.1.2": "",
+			},
+			pattern:     "/usr/local/cuda/compat/libcuda.so.*",
+			expectError: true,
+		},
+		{
+			description: "symlink loop is an error",
+			contents: map[string]string{
+				"/usr/local/cuda":   "symlink=/usr/local/cuda2",
+				"/usr/local/cuda2":  "symlink=/usr/local/cuda",
+				"/compat/libcuda.1": "",
+			},
+			pattern:     "/usr/local/cuda/compat/libcuda.so.*",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostRoot, containerRoot := makeRoots(t, tc.contents)
+
+			files, err := Root(containerRoot).GlobFiles(tc.pattern)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var expectedFiles []string
+			for _, f := range tc.expectedFiles {
+				expectedFiles = append(expectedFiles, filepath.Clean(replaceRoots(f, hostRoot, "")))
+			}
+			require.EqualValues(t, expectedFiles, files)
+		})
+	}
+}
+
+func TestCreateTemp(t *testing.T) {
+	hostRoot, containerRoot := makeRoots(t, map[string]string{
+		"/etc":                   "symlink={{ .hostRoot }}",
+		"/{{ .hostRoot }}/empty": "",
+	})
+
+	dir, err := Root(containerRoot).MkdirAll("/etc/ld.so.conf.d", 0755)
+	require.NoError(t, err)
+	defer dir.Close()
+
+	f, err := CreateTemp(dir, "00-test-*.conf")
+	require.NoError(t, err)
+	defer f.Close()
+
+	matches, err := filepath.Glob(filepath.Join(containerRoot, hostRoot, "/ld.so.conf.d/00-test-*.conf"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+
+	entries, err := os.ReadDir(hostRoot)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestMknod(t *testing.T) {
+	hostRoot, containerRoot := makeRoots(t, map[string]string{
+		"/dev":                   "symlink={{ .hostRoot }}",
+		"/{{ .hostRoot }}/empty": "",
+		"/run/existing":          "symlink={{ .hostRoot }}/target",
+	})
+
+	// A FIFO is used since creating it does not require privileges.
+	handle, err := Root(containerRoot).Mknod("/dev/nvidia-caps/nvidia-cap1", unix.S_IFIFO|0640, 0)
+	require.NoError(t, err)
+	defer handle.Close()
+
+	info, err := os.Lstat(filepath.Join(containerRoot, hostRoot, "nvidia-caps", "nvidia-cap1"))
+	require.NoError(t, err)
+	require.Equal(t, os.ModeNamedPipe, info.Mode().Type())
+
+	_, err = Root(containerRoot).Mknod("/run/existing", unix.S_IFIFO|0640, 0)
+	require.ErrorIs(t, err, os.ErrExist)
+
+	entries, err := os.ReadDir(hostRoot)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestCreateFile(t *testing.T) {
+	hostRoot, containerRoot := makeRoots(t, map[string]string{
+		"/usr/lib":               "symlink={{ .hostRoot }}",
+		"/{{ .hostRoot }}/empty": "",
+		"/etc/existing":          "content",
+		"/etc/dangling":          "symlink={{ .hostRoot }}/created",
+	})
+
+	handle, err := Root(containerRoot).CreateFile("/usr/lib/libcuda.so.1", 0644)
+	require.NoError(t, err)
+	handle.Close()
+	require.FileExists(t, filepath.Join(containerRoot, hostRoot, "libcuda.so.1"))
+
+	handle, err = Root(containerRoot).CreateFile("/etc/existing", 0644)
+	require.NoError(t, err)
+	handle.Close()
+	contents, err := os.ReadFile(filepath.Join(containerRoot, "etc", "existing"))
+	require.NoError(t, err)
+	require.Equal(t, "content", string(contents))
+
+	_, err = Root(containerRoot).CreateFile("/etc/dangling", 0644)
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(hostRoot)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+// makeRoots creates a host root and a container root in a temporary directory.
+// The specified contents are created relative to the container root with
+// {{ .hostRoot }} replaced by the (absolute) path to the host root. Contents
+// with a "symlink=" prefix are created as symlinks.
+func makeRoots(t *testing.T, contents map[string]string) (string, string) {
+	tmpDir := t.TempDir()
+	hostRoot := filepath.Join(tmpDir, "host-root")
+	containerRoot := filepath.Join(tmpDir, "container-root")
+	require.NoError(t, os.MkdirAll(hostRoot, 0755))
+	require.NoError(t, os.MkdirAll(containerRoot, 0755))
+
+	for name, content := range contents {
+		target := replaceRoots(name, hostRoot, containerRoot)
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+
+		content = replaceRoots(content, hostRoot, "")
+		if strings.HasPrefix(content, "symlink=") {
+			require.NoError(t, os.Symlink(strings.TrimPrefix(content, "symlink="), target))
+			continue
+		}
+		require.NoError(t, os.WriteFile(target, []byte(content), 0600))
+	}
+	return hostRoot, containerRoot
+}
+
+// replaceRoots replaces the {{ .hostRoot }} placeholder and prefixes paths
+// that are not explicitly in the host root with the container root.
+func replaceRoots(path string, hostRoot string, containerRoot string) string {
+	if strings.HasPrefix(path, "{{ .hostRoot }}") {
+		return strings.ReplaceAll(path, "{{ .hostRoot }}", hostRoot)
+	}
+	path = strings.ReplaceAll(path, "{{ .hostRoot }}", hostRoot)
+	if containerRoot == "" {
+		return path
+	}
+	return filepath.Join(containerRoot, path)
+}
+
+// requireHostRootUnchanged checks that the host root only contains the files
+// (and their parent directories) that were explicitly created in it.
+func requireHostRootUnchanged(t *testing.T, hostRoot string, contents map[string]string) {
+	expected := map[string]bool{hostRoot: true}
+	for name := range contents {
+		if !strings.HasPrefix(name, "{{ .hostRoot }}") {
+			continue
+		}
+		for path := replaceRoots(name, hostRoot, ""); path != hostRoot; path = filepath.Dir(path) {
+			expected[path] = true
+		}
+	}
+	err := filepath.Walk(hostRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		require.True(t, expected[path], "unexpected path %v in host root", path)
+		return nil
+	})
+	require.NoError(t, err)
+}
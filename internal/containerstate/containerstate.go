@@ -0,0 +1,285 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containerstate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultRoot is the default directory in which per-container state is
+	// tracked.
+	DefaultRoot = "/run/nvidia-container-toolkit/containers"
+
+	stateFilename = "state.json"
+	artifactsDir  = "artifacts"
+)
+
+// A Store tracks host artifacts that are created for a container and which
+// must be removed when the container stops. Each container has a directory,
+// named after the container ID, which holds the state file for the container
+// and the directory in which its artifacts are created. Only artifacts in this
+// directory can be tracked and removed.
+type Store struct {
+	root string
+}
+
+// state is the on-disk representation of the artifacts tracked for a container.
+type state struct {
+	Artifacts []string `json:"artifacts"`
+}
+
+// New creates a store rooted at the specified directory.
+func New(root string) *Store {
+	if root == "" {
+		root = DefaultRoot
+	}
+	return &Store{root: root}
+}
+
+// Dir creates the directory in which artifacts for the specified container
+// are created and returns its path.
+func (s *Store) Dir(containerID string) (string, error) {
+	dir, err := s.artifactsDir(containerID)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create state directory for container %v: %w", containerID, err)
+	}
+	return dir, nil
+}
+
+// ArtifactPath returns the path of the artifact with the specified name in the
+// artifacts directory of the specified container.
+func (s *Store) ArtifactPath(containerID string, name string) (string, error) {
+	dir, err := s.artifactsDir(containerID)
+	if err != nil {
+		return "", err
+	}
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid artifact name %q", name)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Track records the specified host paths as artifacts of the specified
+// container. Paths must be in the directory returned by Dir for the container
+// and are removed when Cleanup is called for the container.
+func (s *Store) Track(containerID string, artifacts ...string) error {
+	dir, err := s.artifactsDir(containerID)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		if !isInDir(dir, artifact) {
+			return fmt.Errorf("artifact path %q is not in %v", artifact, dir)
+		}
+	}
+
+	current, err := s.load(containerID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, artifact := range current.Artifacts {
+		seen[artifact] = true
+	}
+	for _, artifact := range artifacts {
+		artifact = filepath.Clean(artifact)
+		if seen[artifact] {
+			continue
+		}
+		seen[artifact] = true
+		current.Artifacts = append(current.Artifacts, artifact)
+	}
+
+	return s.save(containerID, current)
+}
+
+// Artifacts returns the artifacts that are tracked for the specified container.
+func (s *Store) Artifacts(containerID string) ([]string, error) {
+	current, err := s.load(containerID)
+	if err != nil {
+		return nil, err
+	}
+	return current.Artifacts, nil
+}
+
+// Cleanup removes all artifacts tracked for the specified container followed
+// by the state for the container itself. Artifacts that have already been
+// removed are ignored and tracked paths that are not in the artifacts
+// directory of the container are never removed. If no state exists for the
+// container, this is a no-op.
+func (s *Store) Cleanup(containerID string) error {
+	dir, err := s.artifactsDir(containerID)
+	if err != nil {
+		return err
+	}
+	current, err := s.load(containerID)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, artifact := range current.Artifacts {
+		if err := removeArtifact(dir, artifact); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to remove %v: %w", artifact, err))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	if err := os.RemoveAll(filepath.Join(s.root, containerID)); err != nil {
+		return fmt.Errorf("failed to remove container state: %w", err)
+	}
+	return nil
+}
+
+// removeArtifact removes the specified artifact if it is in the specified
+// directory. Since symlinks in the path of the artifact could cause a path
+// outside of the directory to be removed, the parent of the artifact is
+// resolved before checking this.
+func removeArtifact(dir string, artifact string) error {
+	if !isInDir(dir, artifact) {
+		return fmt.Errorf("refusing to remove path outside of %v", dir)
+	}
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resolvedParent, err := filepath.EvalSymlinks(filepath.Dir(artifact))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resolvedParent != resolvedDir && !isInDir(resolvedDir, resolvedParent) {
+		return fmt.Errorf("refusing to remove path outside of %v", dir)
+	}
+	return os.RemoveAll(filepath.Join(resolvedParent, filepath.Base(artifact)))
+}
+
+// isInDir checks whether the specified path is an absolute path below the
+// specified directory.
+func isInDir(dir string, path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	rel, err := filepath.Rel(dir, filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func (s *Store) load(containerID string) (*state, error) {
+	filename, err := s.statePath(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return &state{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read container state: %w", err)
+	}
+
+	var current state
+	if err := json.Unmarshal(contents, &current); err != nil {
+		return nil, fmt.Errorf("failed to parse container state: %w", err)
+	}
+	return &current, nil
+}
+
+// save writes the state for the specified container. The state is first
+// written to a temporary file which is then renamed so that a partially
+// written state is never observed.
+func (s *Store) save(containerID string, current *state) error {
+	filename, err := s.statePath(containerID)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to marshal container state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+stateFilename+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write container state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write container state: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("failed to save container state: %w", err)
+	}
+	return nil
+}
+
+// statePath returns the path to the state file for the specified container.
+func (s *Store) statePath(containerID string) (string, error) {
+	if err := validateContainerID(containerID); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, containerID, stateFilename), nil
+}
+
+// artifactsDir returns the path to the directory in which the artifacts for
+// the specified container are created.
+func (s *Store) artifactsDir(containerID string) (string, error) {
+	if err := validateContainerID(containerID); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, containerID, artifactsDir), nil
+}
+
+// validateContainerID checks that the specified container ID can be used as
+// a directory name. Since the container ID is used as a directory name, IDs
+// that could refer to paths outside of the root are rejected.
+func validateContainerID(containerID string) error {
+	if containerID == "" || strings.HasPrefix(containerID, ".") || strings.ContainsAny(containerID, `/\`) {
+		return fmt.Errorf("invalid container ID %q", containerID)
+	}
+	return nil
+}
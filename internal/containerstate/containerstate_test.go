@@ -0,0 +1,137 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package containerstate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "state")
+	s := New(root)
+
+	artifactsDir, err := s.Dir("c1")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "c1", "artifacts"), artifactsDir)
+
+	cacheFile, err := s.ArtifactPath("c1", "ld.so.cache")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cacheFile, nil, 0600))
+	overlayDir := filepath.Join(artifactsDir, "overlay")
+	require.NoError(t, os.MkdirAll(filepath.Join(overlayDir, "etc"), 0755))
+	missing := filepath.Join(artifactsDir, "missing")
+
+	otherDir, err := s.Dir("c2")
+	require.NoError(t, err)
+	otherFile := filepath.Join(otherDir, "ld.so.cache")
+	require.NoError(t, os.WriteFile(otherFile, nil, 0600))
+
+	require.NoError(t, s.Track("c1", cacheFile, overlayDir))
+	require.NoError(t, s.Track("c1", cacheFile, missing))
+	require.NoError(t, s.Track("c2", otherFile))
+
+	artifacts, err := s.Artifacts("c1")
+	require.NoError(t, err)
+	require.EqualValues(t, []string{cacheFile, overlayDir, missing}, artifacts)
+
+	require.NoError(t, s.Cleanup("c1"))
+	require.NoFileExists(t, cacheFile)
+	require.NoDirExists(t, overlayDir)
+	require.NoDirExists(t, filepath.Join(root, "c1"))
+	require.FileExists(t, otherFile)
+	require.FileExists(t, filepath.Join(root, "c2", "state.json"))
+
+	// Cleaning up a container without state is a no-op.
+	require.NoError(t, s.Cleanup("c1"))
+	require.NoError(t, s.Cleanup("unknown"))
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestStoreInvalidInput(t *testing.T) {
+	s := New(t.TempDir())
+
+	for _, id := range []string{"", ".", "..", ".hidden", "../c1", "a/b"} {
+		require.Error(t, s.Track(id, "/tmp/file"), "container ID %q", id)
+		require.Error(t, s.Cleanup(id), "container ID %q", id)
+		_, err := s.Dir(id)
+		require.Error(t, err, "container ID %q", id)
+	}
+
+	for _, name := range []string{"", ".", "..", "../state.json", "a/b"} {
+		_, err := s.ArtifactPath("c1", name)
+		require.Error(t, err, "artifact name %q", name)
+	}
+
+	dir, err := s.Dir("c1")
+	require.NoError(t, err)
+	for _, artifact := range []string{"relative/path", "/tmp/file", dir, filepath.Join(dir, ".."), filepath.Join(dir, "..", "state.json")} {
+		require.ErrorContains(t, s.Track("c1", artifact), "is not in", "artifact %q", artifact)
+	}
+}
+
+func TestStoreCleanupOutsideArtifactsDir(t *testing.T) {
+	root := t.TempDir()
+	s := New(root)
+
+	outsideDir := t.TempDir()
+	outsideFile := filepath.Join(outsideDir, "file")
+	require.NoError(t, os.WriteFile(outsideFile, nil, 0600))
+
+	testCases := []struct {
+		description string
+		state       func(dir string) string
+	}{
+		{
+			description: "path outside of the artifacts directory",
+			state: func(string) string {
+				return outsideFile
+			},
+		},
+		{
+			description: "path through a symlink to outside of the artifacts directory",
+			state: func(dir string) string {
+				require.NoError(t, os.Symlink(outsideDir, filepath.Join(dir, "link")))
+				return filepath.Join(dir, "link", "file")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir, err := s.Dir("c1")
+			require.NoError(t, err)
+			defer os.RemoveAll(filepath.Join(root, "c1"))
+
+			// The state file is written directly to simulate state that
+			// was not created using Track.
+			contents, err := json.Marshal(state{Artifacts: []string{tc.state(dir)}})
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(root, "c1", "state.json"), contents, 0600))
+
+			require.ErrorContains(t, s.Cleanup("c1"), "refusing to remove path outside of")
+			require.FileExists(t, outsideFile)
+		})
+	}
+}
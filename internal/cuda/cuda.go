@@ -18,6 +18,7 @@ package cuda
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/NVIDIA/go-nvml/pkg/dl"
 )
@@ -47,6 +48,8 @@ CUresult CUDAAPI cuInit(unsigned int Flags);
 CUresult CUDAAPI cuDriverGetVersion(int *driverVersion);
 CUresult CUDAAPI cuDeviceGet(CUdevice *device, int ordinal);
 CUresult CUDAAPI cuDeviceGetAttribute(int *pi, CUdevice_attribute attrib, CUdevice dev);
+CUresult CUDAAPI cuDeviceGetCount(int *count);
+CUresult CUDAAPI cuDeviceTotalMem_v2(size_t *bytes, CUdevice dev);
 */
 import "C"
 
@@ -58,6 +61,16 @@ const (
 // cuda stores a reference the cuda dynamic library
 var lib *dl.DynamicLibrary
 
+// libraryPath is the path (or name) of the CUDA driver library that is loaded.
+var libraryPath = libraryName
+
+// SetLibraryPath sets the path of the CUDA driver library that is loaded. This
+// allows the library to be loaded from a driver root other than /. By default
+// libcuda.so.1 is loaded using the search path of the dynamic linker.
+func SetLibraryPath(path string) {
+	libraryPath = path
+}
+
 // Version returns the CUDA version of the driver as a string or an error if this
 // cannot be determined.
 func Version() (string, error) {
@@ -124,8 +137,83 @@ func ComputeCapability(index int) (string, error) {
 	return fmt.Sprintf("%d.%d", major, minor), nil
 }
 
+// DeviceCount returns the number of CUDA devices or an error if this cannot be determined.
+func DeviceCount() (int, error) {
+	lib, err := load()
+	if err != nil {
+		return 0, err
+	}
+	defer lib.Close()
+
+	if err := lib.Lookup("cuInit"); err != nil {
+		return 0, fmt.Errorf("failed to lookup symbol: %v", err)
+	}
+	if err := lib.Lookup("cuDeviceGetCount"); err != nil {
+		return 0, fmt.Errorf("failed to lookup symbol: %v", err)
+	}
+
+	if result := C.cuInit(C.uint(0)); result != C.CUDA_SUCCESS {
+		return 0, fmt.Errorf("failed to initialize CUDA: result=%v", result)
+	}
+
+	var count C.int
+	if result := C.cuDeviceGetCount(&count); result != C.CUDA_SUCCESS {
+		return 0, fmt.Errorf("failed to get CUDA device count: result=%v", result)
+	}
+
+	return int(count), nil
+}
+
+// TotalMemory returns the total memory in bytes of a device with the specified index or an error if this cannot
+// be determined.
+func TotalMemory(index int) (uint64, error) {
+	lib, err := load()
+	if err != nil {
+		return 0, err
+	}
+	defer lib.Close()
+
+	if err := lib.Lookup("cuInit"); err != nil {
+		return 0, fmt.Errorf("failed to lookup symbol: %v", err)
+	}
+	if err := lib.Lookup("cuDeviceGet"); err != nil {
+		return 0, fmt.Errorf("failed to lookup symbol: %v", err)
+	}
+	if err := lib.Lookup("cuDeviceTotalMem_v2"); err != nil {
+		return 0, fmt.Errorf("failed to lookup symbol: %v", err)
+	}
+
+	if result := C.cuInit(C.uint(0)); result != C.CUDA_SUCCESS {
+		return 0, fmt.Errorf("failed to initialize CUDA: result=%v", result)
+	}
+
+	var device C.CUdevice
+	if result := C.cuDeviceGet(&device, C.int(index)); result != C.CUDA_SUCCESS {
+		return 0, fmt.Errorf("failed to get CUDA device %v: result=%v", index, result)
+	}
+
+	var bytes C.size_t
+	if result := C.cuDeviceTotalMem_v2(&bytes, device); result != C.CUDA_SUCCESS {
+		return 0, fmt.Errorf("failed to get total memory for device %v: result=%v", index, result)
+	}
+
+	return uint64(bytes), nil
+}
+
+// SetVisibleDevices restricts the devices that are visible to the CUDA driver
+// API to the specified comma-separated list of device indices or UUIDs. The
+// devices are ordered by PCI bus ID so that the indices match the ones used
+// by NVML. Since the environment is only read when CUDA is initialized, this
+// must be called before any other function in this package.
+func SetVisibleDevices(devices string) error {
+	if err := os.Setenv("CUDA_DEVICE_ORDER", "PCI_BUS_ID"); err != nil {
+		return err
+	}
+	return os.Setenv("CUDA_VISIBLE_DEVICES", devices)
+}
+
 func load() (*dl.DynamicLibrary, error) {
-	lib := dl.New(libraryName, libraryLoadFlags)
+	lib := dl.New(libraryPath, libraryLoadFlags)
 	if lib == nil {
 		return nil, fmt.Errorf("error instantiating DynamicLibrary for CUDA")
 	}
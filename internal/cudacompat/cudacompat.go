@@ -0,0 +1,202 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cudacompat
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/cuda"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+const (
+	// LdsoconfdFilenamePattern specifies the pattern for the filename
+	// in ld.so.conf.d that includes a reference to the CUDA compat path.
+	// The 00-compat prefix is chosen to ensure that these libraries have a
+	// higher precedence than other libraries on the system.
+	LdsoconfdFilenamePattern = "00-compat-*.conf"
+)
+
+// DefaultRoots defines the paths in the container that are searched for CUDA
+// Forward Compatibility libraries if no roots are specified.
+var DefaultRoots = []string{"/usr/local/cuda/compat"}
+
+// A Candidate represents a CUDA Forward Compatibility library detected in a
+// container.
+type Candidate struct {
+	// Root is the compat root that the library was found in.
+	Root string `json:"root"`
+	// Dir is the resolved directory containing the library in the container.
+	Dir string `json:"dir"`
+	// Library is the resolved path to the library in the container.
+	Library string `json:"library"`
+	// Version is the driver version of the library as extracted from its
+	// filename.
+	Version string `json:"version"`
+	// Valid indicates whether the library can be used with the host driver.
+	Valid bool `json:"valid"`
+	// Reason explains why the library is (or is not) valid.
+	Reason string `json:"reason"`
+
+	version Version
+}
+
+// A Decision records the selection of a CUDA Forward Compatibility root.
+type Decision struct {
+	HostDriverVersion string      `json:"hostDriverVersion"`
+	Roots             []string    `json:"roots"`
+	Candidates        []Candidate `json:"candidates,omitempty"`
+	Selected          *Candidate  `json:"selected,omitempty"`
+	Reason            string      `json:"reason"`
+}
+
+// SelectedDir returns the container directory of the selected compat libraries.
+// If no libraries were selected, an empty string is returned.
+func (d *Decision) SelectedDir() string {
+	if d == nil || d.Selected == nil {
+		return ""
+	}
+	return d.Selected.Dir
+}
+
+// String returns a human-readable summary of the decision.
+func (d *Decision) String() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%s", d.Reason)
+	for _, c := range d.Candidates {
+		fmt.Fprintf(&s, "; %v (%v): %v", c.Library, c.Version, c.Reason)
+	}
+	return s.String()
+}
+
+// Select determines which (if any) of the CUDA Forward Compatibility libraries
+// in the specified container roots should be used with the host driver.
+//
+// The libcuda.so.MAJOR.MINOR.PATCH libraries in each of the compat roots are
+// considered as candidates. A candidate is valid if its version is strictly
+// newer than the host driver version with the full MAJOR.MINOR.PATCH version
+// used for the comparison. Of the valid candidates the newest is selected, with
+// the order of the roots used as a tie-breaker.
+func Select(logger logger.Interface, root containerroot.Root, compatRoots []string, hostDriverVersion string) (*Decision, error) {
+	if len(compatRoots) == 0 {
+		compatRoots = DefaultRoots
+	}
+	d := &Decision{
+		HostDriverVersion: hostDriverVersion,
+		Roots:             compatRoots,
+	}
+
+	if hostDriverVersion == "" {
+		d.Reason = "host driver version not specified"
+		return d, nil
+	}
+	hostVersion, err := ParseVersion(hostDriverVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host driver version %q: %w", hostDriverVersion, err)
+	}
+
+	if !root.HasPath("/etc/ld.so.cache") {
+		d.Reason = "the container does not have an LDCache"
+		return d, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, compatRoot := range compatRoots {
+		if !root.HasPath(compatRoot) {
+			logger.Debugf("No CUDA forward compatibility libraries directory %v in container", compatRoot)
+			continue
+		}
+		libs, err := root.GlobFiles(filepath.Join(compatRoot, "libcuda.so.*.*"))
+		if err != nil {
+			logger.Warningf("Failed to find CUDA compat library in %v: %v", compatRoot, err)
+			continue
+		}
+		for _, lib := range libs {
+			if seen[lib] {
+				continue
+			}
+			seen[lib] = true
+			d.Candidates = append(d.Candidates, newCandidate(compatRoot, lib, hostVersion))
+		}
+	}
+
+	for i, c := range d.Candidates {
+		if !c.Valid {
+			continue
+		}
+		if d.Selected == nil || c.version.Compare(d.Selected.version) > 0 {
+			d.Selected = &d.Candidates[i]
+		}
+	}
+
+	switch {
+	case len(d.Candidates) == 0:
+		d.Reason = "no CUDA forward compatibility libraries in container"
+	case d.Selected == nil:
+		d.Reason = "no CUDA forward compatibility libraries are newer than the host driver"
+	default:
+		d.Reason = fmt.Sprintf("selected %v (%v) for host driver %v", d.Selected.Dir, d.Selected.Version, hostDriverVersion)
+	}
+	return d, nil
+}
+
+// newCandidate creates a candidate for the specified library and checks its
+// validity against the host driver version.
+func newCandidate(compatRoot string, lib string, hostVersion Version) Candidate {
+	c := Candidate{
+		Root:    compatRoot,
+		Dir:     filepath.Dir(lib),
+		Library: lib,
+		Version: strings.TrimPrefix(filepath.Base(lib), "libcuda.so."),
+	}
+
+	v, err := ParseVersion(c.Version)
+	if err != nil {
+		c.Reason = fmt.Sprintf("invalid version: %v", err)
+		return c
+	}
+	c.version = v
+
+	if v.Compare(hostVersion) <= 0 {
+		c.Reason = fmt.Sprintf("not newer than host driver version %v", hostVersion)
+		return c
+	}
+	c.Valid = true
+	c.Reason = fmt.Sprintf("newer than host driver version %v", hostVersion)
+	return c
+}
+
+// HostDriverVersion returns the version of the driver at the specified driver
+// root. The version is extracted from the name of the libcuda.so.*.* library.
+func HostDriverVersion(driver *root.Driver) (string, error) {
+	if driver == nil {
+		return "", fmt.Errorf("no driver root specified")
+	}
+	libcudaPaths, err := cuda.New(driver.Libraries()).Locate(".*.*")
+	if err != nil {
+		return "", fmt.Errorf("failed to locate libcuda.so: %w", err)
+	}
+	version := strings.TrimPrefix(filepath.Base(libcudaPaths[0]), "libcuda.so.")
+	if version == "" {
+		return "", fmt.Errorf("failed to extract version from %v", libcudaPaths[0])
+	}
+	return version, nil
+}
@@ -0,0 +1,104 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cudacompat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+)
+
+func TestSelect(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	containerRootDir := t.TempDir()
+	for _, name := range []string{
+		"/etc/ld.so.cache",
+		"/usr/local/cuda-12.4/compat/libcuda.so.550.54.15",
+		"/usr/local/cuda-12.6/compat/libcuda.so.560.35.03",
+		"/usr/local/cuda-12.8/compat/libcuda.so.570.86.10",
+		"/usr/local/cuda-broken/compat/libcuda.so.1.invalid",
+	} {
+		target := filepath.Join(containerRootDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, nil, 0600))
+	}
+	// A second root that resolves to an existing root is only considered once.
+	require.NoError(t, os.Symlink("cuda-12.6", filepath.Join(containerRootDir, "/usr/local/cuda")))
+
+	decision, err := Select(
+		logger,
+		containerroot.Root(containerRootDir),
+		[]string{
+			"/usr/local/cuda/compat",
+			"/usr/local/cuda-12.4/compat",
+			"/usr/local/cuda-12.6/compat",
+			"/usr/local/cuda-12.8/compat",
+			"/usr/local/cuda-broken/compat",
+			"/usr/local/cuda-missing/compat",
+		},
+		"560.35.03",
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "/usr/local/cuda-12.8/compat", decision.SelectedDir())
+	require.Equal(t, "570.86.10", decision.Selected.Version)
+
+	var candidates []string
+	valid := make(map[string]bool)
+	for _, c := range decision.Candidates {
+		candidates = append(candidates, c.Library)
+		valid[c.Version] = c.Valid
+	}
+	require.EqualValues(t,
+		[]string{
+			"/usr/local/cuda-12.6/compat/libcuda.so.560.35.03",
+			"/usr/local/cuda-12.4/compat/libcuda.so.550.54.15",
+			"/usr/local/cuda-12.8/compat/libcuda.so.570.86.10",
+			"/usr/local/cuda-broken/compat/libcuda.so.1.invalid",
+		},
+		candidates,
+	)
+	require.EqualValues(t,
+		map[string]bool{
+			"560.35.03": false,
+			"550.54.15": false,
+			"570.86.10": true,
+			"1.invalid": false,
+		},
+		valid,
+	)
+}
+
+func TestSelectNoHostDriverVersion(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	decision, err := Select(logger, containerroot.Root(t.TempDir()), nil, "")
+	require.NoError(t, err)
+	require.Empty(t, decision.SelectedDir())
+	require.EqualValues(t, DefaultRoots, decision.Roots)
+}
+
+func TestHostDriverVersionNoDriver(t *testing.T) {
+	_, err := HostDriverVersion(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,187 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cudacompat
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// defaultMatrix is the compatibility matrix that is embedded in the binary.
+// To update the matrix, edit matrix.json. A matrix can also be loaded from a
+// file at runtime using LoadMatrix.
+//
+//go:embed matrix.json
+var defaultMatrix []byte
+
+// A Matrix maps CUDA toolkit versions to the driver versions that are required
+// to use them.
+type Matrix struct {
+	CUDA []MatrixEntry `json:"cuda"`
+}
+
+// A MatrixEntry defines the driver requirements for a CUDA toolkit version.
+type MatrixEntry struct {
+	// CUDA is the MAJOR.MINOR version of the CUDA toolkit.
+	CUDA string `json:"cuda"`
+	// MinimumDriverVersion is the minimum host driver version that natively
+	// supports this version of the CUDA toolkit.
+	MinimumDriverVersion string `json:"minimumDriverVersion"`
+	// ForwardCompat defines the requirements for running this version of the
+	// CUDA toolkit on an older host driver using the CUDA Forward Compatibility
+	// libraries. If this is nil, forward compatibility is not supported.
+	ForwardCompat *ForwardCompatEntry `json:"forwardCompat,omitempty"`
+
+	cuda          Version
+	minimumDriver Version
+}
+
+// A ForwardCompatEntry defines the requirements for CUDA Forward Compatibility.
+type ForwardCompatEntry struct {
+	// MinimumDriverVersion is the oldest host driver version that the forward
+	// compatibility libraries can be used with.
+	MinimumDriverVersion string `json:"minimumDriverVersion"`
+
+	minimumDriver Version
+}
+
+// DefaultMatrix returns the compatibility matrix embedded in the binary.
+func DefaultMatrix() *Matrix {
+	m, err := parseMatrix(defaultMatrix)
+	if err != nil {
+		panic(fmt.Errorf("invalid embedded CUDA compatibility matrix: %w", err))
+	}
+	return m
+}
+
+// LoadMatrix loads a compatibility matrix from the specified JSON file.
+// If the filename is empty, the embedded matrix is returned.
+func LoadMatrix(filename string) (*Matrix, error) {
+	if filename == "" {
+		return DefaultMatrix(), nil
+	}
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CUDA compatibility matrix: %w", err)
+	}
+	return parseMatrix(contents)
+}
+
+func parseMatrix(contents []byte) (*Matrix, error) {
+	var m Matrix
+	if err := json.Unmarshal(contents, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse CUDA compatibility matrix: %w", err)
+	}
+	for i := range m.CUDA {
+		if err := m.CUDA[i].parse(); err != nil {
+			return nil, err
+		}
+	}
+	return &m, nil
+}
+
+func (e *MatrixEntry) parse() error {
+	var err error
+	e.cuda, err = ParseVersion(e.CUDA)
+	if err != nil {
+		return fmt.Errorf("invalid CUDA version: %w", err)
+	}
+	e.minimumDriver, err = ParseVersion(e.MinimumDriverVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minimum driver version for CUDA %v: %w", e.CUDA, err)
+	}
+	if e.ForwardCompat == nil {
+		return nil
+	}
+	e.ForwardCompat.minimumDriver, err = ParseVersion(e.ForwardCompat.MinimumDriverVersion)
+	if err != nil {
+		return fmt.Errorf("invalid forward compat driver version for CUDA %v: %w", e.CUDA, err)
+	}
+	return nil
+}
+
+// Lookup returns the entry for the specified CUDA version. Only the MAJOR and
+// MINOR components of the version are considered. If no entry exists, the
+// entry for the closest newer CUDA version is returned. If there is no newer
+// entry, nil is returned.
+func (m *Matrix) Lookup(cudaVersion string) *MatrixEntry {
+	v, err := ParseVersion(cudaVersion)
+	if err != nil {
+		return nil
+	}
+	v.Patch = 0
+
+	var closest *MatrixEntry
+	for i, e := range m.CUDA {
+		if e.cuda.Compare(v) < 0 {
+			continue
+		}
+		if closest == nil || e.cuda.Compare(closest.cuda) < 0 {
+			closest = &m.CUDA[i]
+		}
+	}
+	return closest
+}
+
+// CUDAVersionForDriver returns the newest CUDA version that is natively
+// supported by the specified driver version. An empty string is returned if no
+// CUDA version in the matrix is supported.
+func (m *Matrix) CUDAVersionForDriver(driverVersion string) string {
+	driver, err := ParseVersion(driverVersion)
+	if err != nil {
+		return ""
+	}
+
+	var newest *MatrixEntry
+	for i, e := range m.CUDA {
+		if e.minimumDriver.Compare(driver) > 0 {
+			continue
+		}
+		if newest == nil || e.cuda.Compare(newest.cuda) > 0 {
+			newest = &m.CUDA[i]
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+	return newest.CUDA
+}
+
+// SupportsDriver checks whether the specified host driver version natively
+// supports the CUDA version of the entry.
+func (e *MatrixEntry) SupportsDriver(driverVersion string) bool {
+	driver, err := ParseVersion(driverVersion)
+	if err != nil {
+		return false
+	}
+	return driver.Compare(e.minimumDriver) >= 0
+}
+
+// IsForwardCompatible checks whether the CUDA version of the entry can be used
+// on the specified host driver version with CUDA Forward Compatibility.
+func (e *MatrixEntry) IsForwardCompatible(driverVersion string) bool {
+	if e.ForwardCompat == nil {
+		return false
+	}
+	driver, err := ParseVersion(driverVersion)
+	if err != nil {
+		return false
+	}
+	return driver.Compare(e.ForwardCompat.minimumDriver) >= 0
+}
@@ -0,0 +1,106 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cudacompat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultMatrix(t *testing.T) {
+	m := DefaultMatrix()
+	require.NotEmpty(t, m.CUDA)
+}
+
+func TestMatrixLookup(t *testing.T) {
+	m := DefaultMatrix()
+
+	testCases := []struct {
+		cudaVersion         string
+		expectedCUDAVersion string
+	}{
+		{cudaVersion: "12.2", expectedCUDAVersion: "12.2"},
+		{cudaVersion: "12.2.1", expectedCUDAVersion: "12.2"},
+		{cudaVersion: "12.7", expectedCUDAVersion: "12.8"},
+		{cudaVersion: "99.0", expectedCUDAVersion: ""},
+		{cudaVersion: "invalid", expectedCUDAVersion: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.cudaVersion, func(t *testing.T) {
+			e := m.Lookup(tc.cudaVersion)
+			if tc.expectedCUDAVersion == "" {
+				require.Nil(t, e)
+				return
+			}
+			require.NotNil(t, e)
+			require.Equal(t, tc.expectedCUDAVersion, e.CUDA)
+		})
+	}
+}
+
+func TestMatrixCUDAVersionForDriver(t *testing.T) {
+	m := DefaultMatrix()
+
+	testCases := []struct {
+		driverVersion       string
+		expectedCUDAVersion string
+	}{
+		{driverVersion: "535.54.03", expectedCUDAVersion: "12.2"},
+		{driverVersion: "550.54.15", expectedCUDAVersion: "12.4"},
+		{driverVersion: "580.65.06", expectedCUDAVersion: "13.0"},
+		{driverVersion: "418.40.04", expectedCUDAVersion: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.driverVersion, func(t *testing.T) {
+			require.Equal(t, tc.expectedCUDAVersion, m.CUDAVersionForDriver(tc.driverVersion))
+		})
+	}
+}
+
+func TestMatrixEntry(t *testing.T) {
+	e := DefaultMatrix().Lookup("12.4")
+	require.NotNil(t, e)
+
+	require.True(t, e.SupportsDriver("550.54.14"))
+	require.False(t, e.SupportsDriver("535.54.03"))
+
+	require.True(t, e.IsForwardCompatible("535.54.03"))
+	require.False(t, e.IsForwardCompatible("460.32.03"))
+	require.False(t, e.IsForwardCompatible(""))
+}
+
+func TestLoadMatrix(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "matrix.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"cuda": [{"cuda": "99.1", "minimumDriverVersion": "999.1"}]}`), 0600))
+
+	m, err := LoadMatrix(filename)
+	require.NoError(t, err)
+
+	e := m.Lookup("99.1")
+	require.NotNil(t, e)
+	require.True(t, e.SupportsDriver("999.1.0"))
+	require.False(t, e.IsForwardCompatible("999.1.0"))
+
+	require.NoError(t, os.WriteFile(filename, []byte(`{"cuda": [{"cuda": "99", "minimumDriverVersion": "999.1"}]}`), 0600))
+	_, err = LoadMatrix(filename)
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cudacompat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Version represents a driver version of the form MAJOR.MINOR[.PATCH].
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a driver version string such as 550.54.15.
+// If the patch version is omitted (as is the case for some driver
+// branches), it is treated as 0.
+func ParseVersion(version string) (Version, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("expected MAJOR.MINOR[.PATCH]; got %q", version)
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version component %q in %q", part, version)
+		}
+		numbers[i] = n
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Compare compares two versions numerically. The result is 0 if v == o, -1 if
+// v < o, and +1 if v > o.
+func (v Version) Compare(o Version) int {
+	for _, c := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		switch {
+		case c[0] < c[1]:
+			return -1
+		case c[0] > c[1]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// String returns the version as MAJOR.MINOR.PATCH.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
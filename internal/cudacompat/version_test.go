@@ -0,0 +1,76 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cudacompat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := []struct {
+		version         string
+		expectedVersion Version
+		expectedError   bool
+	}{
+		{version: "550.54.15", expectedVersion: Version{550, 54, 15}},
+		{version: "570.86.10", expectedVersion: Version{570, 86, 10}},
+		{version: "560.35.03", expectedVersion: Version{560, 35, 3}},
+		{version: "418.87", expectedVersion: Version{418, 87, 0}},
+		{version: "550", expectedError: true},
+		{version: "550.54.15.1", expectedError: true},
+		{version: "550.x.15", expectedError: true},
+		{version: "", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			v, err := ParseVersion(tc.version)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, v)
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "550.54.15", b: "550.54.15", expected: 0},
+		{a: "550.54.15", b: "550.54.14", expected: 1},
+		{a: "550.54.15", b: "550.90.07", expected: -1},
+		{a: "99.55.66", b: "222.88.99", expected: -1},
+		{a: "560.35.03", b: "560.35.3", expected: 0},
+		{a: "418.87", b: "418.87.01", expected: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" vs "+tc.b, func(t *testing.T) {
+			a, err := ParseVersion(tc.a)
+			require.NoError(t, err)
+			b, err := ParseVersion(tc.b)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, a.Compare(b))
+		})
+	}
+}
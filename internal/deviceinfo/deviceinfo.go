@@ -0,0 +1,247 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package deviceinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// Info stores the properties of the driver and the GPUs on a system that are
+// relevant for checking the requirements of a container.
+//
+// Info can be queried using NVML or loaded from a cached device-info file.
+type Info struct {
+	// DriverVersion is the version of the NVIDIA driver (e.g. 550.54.15).
+	DriverVersion string `json:"driverVersion"`
+	// CUDAVersion is the CUDA version supported by the driver (e.g. 12.4).
+	CUDAVersion string   `json:"cudaVersion"`
+	Devices     []Device `json:"devices"`
+}
+
+// A Device stores the properties of a single GPU.
+type Device struct {
+	Index int    `json:"index"`
+	UUID  string `json:"uuid"`
+	// Brand is the lowercase brand name of the device (e.g. tesla, geforce).
+	Brand string `json:"brand"`
+	// ComputeCapability is the CUDA compute capability of the device (e.g. 8.0).
+	ComputeCapability string `json:"computeCapability"`
+	// Memory is the total memory of the device in bytes.
+	Memory     uint64 `json:"memory"`
+	MIGEnabled bool   `json:"migEnabled"`
+	NVLink     bool   `json:"nvlink"`
+}
+
+// Load loads the device info from the specified JSON file.
+func Load(filename string) (*Info, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device info: %w", err)
+	}
+	var info Info
+	if err := json.Unmarshal(contents, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse device info: %w", err)
+	}
+	return &info, nil
+}
+
+// Save writes the device info to the specified JSON file.
+func (i *Info) Save(filename string) error {
+	contents, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(contents, '\n'), 0644)
+}
+
+// New queries the device info using the specified NVML library.
+func New(nvmllib nvml.Interface) (*Info, error) {
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		_ = nvmllib.Shutdown()
+	}()
+
+	driverVersion, ret := nvmllib.SystemGetDriverVersion()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get driver version: %v", ret)
+	}
+	cudaVersion, ret := nvmllib.SystemGetCudaDriverVersion()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get CUDA version: %v", ret)
+	}
+
+	info := &Info{
+		DriverVersion: driverVersion,
+		CUDAVersion:   fmt.Sprintf("%d.%d", cudaVersion/1000, cudaVersion%1000/10),
+	}
+
+	count, ret := nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device count: %v", ret)
+	}
+	for index := 0; index < count; index++ {
+		d, ret := nvmllib.DeviceGetHandleByIndex(index)
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get device %d: %v", index, ret)
+		}
+		device, err := newDevice(index, d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get info for device %d: %w", index, err)
+		}
+		info.Devices = append(info.Devices, *device)
+	}
+	return info, nil
+}
+
+func newDevice(index int, d nvml.Device) (*Device, error) {
+	uuid, ret := d.GetUUID()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get UUID: %v", ret)
+	}
+	brand, ret := d.GetBrand()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get brand: %v", ret)
+	}
+	major, minor, ret := d.GetCudaComputeCapability()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get compute capability: %v", ret)
+	}
+	memory, ret := d.GetMemoryInfo()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get memory info: %v", ret)
+	}
+
+	device := &Device{
+		Index:             index,
+		UUID:              uuid,
+		Brand:             brandName(brand),
+		ComputeCapability: fmt.Sprintf("%d.%d", major, minor),
+		Memory:            memory.Total,
+	}
+
+	// MIG mode and NVLink are not supported on all devices.
+	if current, _, ret := d.GetMigMode(); ret == nvml.SUCCESS {
+		device.MIGEnabled = current == nvml.DEVICE_MIG_ENABLE
+	}
+	for link := 0; link < nvml.NVLINK_MAX_LINKS; link++ {
+		state, ret := d.GetNvLinkState(link)
+		if ret != nvml.SUCCESS {
+			break
+		}
+		if state == nvml.FEATURE_ENABLED {
+			device.NVLink = true
+			break
+		}
+	}
+	return device, nil
+}
+
+// Select returns the devices that match the specified device identifiers.
+// Identifiers may be device indices, UUIDs, or "all". MIG devices (e.g. 0:1)
+// select their parent device. Fully-qualified CDI device names of the gpu kind
+// (e.g. nvidia.com/gpu=0) are also supported, with the device name after the
+// '=' being considered. Identifiers that do not match a device, including CDI
+// device names of other kinds, are ignored.
+func (i *Info) Select(ids ...string) []Device {
+	selected := make(map[int]bool)
+	var devices []Device
+	add := func(d Device) {
+		if selected[d.Index] {
+			return
+		}
+		selected[d.Index] = true
+		devices = append(devices, d)
+	}
+
+	for _, id := range ids {
+		if kind, name, found := strings.Cut(id, "="); found {
+			if !isGPUKind(kind) {
+				continue
+			}
+			id = name
+		}
+		if id == "all" {
+			for _, d := range i.Devices {
+				add(d)
+			}
+			continue
+		}
+		if parent, _, isMIG := strings.Cut(id, ":"); isMIG {
+			id = parent
+		}
+		for _, d := range i.Devices {
+			if id == d.UUID || id == strconv.Itoa(d.Index) {
+				add(d)
+			}
+		}
+	}
+	return devices
+}
+
+// isGPUKind checks whether the specified CDI device kind refers to full GPUs
+// (e.g. nvidia.com/gpu or runtime.nvidia.com/gpu).
+func isGPUKind(kind string) bool {
+	_, class, found := strings.Cut(kind, "/")
+	return found && class == "gpu"
+}
+
+// brandName returns the lowercase brand name for the specified brand type.
+func brandName(brand nvml.BrandType) string {
+	switch brand {
+	case nvml.BRAND_QUADRO:
+		return "quadro"
+	case nvml.BRAND_TESLA:
+		return "tesla"
+	case nvml.BRAND_NVS:
+		return "nvs"
+	case nvml.BRAND_GRID:
+		return "grid"
+	case nvml.BRAND_GEFORCE:
+		return "geforce"
+	case nvml.BRAND_TITAN:
+		return "titan"
+	case nvml.BRAND_NVIDIA_VAPPS:
+		return "nvidiavapps"
+	case nvml.BRAND_NVIDIA_VPC:
+		return "nvidiavpc"
+	case nvml.BRAND_NVIDIA_VCS:
+		return "nvidiavcs"
+	case nvml.BRAND_NVIDIA_VWS:
+		return "nvidiavws"
+	case nvml.BRAND_NVIDIA_CLOUD_GAMING:
+		return "nvidiacloudgaming"
+	case nvml.BRAND_QUADRO_RTX:
+		return "quadrortx"
+	case nvml.BRAND_NVIDIA_RTX:
+		return "nvidiartx"
+	case nvml.BRAND_NVIDIA:
+		return "nvidia"
+	case nvml.BRAND_GEFORCE_RTX:
+		return "geforcertx"
+	case nvml.BRAND_TITAN_RTX:
+		return "titanrtx"
+	}
+	return "unknown"
+}
@@ -0,0 +1,140 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package deviceinfo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	server := dgxa100.New()
+	for i, d := range server.Devices {
+		d := d.(*dgxa100.Device)
+		hasNVLink := i == 0
+		d.GetNvLinkStateFunc = func(link int) (nvml.EnableState, nvml.Return) {
+			if !hasNVLink {
+				return nvml.FEATURE_DISABLED, nvml.ERROR_NOT_SUPPORTED
+			}
+			return nvml.FEATURE_ENABLED, nvml.SUCCESS
+		}
+	}
+	server.Devices[1].(*dgxa100.Device).MigMode = nvml.DEVICE_MIG_ENABLE
+
+	info, err := New(server)
+	require.NoError(t, err)
+
+	require.Equal(t, "550.54.15", info.DriverVersion)
+	require.Equal(t, "12.4", info.CUDAVersion)
+	require.Len(t, info.Devices, 8)
+
+	require.Equal(t, Device{
+		Index:             0,
+		UUID:              server.Devices[0].(*dgxa100.Device).UUID,
+		Brand:             "nvidia",
+		ComputeCapability: "8.0",
+		Memory:            42949672960,
+		NVLink:            true,
+	}, info.Devices[0])
+	require.True(t, info.Devices[1].MIGEnabled)
+	require.False(t, info.Devices[1].NVLink)
+}
+
+func TestSelect(t *testing.T) {
+	info := &Info{
+		Devices: []Device{
+			{Index: 0, UUID: "GPU-0"},
+			{Index: 1, UUID: "GPU-1"},
+			{Index: 2, UUID: "GPU-2"},
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		ids             []string
+		expectedIndices []int
+	}{
+		{
+			description: "no ids selects no devices",
+		},
+		{
+			description:     "all selects all devices",
+			ids:             []string{"all"},
+			expectedIndices: []int{0, 1, 2},
+		},
+		{
+			description:     "indices and UUIDs are selected",
+			ids:             []string{"2", "GPU-0"},
+			expectedIndices: []int{2, 0},
+		},
+		{
+			description:     "duplicates are removed",
+			ids:             []string{"1", "GPU-1"},
+			expectedIndices: []int{1},
+		},
+		{
+			description:     "MIG devices select parent",
+			ids:             []string{"1:0", "1:1"},
+			expectedIndices: []int{1},
+		},
+		{
+			description:     "CDI device names are supported",
+			ids:             []string{"nvidia.com/gpu=GPU-2", "nvidia.com/gpu=0"},
+			expectedIndices: []int{2, 0},
+		},
+		{
+			description:     "CDI device names of other kinds are ignored",
+			ids:             []string{"nvidia.com/imex-channel=0", "nvidia.com/mofed=all", "runtime.nvidia.com/gpu=1"},
+			expectedIndices: []int{1},
+		},
+		{
+			description: "unknown devices are ignored",
+			ids:         []string{"4", "nvidia.com/gpu=GPU-4"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var indices []int
+			for _, d := range info.Select(tc.ids...) {
+				indices = append(indices, d.Index)
+			}
+			require.EqualValues(t, tc.expectedIndices, indices)
+		})
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	info := &Info{
+		DriverVersion: "550.54.15",
+		CUDAVersion:   "12.4",
+		Devices: []Device{
+			{Index: 0, UUID: "GPU-0", Brand: "tesla", ComputeCapability: "8.0", Memory: 1 << 30, MIGEnabled: true},
+		},
+	}
+
+	filename := filepath.Join(t.TempDir(), "device-info.json")
+	require.NoError(t, info.Save(filename))
+
+	loaded, err := Load(filename)
+	require.NoError(t, err)
+	require.Equal(t, info, loaded)
+}
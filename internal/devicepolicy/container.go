@@ -0,0 +1,57 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package devicepolicy
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// imageAnnotations are the annotations that are set by CRI runtimes to record
+// the image of a container.
+var imageAnnotations = []string{
+	// containerd
+	"io.kubernetes.cri.image-name",
+	// cri-o
+	"io.kubernetes.cri-o.ImageName",
+}
+
+// A Container holds the properties of a container that policy rules can
+// match.
+type Container struct {
+	Image       string
+	Annotations map[string]string
+}
+
+// NewContainerFromSpec creates a container from the specified OCI spec.
+func NewContainerFromSpec(spec *specs.Spec) Container {
+	return NewContainer(spec.Annotations)
+}
+
+// NewContainer creates a container with the specified annotations. The image
+// is determined from the annotations set by CRI runtimes.
+func NewContainer(annotations map[string]string) Container {
+	c := Container{
+		Annotations: annotations,
+	}
+	for _, key := range imageAnnotations {
+		if image := annotations[key]; image != "" {
+			c.Image = image
+			break
+		}
+	}
+	return c
+}
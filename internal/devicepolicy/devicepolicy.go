@@ -0,0 +1,178 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package devicepolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/parser"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// A Source describes how a device was requested by a container.
+type Source string
+
+// The following sources of device requests are supported.
+const (
+	SourceAnnotation = Source("annotation")
+	SourceEnvvar     = Source("envvar")
+	SourceMount      = Source("mount")
+)
+
+type action string
+
+const (
+	actionAllow = action("allow")
+	actionDeny  = action("deny")
+)
+
+type onDeny string
+
+const (
+	onDenyReject = onDeny("reject")
+	onDenyFilter = onDeny("filter")
+)
+
+// A Decision records whether a device request is allowed and why.
+type Decision struct {
+	Device  string
+	Source  Source
+	Allowed bool
+	Reason  string
+}
+
+// Policy restricts the CDI devices that a container may request.
+type Policy struct {
+	logger        logger.Interface
+	defaultAction action
+	onDeny        onDeny
+	rules         []rule
+}
+
+// New creates a policy from the specified config. If the config defines no
+// rules and does not deny requests by default, a nil policy, which allows all
+// requests, is returned.
+func New(logger logger.Interface, cfg config.DevicePolicyConfig) (*Policy, error) {
+	defaultAction, err := parseAction(cfg.DefaultAction, actionAllow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default-action: %w", err)
+	}
+	if len(cfg.Rules) == 0 && defaultAction == actionAllow {
+		return nil, nil
+	}
+
+	p := &Policy{
+		logger:        logger,
+		defaultAction: defaultAction,
+	}
+	switch onDeny(cfg.OnDeny) {
+	case "", onDenyReject:
+		p.onDeny = onDenyReject
+	case onDenyFilter:
+		p.onDeny = onDenyFilter
+	default:
+		return nil, fmt.Errorf("invalid on-deny value %q", cfg.OnDeny)
+	}
+
+	for i, ruleConfig := range cfg.Rules {
+		r, err := newRule(i, ruleConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+		p.rules = append(p.rules, *r)
+	}
+	return p, nil
+}
+
+// Evaluate determines whether the container may request the specified
+// device from the specified source.
+func (p *Policy) Evaluate(c Container, source Source, device string) Decision {
+	d := Decision{
+		Device: device,
+		Source: source,
+	}
+	if p == nil {
+		d.Allowed = true
+		d.Reason = "no policy configured"
+		return d
+	}
+
+	vendor, class, name, err := parser.ParseQualifiedName(device)
+	if err != nil {
+		d.Reason = fmt.Sprintf("invalid device name: %v", err)
+		return d
+	}
+	r := request{
+		container: c,
+		source:    source,
+		kind:      vendor + "/" + class,
+		class:     class,
+		name:      name,
+	}
+	for _, rule := range p.rules {
+		if !rule.matches(r) {
+			continue
+		}
+		d.Allowed = rule.action == actionAllow
+		d.Reason = fmt.Sprintf("matched %v", rule)
+		return d
+	}
+	d.Allowed = p.defaultAction == actionAllow
+	d.Reason = fmt.Sprintf("no rule matched; default action is %v", p.defaultAction)
+	return d
+}
+
+// Apply evaluates the policy for each of the specified devices and returns
+// the devices that are allowed. Each decision is logged. If any device is
+// denied and the policy rejects denied requests, an error is returned.
+func (p *Policy) Apply(c Container, source Source, devices []string) ([]string, error) {
+	if p == nil {
+		return devices, nil
+	}
+
+	var allowed []string
+	var denied []string
+	for _, device := range devices {
+		d := p.Evaluate(c, source, device)
+		if d.Allowed {
+			p.logger.Infof("Allowing device %q requested via %v: %v", d.Device, d.Source, d.Reason)
+			allowed = append(allowed, device)
+			continue
+		}
+		p.logger.Warningf("Denying device %q requested via %v: %v", d.Device, d.Source, d.Reason)
+		denied = append(denied, device)
+	}
+
+	if len(denied) > 0 && p.onDeny == onDenyReject {
+		return nil, fmt.Errorf("device request denied by policy: %v", strings.Join(denied, ","))
+	}
+	return allowed, nil
+}
+
+func parseAction(value string, defaultAction action) (action, error) {
+	switch a := action(value); a {
+	case "":
+		return defaultAction, nil
+	case actionAllow, actionDeny:
+		return a, nil
+	default:
+		return "", fmt.Errorf("unsupported action %q", value)
+	}
+}
@@ -0,0 +1,228 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package devicepolicy
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+func TestPolicyApply(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	tenantContainer := Container{
+		Image: "registry.example.com/tenant/app:v1",
+		Annotations: map[string]string{
+			"example.com/tenant": "a",
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		policy          config.DevicePolicyConfig
+		container       Container
+		source          Source
+		devices         []string
+		expectedDevices []string
+		expectedError   string
+	}{
+		{
+			description:     "empty policy allows all devices",
+			source:          SourceAnnotation,
+			devices:         []string{"nvidia.com/gpu=0", "management.nvidia.com/gpu=all"},
+			expectedDevices: []string{"nvidia.com/gpu=0", "management.nvidia.com/gpu=all"},
+		},
+		{
+			description: "denied kind is rejected",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Kinds: []string{"management.nvidia.com/*"}},
+				},
+			},
+			source:        SourceAnnotation,
+			devices:       []string{"nvidia.com/gpu=0", "management.nvidia.com/gpu=all"},
+			expectedError: "device request denied by policy: management.nvidia.com/gpu=all",
+		},
+		{
+			description: "denied class is filtered",
+			policy: config.DevicePolicyConfig{
+				OnDeny: "filter",
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Classes: []string{"imex-channel"}},
+				},
+			},
+			source:          SourceEnvvar,
+			devices:         []string{"nvidia.com/gpu=0", "nvidia.com/imex-channel=0"},
+			expectedDevices: []string{"nvidia.com/gpu=0"},
+		},
+		{
+			description: "first matching rule applies",
+			policy: config.DevicePolicyConfig{
+				DefaultAction: "deny",
+				OnDeny:        "filter",
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Kinds: []string{"nvidia.com/gpu"}, Names: []string{"all"}},
+					{Action: "allow", Kinds: []string{"nvidia.com/gpu"}},
+				},
+			},
+			source:          SourceAnnotation,
+			devices:         []string{"nvidia.com/gpu=all", "nvidia.com/gpu=1", "nvidia.com/imex-channel=0"},
+			expectedDevices: []string{"nvidia.com/gpu=1"},
+		},
+		{
+			description: "source must match",
+			policy: config.DevicePolicyConfig{
+				DefaultAction: "deny",
+				Rules: []config.DevicePolicyRule{
+					{Action: "allow", Sources: []string{"annotation"}},
+				},
+			},
+			source:        SourceMount,
+			devices:       []string{"nvidia.com/gpu=0"},
+			expectedError: "device request denied by policy: nvidia.com/gpu=0",
+		},
+		{
+			description: "image and annotations must match",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{
+					{
+						Action:      "deny",
+						Images:      []string{"registry.example.com/tenant/*"},
+						Annotations: map[string]string{"example.com/tenant": "?"},
+					},
+				},
+			},
+			container:     tenantContainer,
+			source:        SourceAnnotation,
+			devices:       []string{"nvidia.com/gpu=0"},
+			expectedError: "device request denied by policy: nvidia.com/gpu=0",
+		},
+		{
+			description: "missing annotation does not match",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Annotations: map[string]string{"example.com/tenant": "*"}},
+				},
+			},
+			container: Container{
+				Image: "registry.example.com/tenant/app:v1",
+			},
+			source:          SourceAnnotation,
+			devices:         []string{"nvidia.com/gpu=0"},
+			expectedDevices: []string{"nvidia.com/gpu=0"},
+		},
+		{
+			description: "unknown image does not match",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Images: []string{"*"}},
+				},
+			},
+			source:          SourceAnnotation,
+			devices:         []string{"nvidia.com/gpu=0"},
+			expectedDevices: []string{"nvidia.com/gpu=0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			p, err := New(logger, tc.policy)
+			require.NoError(t, err)
+
+			devices, err := p.Apply(tc.container, tc.source, tc.devices)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, devices)
+		})
+	}
+}
+
+func TestNewInvalidPolicy(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		policy        config.DevicePolicyConfig
+		expectedError string
+	}{
+		{
+			description:   "invalid default action",
+			policy:        config.DevicePolicyConfig{DefaultAction: "block"},
+			expectedError: `invalid default-action: unsupported action "block"`,
+		},
+		{
+			description: "invalid on-deny",
+			policy: config.DevicePolicyConfig{
+				DefaultAction: "deny",
+				OnDeny:        "drop",
+			},
+			expectedError: `invalid on-deny value "drop"`,
+		},
+		{
+			description: "missing rule action",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{{Kinds: []string{"nvidia.com/gpu"}}},
+			},
+			expectedError: "invalid rule 0: an action is required",
+		},
+		{
+			description: "invalid rule source",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{{Action: "deny", Sources: []string{"hook"}}},
+			},
+			expectedError: `invalid rule 0: unsupported source "hook"`,
+		},
+		{
+			description: "allow rule with images",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{{Action: "allow", Images: []string{"nvcr.io/nvidia/*"}}},
+			},
+			expectedError: "invalid rule 0: images and annotations are only supported for deny rules",
+		},
+		{
+			description: "allow rule with annotations",
+			policy: config.DevicePolicyConfig{
+				Rules: []config.DevicePolicyRule{{Action: "allow", Annotations: map[string]string{"example.com/tenant": "a"}}},
+			},
+			expectedError: "invalid rule 0: images and annotations are only supported for deny rules",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := New(logger, tc.policy)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestNewContainerFromSpec(t *testing.T) {
+	c := NewContainerFromSpec(&specs.Spec{
+		Annotations: map[string]string{
+			"io.kubernetes.cri-o.ImageName": "nvcr.io/nvidia/cuda:12.8.0-base-ubuntu24.04",
+		},
+	})
+	require.Equal(t, "nvcr.io/nvidia/cuda:12.8.0-base-ubuntu24.04", c.Image)
+}
@@ -0,0 +1,167 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package devicepolicy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+// A request holds the properties of a single device request that rules are
+// matched against.
+type request struct {
+	container Container
+	source    Source
+	kind      string
+	class     string
+	name      string
+}
+
+type rule struct {
+	index       int
+	description string
+	action      action
+	kinds       patterns
+	classes     patterns
+	names       patterns
+	sources     map[Source]bool
+	images      patterns
+	annotations map[string]*pattern
+}
+
+func newRule(index int, cfg config.DevicePolicyRule) (*rule, error) {
+	a, err := parseAction(cfg.Action, "")
+	if err != nil {
+		return nil, err
+	}
+	if a == "" {
+		return nil, fmt.Errorf("an action is required")
+	}
+	// The image and annotations of a container are controlled by its owner
+	// and can therefore not be used to grant access to devices.
+	if a == actionAllow && (len(cfg.Images) > 0 || len(cfg.Annotations) > 0) {
+		return nil, fmt.Errorf("images and annotations are only supported for deny rules")
+	}
+
+	r := &rule{
+		index:       index,
+		description: cfg.Description,
+		action:      a,
+		kinds:       newPatterns(cfg.Kinds),
+		classes:     newPatterns(cfg.Classes),
+		names:       newPatterns(cfg.Names),
+		images:      newPatterns(cfg.Images),
+	}
+	for _, source := range cfg.Sources {
+		switch s := Source(source); s {
+		case SourceAnnotation, SourceEnvvar, SourceMount:
+			if r.sources == nil {
+				r.sources = make(map[Source]bool)
+			}
+			r.sources[s] = true
+		default:
+			return nil, fmt.Errorf("unsupported source %q", source)
+		}
+	}
+	for key, value := range cfg.Annotations {
+		if r.annotations == nil {
+			r.annotations = make(map[string]*pattern)
+		}
+		r.annotations[key] = newPattern(value)
+	}
+	return r, nil
+}
+
+// matches checks whether all the criteria of the rule match the request.
+func (r rule) matches(req request) bool {
+	if !r.kinds.matches(req.kind) {
+		return false
+	}
+	if !r.classes.matches(req.class) {
+		return false
+	}
+	if !r.names.matches(req.name) {
+		return false
+	}
+	if r.sources != nil && !r.sources[req.source] {
+		return false
+	}
+	if r.images != nil {
+		if req.container.Image == "" || !r.images.matches(req.container.Image) {
+			return false
+		}
+	}
+	for key, p := range r.annotations {
+		value, ok := req.container.Annotations[key]
+		if !ok || !p.matches(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r rule) String() string {
+	s := fmt.Sprintf("rule %d (%v)", r.index, r.action)
+	if r.description != "" {
+		s += ": " + r.description
+	}
+	return s
+}
+
+// patterns matches a value if any of its patterns match. A nil set of
+// patterns matches any value.
+type patterns []*pattern
+
+func newPatterns(values []string) patterns {
+	var ps patterns
+	for _, value := range values {
+		ps = append(ps, newPattern(value))
+	}
+	return ps
+}
+
+func (ps patterns) matches(value string) bool {
+	if ps == nil {
+		return true
+	}
+	for _, p := range ps {
+		if p.matches(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// A pattern matches a complete value. The * wildcard matches any sequence of
+// characters (including /) and the ? wildcard matches a single character.
+type pattern struct {
+	*regexp.Regexp
+}
+
+func newPattern(value string) *pattern {
+	expr := regexp.QuoteMeta(value)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return &pattern{regexp.MustCompile("^" + expr + "$")}
+}
+
+func (p *pattern) matches(value string) bool {
+	return p.MatchString(value)
+}
@@ -21,6 +21,19 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
 )
 
+const (
+	// CUFileConfigPath is the path at which the cuFile configuration is
+	// injected into the container. This is the default path used by libcufile.
+	CUFileConfigPath = "/etc/cufile.json"
+)
+
+// cufileConfigSearchPaths defines the locations at which the cuFile
+// configuration is searched for on the host. The first file found is used.
+var cufileConfigSearchPaths = []string{
+	CUFileConfigPath,
+	"/usr/local/cuda/gds/cufile.json",
+}
+
 type gdsDeviceDiscoverer struct {
 	None
 	logger  logger.Interface
@@ -43,15 +56,14 @@ func NewGDSDiscoverer(logger logger.Interface, driverRoot string, devRoot string
 		[]string{"/run/udev"},
 	)
 
-	cufile := NewMounts(
-		logger,
-		lookup.NewFileLocator(
+	cufile := &cufileConfig{
+		logger: logger,
+		locator: lookup.NewFileLocator(
 			lookup.WithLogger(logger),
 			lookup.WithRoot(driverRoot),
+			lookup.WithCount(1),
 		),
-		driverRoot,
-		[]string{"/etc/cufile.json"},
-	)
+	}
 
 	d := gdsDeviceDiscoverer{
 		logger:  logger,
@@ -78,3 +90,37 @@ func (d *gdsDeviceDiscoverer) Mounts() ([]Mount, error) {
 
 	return d.mounts.Mounts()
 }
+
+// cufileConfig discovers the cuFile configuration (cufile.json) required by
+// GPUDirect Storage. The first configuration file located is mounted at the
+// default path in the container regardless of its location on the host.
+type cufileConfig struct {
+	None
+	logger  logger.Interface
+	locator lookup.Locator
+}
+
+// Mounts returns the mount for the cuFile configuration, if found.
+func (d *cufileConfig) Mounts() ([]Mount, error) {
+	for _, candidate := range cufileConfigSearchPaths {
+		located, err := d.locator.Locate(candidate)
+		if err != nil || len(located) == 0 {
+			d.logger.Debugf("Could not locate %v: %v", candidate, err)
+			continue
+		}
+		d.logger.Infof("Selecting %v as %v", located[0], CUFileConfigPath)
+		m := Mount{
+			HostPath: located[0],
+			Path:     CUFileConfigPath,
+			Options: []string{
+				"ro",
+				"nosuid",
+				"nodev",
+				"bind",
+			},
+		}
+		return []Mount{m}, nil
+	}
+	d.logger.Warningf("No cuFile configuration found")
+	return nil, nil
+}
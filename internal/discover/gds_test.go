@@ -0,0 +1,108 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGDSDiscoverer(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		files           []string
+		expectedDevices []Device
+		expectedMounts  []Mount
+	}{
+		{
+			description: "no devices returns no mounts",
+			files:       []string{"etc/cufile.json"},
+		},
+		{
+			description: "config in /etc is used",
+			files: []string{
+				"dev/nvidia-fs0",
+				"etc/cufile.json",
+				"usr/local/cuda/gds/cufile.json",
+			},
+			expectedDevices: []Device{
+				{HostPath: "{{ .root }}/dev/nvidia-fs0", Path: "/dev/nvidia-fs0"},
+			},
+			expectedMounts: []Mount{
+				{
+					HostPath: "{{ .root }}/etc/cufile.json",
+					Path:     "/etc/cufile.json",
+					Options:  []string{"ro", "nosuid", "nodev", "bind"},
+				},
+			},
+		},
+		{
+			description: "config from CUDA installation is injected at /etc/cufile.json",
+			files: []string{
+				"dev/nvidia-fs0",
+				"dev/nvidia-fs1",
+				"usr/local/cuda/gds/cufile.json",
+			},
+			expectedDevices: []Device{
+				{HostPath: "{{ .root }}/dev/nvidia-fs0", Path: "/dev/nvidia-fs0"},
+				{HostPath: "{{ .root }}/dev/nvidia-fs1", Path: "/dev/nvidia-fs1"},
+			},
+			expectedMounts: []Mount{
+				{
+					HostPath: "{{ .root }}/usr/local/cuda/gds/cufile.json",
+					Path:     "/etc/cufile.json",
+					Options:  []string{"ro", "nosuid", "nodev", "bind"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			for _, f := range tc.files {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(f)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, f), nil, 0644))
+			}
+			for i := range tc.expectedDevices {
+				tc.expectedDevices[i].HostPath = strings.ReplaceAll(tc.expectedDevices[i].HostPath, "{{ .root }}", root)
+			}
+			for i := range tc.expectedMounts {
+				tc.expectedMounts[i].HostPath = strings.ReplaceAll(tc.expectedMounts[i].HostPath, "{{ .root }}", root)
+			}
+
+			d, err := NewGDSDiscoverer(logger, root, root)
+			require.NoError(t, err)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.expectedDevices, devices)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, mounts)
+		})
+	}
+}
@@ -62,15 +62,33 @@ func CreateNvidiaCDIHook(nvidiaCDIHookPath string, hookName string, additionalAr
 	return cdiHook(nvidiaCDIHookPath).Create(hookName, additionalArgs...)
 }
 
+// CreateNvidiaCDIPoststopHook creates a hook which invokes the NVIDIA Container
+// CLI hook subcommand once the container has stopped. Poststop hooks run in the
+// runtime namespace and are used to undo modifications made on the host.
+func CreateNvidiaCDIPoststopHook(nvidiaCDIHookPath string, hookName string, additionalArgs ...string) Hook {
+	return cdiHook(nvidiaCDIHookPath).createWithLifecycle(cdi.PoststopHook, hookName, additionalArgs...)
+}
+
+// CreateCleanupHook creates a poststop hook which removes the per-container
+// artifacts tracked for a container once it has stopped.
+func CreateCleanupHook(nvidiaCDIHookPath string) Hook {
+	return CreateNvidiaCDIPoststopHook(nvidiaCDIHookPath, "cleanup")
+}
+
 type cdiHook string
 
 func (c cdiHook) Create(name string, args ...string) Hook {
+	return c.createWithLifecycle(cdi.CreateContainerHook, name, args...)
+}
+
+func (c cdiHook) createWithLifecycle(lifecycle string, name string, args ...string) Hook {
 	return Hook{
-		Lifecycle: cdi.CreateContainerHook,
+		Lifecycle: lifecycle,
 		Path:      string(c),
 		Args:      append(c.requiredArgs(name), args...),
 	}
 }
+
 func (c cdiHook) requiredArgs(name string) []string {
 	base := filepath.Base(string(c))
 	if base == "nvidia-ctk" {
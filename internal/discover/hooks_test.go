@@ -0,0 +1,65 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNvidiaCDIHook(t *testing.T) {
+	testCases := []struct {
+		description  string
+		hook         Hook
+		expectedHook Hook
+	}{
+		{
+			description: "createContainer hook",
+			hook:        CreateNvidiaCDIHook("/usr/bin/nvidia-cdi-hook", "chmod", "--mode", "755"),
+			expectedHook: Hook{
+				Lifecycle: "createContainer",
+				Path:      "/usr/bin/nvidia-cdi-hook",
+				Args:      []string{"nvidia-cdi-hook", "chmod", "--mode", "755"},
+			},
+		},
+		{
+			description: "poststop hook",
+			hook:        CreateNvidiaCDIPoststopHook("/usr/bin/nvidia-ctk", "cleanup"),
+			expectedHook: Hook{
+				Lifecycle: "poststop",
+				Path:      "/usr/bin/nvidia-ctk",
+				Args:      []string{"nvidia-ctk", "hook", "cleanup"},
+			},
+		},
+		{
+			description: "cleanup hook",
+			hook:        CreateCleanupHook("/usr/bin/nvidia-cdi-hook"),
+			expectedHook: Hook{
+				Lifecycle: "poststop",
+				Path:      "/usr/bin/nvidia-cdi-hook",
+				Args:      []string{"nvidia-cdi-hook", "cleanup"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expectedHook, tc.hook)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+)
+
+// NewVGPULicensingDiscoverer creates a discoverer for the files used for vGPU
+// licensing in a vGPU guest. This includes the configuration of the
+// nvidia-gridd licensing daemon, the client configuration tokens, and the
+// sockets used to communicate with the daemon.
+func NewVGPULicensingDiscoverer(logger logger.Interface, driverRoot string) Discover {
+	config := NewMounts(
+		logger,
+		lookup.NewFileLocator(
+			lookup.WithLogger(logger),
+			lookup.WithRoot(driverRoot),
+			lookup.WithCount(1),
+		),
+		driverRoot,
+		[]string{
+			"/etc/nvidia/gridd.conf",
+		},
+	)
+
+	tokens := NewMounts(
+		logger,
+		lookup.NewDirectoryLocator(
+			lookup.WithLogger(logger),
+			lookup.WithRoot(driverRoot),
+			lookup.WithCount(1),
+		),
+		driverRoot,
+		[]string{
+			"/etc/nvidia/ClientConfigToken",
+		},
+	)
+
+	sockets := newMounts(
+		logger,
+		lookup.NewDirectoryLocator(
+			lookup.WithLogger(logger),
+			lookup.WithRoot(driverRoot),
+			lookup.WithSearchPaths("/run", "/var/run"),
+			lookup.WithCount(1),
+		),
+		driverRoot,
+		[]string{
+			"/nvidia-gridd",
+		},
+	)
+
+	return Merge(
+		config,
+		tokens,
+		(*ipcMounts)(sockets),
+	)
+}
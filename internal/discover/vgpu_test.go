@@ -0,0 +1,74 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVGPULicensingDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "etc/nvidia/ClientConfigToken"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "var/run/nvidia-gridd"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "etc/nvidia/gridd.conf"), nil, 0644))
+
+	d := NewVGPULicensingDiscoverer(logger, driverRoot)
+
+	mounts, err := d.Mounts()
+	require.NoError(t, err)
+	require.EqualValues(t,
+		[]Mount{
+			{
+				HostPath: filepath.Join(driverRoot, "etc/nvidia/gridd.conf"),
+				Path:     "/etc/nvidia/gridd.conf",
+				Options:  []string{"ro", "nosuid", "nodev", "bind"},
+			},
+			{
+				HostPath: filepath.Join(driverRoot, "etc/nvidia/ClientConfigToken"),
+				Path:     "/etc/nvidia/ClientConfigToken",
+				Options:  []string{"ro", "nosuid", "nodev", "bind"},
+			},
+			{
+				HostPath: filepath.Join(driverRoot, "var/run/nvidia-gridd"),
+				Path:     "/var/run/nvidia-gridd",
+				Options:  []string{"ro", "nosuid", "nodev", "bind", "noexec"},
+			},
+		},
+		mounts,
+	)
+
+	devices, err := d.Devices()
+	require.NoError(t, err)
+	require.Empty(t, devices)
+}
+
+func TestVGPULicensingDiscovererMissingFiles(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	d := NewVGPULicensingDiscoverer(logger, t.TempDir())
+
+	mounts, err := d.Mounts()
+	require.NoError(t, err)
+	require.Empty(t, mounts)
+}
@@ -0,0 +1,203 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package infiniband
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultSysRoot is the default path at which sysfs is mounted.
+	DefaultSysRoot = "/sys"
+
+	// DeviceNodeRoot is the path at which the RDMA device nodes are created.
+	DeviceNodeRoot = "/dev/infiniband"
+)
+
+// Device represents an RDMA device (HCA) as listed under /sys/class/infiniband.
+type Device struct {
+	// Name is the RDMA device name (e.g. mlx5_0).
+	Name string
+	// PCIBusID is the PCI bus ID of the device (e.g. 0000:3b:00.0). This is
+	// empty if the device is not a PCI device.
+	PCIBusID string
+	// VerbsDeviceNodes are the names of the uverbs device nodes associated
+	// with the device (e.g. uverbs0).
+	VerbsDeviceNodes []string
+	// Ports are the ports of the device.
+	Ports []Port
+}
+
+// Port represents a single port of an RDMA device.
+type Port struct {
+	// Number is the (1-based) port number.
+	Number int
+	// MADDeviceNodes are the names of the umad device nodes associated with
+	// the port (e.g. umad0).
+	MADDeviceNodes []string
+}
+
+// GetDevices returns the RDMA devices available on the system. The sysRoot
+// specifies the path at which sysfs is mounted. If no RDMA devices are
+// present, an empty list is returned.
+func GetDevices(sysRoot string) ([]Device, error) {
+	if sysRoot == "" {
+		sysRoot = DefaultSysRoot
+	}
+
+	classRoot := filepath.Join(sysRoot, "class", "infiniband")
+	entries, err := os.ReadDir(classRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v: %w", classRoot, err)
+	}
+
+	verbs, err := getAssociatedNodes(filepath.Join(sysRoot, "class", "infiniband_verbs"), "uverbs")
+	if err != nil {
+		return nil, err
+	}
+	mads, err := getAssociatedNodes(filepath.Join(sysRoot, "class", "infiniband_mad"), "umad")
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []Device
+	for _, entry := range entries {
+		name := entry.Name()
+		deviceRoot := filepath.Join(classRoot, name)
+
+		ports, err := getPorts(deviceRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ports for %v: %w", name, err)
+		}
+		for i, port := range ports {
+			ports[i].MADDeviceNodes = mads[associatedNodeKey{name, port.Number}]
+		}
+
+		device := Device{
+			Name:             name,
+			PCIBusID:         getPCIBusID(deviceRoot),
+			VerbsDeviceNodes: verbs[associatedNodeKey{ibdev: name}],
+			Ports:            ports,
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+// DeviceNodePath returns the path of the specified device node.
+func DeviceNodePath(node string) string {
+	return filepath.Join(DeviceNodeRoot, node)
+}
+
+// getPCIBusID returns the PCI bus ID of the device by resolving its device
+// link. An empty string is returned if the link cannot be resolved.
+func getPCIBusID(deviceRoot string) string {
+	resolved, err := filepath.EvalSymlinks(filepath.Join(deviceRoot, "device"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(resolved)
+}
+
+// getPorts returns the ports of the device ordered by port number.
+func getPorts(deviceRoot string) ([]Port, error) {
+	entries, err := os.ReadDir(filepath.Join(deviceRoot, "ports"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []Port
+	for _, entry := range entries {
+		number, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		ports = append(ports, Port{Number: number})
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Number < ports[j].Number
+	})
+	return ports, nil
+}
+
+type associatedNodeKey struct {
+	ibdev string
+	port  int
+}
+
+// getAssociatedNodes reads the device nodes with the specified prefix from
+// the specified sysfs class and returns them keyed by the RDMA device (and
+// port, if present) that they are associated with.
+func getAssociatedNodes(classRoot string, prefix string) (map[associatedNodeKey][]string, error) {
+	entries, err := os.ReadDir(classRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v: %w", classRoot, err)
+	}
+
+	nodes := make(map[associatedNodeKey][]string)
+	for _, entry := range entries {
+		node := entry.Name()
+		if !strings.HasPrefix(node, prefix) {
+			continue
+		}
+		ibdev, err := readAttribute(filepath.Join(classRoot, node, "ibdev"))
+		if err != nil {
+			return nil, err
+		}
+		key := associatedNodeKey{ibdev: ibdev}
+		if port, err := readAttribute(filepath.Join(classRoot, node, "port")); err == nil {
+			key.port, _ = strconv.Atoi(port)
+		}
+		nodes[key] = append(nodes[key], node)
+	}
+
+	for _, n := range nodes {
+		sort.Slice(n, func(i, j int) bool {
+			return nodeIndex(n[i], prefix) < nodeIndex(n[j], prefix)
+		})
+	}
+	return nodes, nil
+}
+
+func nodeIndex(node string, prefix string) int {
+	index, _ := strconv.Atoi(strings.TrimPrefix(node, prefix))
+	return index
+}
+
+func readAttribute(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %v: %w", path, err)
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
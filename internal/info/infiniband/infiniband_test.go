@@ -0,0 +1,73 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package infiniband
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestGetDevices(t *testing.T) {
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description     string
+		sysRoot         string
+		expectedDevices []Device
+	}{
+		{
+			description: "missing sysfs class returns no devices",
+			sysRoot:     t.TempDir(),
+		},
+		{
+			description: "devices are read from sysfs",
+			sysRoot:     filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-rdma", "sys"),
+			expectedDevices: []Device{
+				{
+					Name:             "mlx5_0",
+					PCIBusID:         "0000:3b:00.0",
+					VerbsDeviceNodes: []string{"uverbs0"},
+					Ports: []Port{
+						{Number: 1, MADDeviceNodes: []string{"umad0"}},
+					},
+				},
+				{
+					Name:             "mlx5_1",
+					PCIBusID:         "0000:86:00.0",
+					VerbsDeviceNodes: []string{"uverbs1"},
+					Ports: []Port{
+						{Number: 1, MADDeviceNodes: []string{"umad1"}},
+						{Number: 2, MADDeviceNodes: []string{"umad2"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devices, err := GetDevices(tc.sysRoot)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, devices)
+		})
+	}
+}
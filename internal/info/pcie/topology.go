@@ -0,0 +1,67 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package pcie
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Path represents the location of a PCI device in the PCIe hierarchy. The
+// first element is the host bridge (e.g. pci0000:00), followed by the bus IDs
+// of the bridges (root ports and switch ports) leading to the device, with the
+// bus ID of the device itself as the last element.
+type Path []string
+
+// GetPath returns the location of the PCI device with the specified bus ID
+// in the PCIe hierarchy. This is determined by resolving the device link in
+// /bus/pci/devices under the specified sysfs root.
+func GetPath(sysRoot string, busID string) (Path, error) {
+	devicesRoot, err := filepath.EvalSymlinks(filepath.Join(sysRoot, "devices"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve sysfs devices root: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(sysRoot, "bus", "pci", "devices", busID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve PCI device %v: %w", busID, err)
+	}
+	relative, err := filepath.Rel(devicesRoot, resolved)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return nil, fmt.Errorf("unexpected location %v for PCI device %v", resolved, busID)
+	}
+	return strings.Split(relative, string(filepath.Separator)), nil
+}
+
+// CommonBridges returns the number of PCIe bridges that are shared by the
+// paths of two devices. Devices attached to the same PCIe switch share at
+// least one bridge. If the devices are connected only through a host bridge
+// (or not at all), zero is returned.
+func (p Path) CommonBridges(o Path) int {
+	if len(p) == 0 || len(o) == 0 || p[0] != o[0] {
+		return 0
+	}
+	common := 0
+	// The last element of each path is the device itself and is not a bridge.
+	for i := 1; i < len(p)-1 && i < len(o)-1; i++ {
+		if p[i] != o[i] {
+			break
+		}
+		common++
+	}
+	return common
+}
@@ -0,0 +1,86 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package pcie
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestGetPath(t *testing.T) {
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	sysRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-gpu-affinity", "sys")
+
+	path, err := GetPath(sysRoot, "0000:07:00.0")
+	require.NoError(t, err)
+	require.EqualValues(t, Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:01.0", "0000:07:00.0"}, path)
+
+	_, err = GetPath(sysRoot, "0000:ff:00.0")
+	require.Error(t, err)
+}
+
+func TestCommonBridges(t *testing.T) {
+	testCases := []struct {
+		description string
+		a           Path
+		b           Path
+		expected    int
+	}{
+		{
+			description: "different host bridges",
+			a:           Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0"},
+			b:           Path{"pci0000:80", "0000:80:01.0", "0000:81:00.0"},
+			expected:    0,
+		},
+		{
+			description: "different root ports",
+			a:           Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0"},
+			b:           Path{"pci0000:00", "0000:00:02.0", "0000:02:00.0"},
+			expected:    0,
+		},
+		{
+			description: "same root port",
+			a:           Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0"},
+			b:           Path{"pci0000:00", "0000:00:01.0", "0000:01:00.1"},
+			expected:    1,
+		},
+		{
+			description: "same PCIe switch",
+			a:           Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:00.0", "0000:03:00.0"},
+			b:           Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:01.0", "0000:07:00.0"},
+			expected:    2,
+		},
+		{
+			description: "empty path",
+			a:           Path{},
+			b:           Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0"},
+			expected:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.a.CommonBridges(tc.b))
+			require.Equal(t, tc.expected, tc.b.CommonBridges(tc.a))
+		})
+	}
+}
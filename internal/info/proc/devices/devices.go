@@ -37,6 +37,8 @@ const (
 	NVIDIACaps     = Name("nvidia-caps")
 	NVIDIAUVM      = Name("nvidia-uvm")
 
+	NVIDIACapsImexChannels = Name("nvidia-caps-imex-channels")
+
 	procDevicesPath    = "/proc/devices"
 	nvidiaDevicePrefix = "nvidia"
 )
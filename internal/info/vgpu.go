@@ -0,0 +1,130 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package info
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// migBackedProfilePattern matches the names of MIG-backed vGPU profiles such
+// as A100-2-10C. These include the number of GPU slices in addition to the
+// framebuffer size.
+var migBackedProfilePattern = regexp.MustCompile(`-[0-9]+-[0-9]+[A-Z]+$`)
+
+// A VGPU describes a vGPU as seen from a vGPU guest.
+type VGPU struct {
+	// Profile is the name of the vGPU profile (e.g. A100-4C).
+	Profile string
+	// MIGBacked indicates whether the vGPU is backed by a MIG device on the
+	// host.
+	MIGBacked bool
+	// ProductName is the name of the enabled licensable product (e.g. NVIDIA
+	// Virtual Compute Server). This is empty if no product is enabled.
+	ProductName string
+}
+
+// GetVGPU returns the vGPU information for the specified device.
+// If the device is not a vGPU (i.e. this is not a vGPU guest), nil is returned.
+// This is also the case if the driver does not support querying the
+// virtualization mode.
+func GetVGPU(d nvml.Device) (*VGPU, error) {
+	mode, ret := d.GetVirtualizationMode()
+	if ret == nvml.ERROR_NOT_SUPPORTED || ret == nvml.ERROR_FUNCTION_NOT_FOUND {
+		return nil, nil
+	}
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get virtualization mode: %v", ret)
+	}
+	if mode != nvml.GPU_VIRTUALIZATION_MODE_VGPU {
+		return nil, nil
+	}
+
+	name, ret := d.GetName()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device name: %v", ret)
+	}
+	profile := strings.TrimSpace(name)
+	for _, prefix := range []string{"GRID ", "NVIDIA "} {
+		profile = strings.TrimPrefix(profile, prefix)
+	}
+
+	vgpu := &VGPU{
+		Profile:     profile,
+		MIGBacked:   migBackedProfilePattern.MatchString(profile),
+		ProductName: getLicensedProductName(d),
+	}
+	return vgpu, nil
+}
+
+// IsVGPUGuest checks whether any of the devices is a vGPU.
+// NVML is expected to be initialized.
+func IsVGPUGuest(devicelib device.Interface) (is bool, reason string) {
+	var profiles []string
+	err := devicelib.VisitDevices(func(i int, d device.Device) error {
+		vgpu, err := GetVGPU(d)
+		if err != nil {
+			return fmt.Errorf("device %v: %w", i, err)
+		}
+		if vgpu != nil {
+			profiles = append(profiles, vgpu.Profile)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Sprintf("failed to get vGPU information: %v", err)
+	}
+	if len(profiles) == 0 {
+		return false, "no vGPU devices found"
+	}
+	return true, fmt.Sprintf("found vGPU devices %v", profiles)
+}
+
+// getLicensedProductName returns the product name of the first enabled
+// licensable feature of the device.
+func getLicensedProductName(d nvml.Device) string {
+	features, ret := d.GetGridLicensableFeatures()
+	if ret != nvml.SUCCESS {
+		return ""
+	}
+	for i, feature := range features.GridLicensableFeatures {
+		if uint32(i) >= features.LicensableFeaturesCount {
+			break
+		}
+		if feature.FeatureEnabled == 0 {
+			continue
+		}
+		return cString(feature.ProductName[:])
+	}
+	return ""
+}
+
+// cString converts a NULL-terminated C string to a Go string.
+func cString(c []int8) string {
+	var s strings.Builder
+	for _, b := range c {
+		if b == 0 {
+			break
+		}
+		s.WriteByte(byte(b))
+	}
+	return s.String()
+}
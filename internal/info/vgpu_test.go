@@ -0,0 +1,172 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package info
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVGPU(t *testing.T) {
+	testCases := []struct {
+		description   string
+		device        *mock.Device
+		expectedError bool
+		expected      *VGPU
+	}{
+		{
+			description: "bare-metal device is not a vGPU",
+			device: &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return nvml.GPU_VIRTUALIZATION_MODE_NONE, nvml.SUCCESS
+				},
+			},
+		},
+		{
+			description: "passthrough device is not a vGPU",
+			device: &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return nvml.GPU_VIRTUALIZATION_MODE_PASSTHROUGH, nvml.SUCCESS
+				},
+			},
+		},
+		{
+			description: "unsupported virtualization mode is not a vGPU",
+			device: &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return 0, nvml.ERROR_NOT_SUPPORTED
+				},
+			},
+		},
+		{
+			description: "missing virtualization mode query is not a vGPU",
+			device: &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return 0, nvml.ERROR_FUNCTION_NOT_FOUND
+				},
+			},
+		},
+		{
+			description: "failed query is an error",
+			device: &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return 0, nvml.ERROR_UNKNOWN
+				},
+			},
+			expectedError: true,
+		},
+		{
+			description: "vGPU profile is extracted from name",
+			device: &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return nvml.GPU_VIRTUALIZATION_MODE_VGPU, nvml.SUCCESS
+				},
+				GetNameFunc: func() (string, nvml.Return) {
+					return "GRID A100-4C", nvml.SUCCESS
+				},
+				GetGridLicensableFeaturesFunc: func() (nvml.GridLicensableFeatures, nvml.Return) {
+					features := nvml.GridLicensableFeatures{
+						IsGridLicenseSupported:  1,
+						LicensableFeaturesCount: 1,
+					}
+					features.GridLicensableFeatures[0].FeatureEnabled = 1
+					for i, b := range []byte("NVIDIA Virtual Compute Server") {
+						features.GridLicensableFeatures[0].ProductName[i] = int8(b)
+					}
+					return features, nvml.SUCCESS
+				},
+			},
+			expected: &VGPU{
+				Profile:     "A100-4C",
+				ProductName: "NVIDIA Virtual Compute Server",
+			},
+		},
+		{
+			description: "MIG-backed vGPU is detected",
+			device: &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return nvml.GPU_VIRTUALIZATION_MODE_VGPU, nvml.SUCCESS
+				},
+				GetNameFunc: func() (string, nvml.Return) {
+					return "NVIDIA A100-2-10C", nvml.SUCCESS
+				},
+				GetGridLicensableFeaturesFunc: func() (nvml.GridLicensableFeatures, nvml.Return) {
+					return nvml.GridLicensableFeatures{}, nvml.ERROR_NOT_SUPPORTED
+				},
+			},
+			expected: &VGPU{
+				Profile:   "A100-2-10C",
+				MIGBacked: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			vgpu, err := GetVGPU(tc.device)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expected, vgpu)
+		})
+	}
+}
+
+func TestIsVGPUGuest(t *testing.T) {
+	newLib := func(modes ...nvml.GpuVirtualizationMode) device.Interface {
+		var devices []nvml.Device
+		for _, mode := range modes {
+			mode := mode
+			devices = append(devices, &mock.Device{
+				GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+					return mode, nvml.SUCCESS
+				},
+				GetNameFunc: func() (string, nvml.Return) {
+					return "GRID T4-8C", nvml.SUCCESS
+				},
+				GetGridLicensableFeaturesFunc: func() (nvml.GridLicensableFeatures, nvml.Return) {
+					return nvml.GridLicensableFeatures{}, nvml.ERROR_NOT_SUPPORTED
+				},
+			})
+		}
+		nvmllib := &mock.Interface{
+			DeviceGetCountFunc: func() (int, nvml.Return) {
+				return len(devices), nvml.SUCCESS
+			},
+			DeviceGetHandleByIndexFunc: func(n int) (nvml.Device, nvml.Return) {
+				return devices[n], nvml.SUCCESS
+			},
+		}
+		return device.New(nvmllib)
+	}
+
+	isGuest, _ := IsVGPUGuest(newLib())
+	require.False(t, isGuest)
+
+	isGuest, _ = IsVGPUGuest(newLib(nvml.GPU_VIRTUALIZATION_MODE_NONE, nvml.GPU_VIRTUALIZATION_MODE_PASSTHROUGH))
+	require.False(t, isGuest)
+
+	isGuest, reason := IsVGPUGuest(newLib(nvml.GPU_VIRTUALIZATION_MODE_VGPU))
+	require.True(t, isGuest)
+	require.Contains(t, reason, "T4-8C")
+}
@@ -0,0 +1,74 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ldconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+)
+
+func TestCreateLdsoconfdFile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		dirs            []string
+		expectedContent []string
+	}{
+		{
+			description: "no directories creates no file",
+		},
+		{
+			description:     "directories are added once",
+			dirs:            []string{"/usr/local/cuda/compat", "/usr/lib/foo", "/usr/local/cuda/compat"},
+			expectedContent: []string{"/usr/local/cuda/compat\n/usr/lib/foo\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rootDir := t.TempDir()
+
+			err := CreateLdsoconfdFile(logger, containerroot.Root(rootDir), "00-test-*.conf", tc.dirs...)
+			require.NoError(t, err)
+
+			files, err := filepath.Glob(filepath.Join(rootDir, "etc/ld.so.conf.d/00-test-*.conf"))
+			require.NoError(t, err)
+
+			var content []string
+			for _, file := range files {
+				contents, err := os.ReadFile(file)
+				require.NoError(t, err)
+				content = append(content, string(contents))
+
+				info, err := os.Stat(file)
+				require.NoError(t, err)
+				require.EqualValues(t, 0644, info.Mode().Perm())
+			}
+			require.Equal(t, tc.expectedContent, content)
+			if len(tc.dirs) == 0 {
+				require.NoDirExists(t, filepath.Join(rootDir, "etc/ld.so.conf.d"))
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ContainerIDField is the name of the field that holds the container ID
+	// in log records.
+	ContainerIDField = "container-id"
+	// BundleField is the name of the field that holds the container bundle
+	// in log records.
+	BundleField = "bundle"
+)
+
+// Configure updates the format and level of the specified logger. If a
+// filename is specified, records are also written to this file. The returned
+// function closes the file and must be called once the logger is no longer
+// used.
+func Configure(l *logrus.Logger, format string, level string, filename string) (func() error, error) {
+	switch format {
+	case "":
+	case "text":
+		l.SetFormatter(new(logrus.TextFormatter))
+	case "json":
+		l.SetFormatter(new(logrus.JSONFormatter))
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+
+	if level != "" {
+		logLevel, err := logrus.ParseLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q", level)
+		}
+		l.SetLevel(logLevel)
+	}
+
+	if filename == "" || filename == os.DevNull {
+		return func() error { return nil }, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	logFile, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	l.SetOutput(io.MultiWriter(l.Out, logFile))
+
+	return logFile.Close, nil
+}
+
+// AddContainerFields adds the ID and bundle of a container to all records
+// logged by the specified logger. Empty values are omitted. This is a no-op
+// for loggers that are not backed by logrus.
+func AddContainerFields(l Interface, id string, bundle string) {
+	logger, ok := l.(*logrus.Logger)
+	if !ok {
+		return
+	}
+	fields := make(logrus.Fields)
+	if id != "" {
+		fields[ContainerIDField] = id
+	}
+	if bundle != "" {
+		fields[BundleField] = bundle
+	}
+	if len(fields) == 0 {
+		return
+	}
+	logger.AddHook(containerFieldsHook(fields))
+}
+
+// containerFieldsHook is a logrus hook that adds a fixed set of fields to
+// each record.
+type containerFieldsHook logrus.Fields
+
+// Levels returns all levels since the fields are added to all records.
+func (h containerFieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adds the fields to the record.
+func (h containerFieldsHook) Fire(entry *logrus.Entry) error {
+	for k, v := range h {
+		if _, exists := entry.Data[k]; !exists {
+			entry.Data[k] = v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "logs", "nvidia-cdi-hook.log")
+
+	stderr := &bytes.Buffer{}
+	l := logrus.New()
+	l.SetOutput(stderr)
+
+	closeLog, err := Configure(l, "json", "debug", filename)
+	require.NoError(t, err)
+
+	AddContainerFields(l, "ctr", "/run/bundle")
+	l.Debugf("creating symlinks")
+	require.NoError(t, closeLog())
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, stderr.String(), string(contents))
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(contents, &record))
+	require.Equal(t, "debug", record["level"])
+	require.Equal(t, "creating symlinks", record["msg"])
+	require.Equal(t, "ctr", record[ContainerIDField])
+	require.Equal(t, "/run/bundle", record[BundleField])
+}
+
+func TestConfigureInvalid(t *testing.T) {
+	_, err := Configure(logrus.New(), "xml", "", "")
+	require.EqualError(t, err, `invalid log format "xml"`)
+
+	_, err = Configure(logrus.New(), "", "loud", "")
+	require.EqualError(t, err, `invalid log level "loud"`)
+}
+
+func TestAddContainerFieldsNullLogger(t *testing.T) {
+	// This must not panic.
+	AddContainerFields(&NullLogger{}, "ctr", "/run/bundle")
+}
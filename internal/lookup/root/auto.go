@@ -0,0 +1,188 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+)
+
+const (
+	// AutoDriverRoot is the value that a driver root can be set to to
+	// trigger the auto-discovery of the driver root.
+	AutoDriverRoot = "auto"
+
+	defaultDiscoveryTimeout  = 30 * time.Second
+	defaultDiscoveryInterval = time.Second
+)
+
+// DefaultContainerizedDriverRoots defines the known locations where
+// containerized drivers (e.g. those managed by the GPU Operator) make the
+// driver root available on the host.
+var DefaultContainerizedDriverRoots = []string{
+	"/run/nvidia/driver",
+}
+
+// autoDiscoverer is used to determine the driver root automatically.
+type autoDiscoverer struct {
+	logger logger.Interface
+	// containerizedRoots are the candidate containerized driver roots. These
+	// are checked in order.
+	containerizedRoots []string
+	// hostRoot is the driver root used for host driver installations.
+	hostRoot string
+	// timeout is the maximum time to wait for a containerized driver that is
+	// not yet ready.
+	timeout time.Duration
+	// interval is the time to wait between checks for a containerized
+	// driver.
+	interval time.Duration
+	sleep    func(time.Duration)
+}
+
+// An AutoDiscoveryOption configures the auto-discovery of the driver root.
+type AutoDiscoveryOption func(*autoDiscoverer)
+
+// WithAutoDiscoveryLogger sets the logger used for driver root auto-discovery.
+func WithAutoDiscoveryLogger(logger logger.Interface) AutoDiscoveryOption {
+	return func(a *autoDiscoverer) {
+		a.logger = logger
+	}
+}
+
+// WithContainerizedDriverRoots sets the candidate containerized driver roots.
+func WithContainerizedDriverRoots(roots ...string) AutoDiscoveryOption {
+	return func(a *autoDiscoverer) {
+		a.containerizedRoots = roots
+	}
+}
+
+// WithHostDriverRoot sets the root used for host driver installations.
+func WithHostDriverRoot(root string) AutoDiscoveryOption {
+	return func(a *autoDiscoverer) {
+		a.hostRoot = root
+	}
+}
+
+// WithDiscoveryTimeout sets the maximum time to wait for a containerized
+// driver that is not yet ready. A timeout of zero disables waiting.
+func WithDiscoveryTimeout(timeout time.Duration) AutoDiscoveryOption {
+	return func(a *autoDiscoverer) {
+		a.timeout = timeout
+	}
+}
+
+// WithDiscoveryInterval sets the interval at which a containerized driver
+// that is not yet ready is rechecked.
+func WithDiscoveryInterval(interval time.Duration) AutoDiscoveryOption {
+	return func(a *autoDiscoverer) {
+		a.interval = interval
+	}
+}
+
+// IsAuto checks whether the specified driver root requests auto-discovery.
+func IsAuto(driverRoot string) bool {
+	return driverRoot == AutoDriverRoot
+}
+
+// DiscoverDriverRoot determines the driver root by probing the known
+// containerized driver locations and then the host driver root. A candidate
+// is considered valid if both libnvidia-ml.so.1 and nvidia-smi can be located
+// under it.
+//
+// If no valid driver root is found but a containerized driver location
+// exists, the driver container is assumed to not be ready yet and the
+// candidates are rechecked until the configured timeout expires. If the
+// timeout expires, the host driver root is returned along with an error so
+// that callers can decide whether to continue.
+func DiscoverDriverRoot(opts ...AutoDiscoveryOption) (string, error) {
+	a := &autoDiscoverer{
+		containerizedRoots: DefaultContainerizedDriverRoots,
+		hostRoot:           "/",
+		timeout:            defaultDiscoveryTimeout,
+		interval:           defaultDiscoveryInterval,
+		sleep:              time.Sleep,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.logger == nil {
+		a.logger = &logger.NullLogger{}
+	}
+	return a.discover()
+}
+
+func (a *autoDiscoverer) discover() (string, error) {
+	var waited time.Duration
+	for {
+		driverRoot, pending := a.probe()
+		if driverRoot != "" {
+			a.logger.Infof("Using auto-discovered driver root %v", driverRoot)
+			return driverRoot, nil
+		}
+		if len(pending) == 0 {
+			return a.hostRoot, fmt.Errorf("no valid driver root found")
+		}
+		if waited >= a.timeout {
+			return a.hostRoot, fmt.Errorf("timed out waiting for containerized driver at %v", pending)
+		}
+		a.logger.Infof("Waiting for containerized driver at %v to become ready", pending)
+		a.sleep(a.interval)
+		waited += a.interval
+	}
+}
+
+// probe checks the candidate driver roots in order and returns the first
+// valid root. If no root is valid, the containerized driver roots that exist
+// but are not (yet) valid are returned instead.
+func (a *autoDiscoverer) probe() (string, []string) {
+	var pending []string
+	for _, candidate := range a.containerizedRoots {
+		err := a.validate(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		a.logger.Debugf("Ignoring containerized driver root %v: %v", candidate, err)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			pending = append(pending, candidate)
+		}
+	}
+	if err := a.validate(a.hostRoot); err != nil {
+		a.logger.Debugf("Ignoring host driver root %v: %v", a.hostRoot, err)
+		return "", pending
+	}
+	return a.hostRoot, nil
+}
+
+// validate checks whether the specified driver root contains a usable driver.
+func (a *autoDiscoverer) validate(driverRoot string) error {
+	driver := New(
+		WithLogger(a.logger),
+		WithDriverRoot(driverRoot),
+	)
+	if _, err := driver.Libraries().Locate("libnvidia-ml.so.1"); err != nil {
+		return fmt.Errorf("failed to locate libnvidia-ml.so.1: %w", err)
+	}
+	if _, err := lookup.NewExecutableLocator(a.logger, driverRoot).Locate("nvidia-smi"); err != nil {
+		return fmt.Errorf("failed to locate nvidia-smi: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverDriverRoot(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	// createDriverRoot creates a driver root containing the files used to
+	// validate a driver installation.
+	createDriverRoot := func(t *testing.T, root string) {
+		for _, f := range []string{"usr/lib64/libnvidia-ml.so.1", "usr/bin/nvidia-smi"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(f)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(root, f), nil, 0755))
+		}
+	}
+
+	testCases := []struct {
+		description string
+		// setup creates the containerized and host driver roots and returns
+		// a function to call while waiting.
+		setup          func(t *testing.T, containerized string, host string) func()
+		expectedRoot   string
+		expectedError  bool
+		expectedSleeps int
+	}{
+		{
+			description: "containerized driver is preferred",
+			setup: func(t *testing.T, containerized string, host string) func() {
+				createDriverRoot(t, containerized)
+				createDriverRoot(t, host)
+				return nil
+			},
+			expectedRoot: "containerized",
+		},
+		{
+			description: "host driver is used if no containerized driver exists",
+			setup: func(t *testing.T, containerized string, host string) func() {
+				createDriverRoot(t, host)
+				return nil
+			},
+			expectedRoot: "host",
+		},
+		{
+			description: "no driver returns an error without waiting",
+			setup: func(t *testing.T, containerized string, host string) func() {
+				return nil
+			},
+			expectedRoot:  "host",
+			expectedError: true,
+		},
+		{
+			description: "waits for containerized driver to become ready",
+			setup: func(t *testing.T, containerized string, host string) func() {
+				require.NoError(t, os.MkdirAll(containerized, 0755))
+				sleeps := 0
+				return func() {
+					sleeps++
+					if sleeps == 2 {
+						createDriverRoot(t, containerized)
+					}
+				}
+			},
+			expectedRoot:   "containerized",
+			expectedSleeps: 2,
+		},
+		{
+			description: "wait for containerized driver is bounded",
+			setup: func(t *testing.T, containerized string, host string) func() {
+				require.NoError(t, os.MkdirAll(containerized, 0755))
+				return nil
+			},
+			expectedRoot:   "host",
+			expectedError:  true,
+			expectedSleeps: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tmp := t.TempDir()
+			roots := map[string]string{
+				"containerized": filepath.Join(tmp, "run", "nvidia", "driver"),
+				"host":          filepath.Join(tmp, "host"),
+			}
+			onSleep := tc.setup(t, roots["containerized"], roots["host"])
+
+			var sleeps int
+			driverRoot, err := DiscoverDriverRoot(
+				WithAutoDiscoveryLogger(logger),
+				WithContainerizedDriverRoots(roots["containerized"]),
+				WithHostDriverRoot(roots["host"]),
+				WithDiscoveryTimeout(5*time.Second),
+				WithDiscoveryInterval(time.Second),
+				withSleep(func(time.Duration) {
+					sleeps++
+					if onSleep != nil {
+						onSleep()
+					}
+				}),
+			)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, roots[tc.expectedRoot], driverRoot)
+			require.Equal(t, tc.expectedSleeps, sleeps)
+		})
+	}
+}
+
+// withSleep overrides the function used to wait between checks.
+func withSleep(sleep func(time.Duration)) AutoDiscoveryOption {
+	return func(a *autoDiscoverer) {
+		a.sleep = sleep
+	}
+}
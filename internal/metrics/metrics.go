@@ -0,0 +1,190 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A Collector is a metric that can be exported by a Registry.
+type Collector interface {
+	collect() *family
+}
+
+// family represents a metric family in the Prometheus text format.
+type family struct {
+	name       string
+	help       string
+	metricType string
+	samples    []sample
+}
+
+// sample represents a single sample of a metric family. The series includes
+// the metric name and the formatted labels.
+type sample struct {
+	series string
+	value  float64
+}
+
+// metric stores the values of a metric for each combination of label values.
+type metric struct {
+	sync.Mutex
+	name   string
+	help   string
+	labels []string
+	values map[string][]float64
+}
+
+func newMetric(name string, help string, labels []string) metric {
+	return metric{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: make(map[string][]float64),
+	}
+}
+
+// update applies the specified function to the values for the label values.
+// The values are created with the specified size if required.
+func (m *metric) update(size int, labelValues []string, f func([]float64)) {
+	if len(labelValues) != len(m.labels) {
+		panic(fmt.Sprintf("metric %v expects %d label values; got %d", m.name, len(m.labels), len(labelValues)))
+	}
+	m.Lock()
+	defer m.Unlock()
+
+	key := m.formatLabels(labelValues)
+	values, ok := m.values[key]
+	if !ok {
+		values = make([]float64, size)
+		m.values[key] = values
+	}
+	f(values)
+}
+
+// collect returns the family for the metric with a sample for each
+// combination of label values and each of the specified suffixes.
+func (m *metric) collect(metricType string, suffixes ...string) *family {
+	m.Lock()
+	defer m.Unlock()
+
+	f := &family{
+		name:       m.name,
+		help:       m.help,
+		metricType: metricType,
+	}
+	var keys []string
+	for key := range m.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for i, suffix := range suffixes {
+			f.samples = append(f.samples, sample{
+				series: m.name + suffix + key,
+				value:  m.values[key][i],
+			})
+		}
+	}
+	return f
+}
+
+// formatLabels returns the labels for the specified values in the Prometheus
+// text format.
+func (m *metric) formatLabels(labelValues []string) string {
+	if len(labelValues) == 0 {
+		return ""
+	}
+	var pairs []string
+	for i, label := range m.labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label, labelValueEscaper.Replace(labelValues[i])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labelValueEscaper escapes label values as required by the Prometheus text
+// format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// A Counter is a metric whose value only increases.
+type Counter struct {
+	metric
+}
+
+// NewCounter creates a counter with the specified labels.
+func NewCounter(name string, help string, labels ...string) *Counter {
+	return &Counter{newMetric(name, help, labels)}
+}
+
+// Inc increments the counter for the specified label values by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter for the specified label values.
+func (c *Counter) Add(value float64, labelValues ...string) {
+	c.update(1, labelValues, func(v []float64) { v[0] += value })
+}
+
+func (c *Counter) collect() *family {
+	return c.metric.collect("counter", "")
+}
+
+// A Gauge is a metric whose value can be set arbitrarily.
+type Gauge struct {
+	metric
+}
+
+// NewGauge creates a gauge with the specified labels.
+func NewGauge(name string, help string, labels ...string) *Gauge {
+	return &Gauge{newMetric(name, help, labels)}
+}
+
+// Set sets the value of the gauge for the specified label values.
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.update(1, labelValues, func(v []float64) { v[0] = value })
+}
+
+func (g *Gauge) collect() *family {
+	return g.metric.collect("gauge", "")
+}
+
+// A Summary tracks the sum and count of observations. Quantiles are not
+// tracked so that summaries from multiple processes can be combined.
+type Summary struct {
+	metric
+}
+
+// NewSummary creates a summary with the specified labels.
+func NewSummary(name string, help string, labels ...string) *Summary {
+	return &Summary{newMetric(name, help, labels)}
+}
+
+// Observe adds an observation for the specified label values.
+func (s *Summary) Observe(value float64, labelValues ...string) {
+	s.update(2, labelValues, func(v []float64) {
+		v[0] += value
+		v[1]++
+	})
+}
+
+func (s *Summary) collect() *family {
+	return s.metric.collect("summary", "_sum", "_count")
+}
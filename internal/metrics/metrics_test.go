@@ -0,0 +1,219 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package metrics
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryWriteTo(t *testing.T) {
+	counter := NewCounter("test_total", "A counter.", "mode")
+	gauge := NewGauge("test_gauge", "A gauge.")
+	summary := NewSummary("test_seconds", "A summary.", "name")
+
+	counter.Inc("cdi")
+	counter.Add(2, "legacy")
+	counter.Inc("cdi")
+	gauge.Set(1.5)
+	summary.Observe(0.25, `quoted "name"`)
+	summary.Observe(0.5, `quoted "name"`)
+
+	var buf bytes.Buffer
+	_, err := NewRegistry(counter, gauge, summary).WriteTo(&buf)
+	require.NoError(t, err)
+
+	expected := `# HELP test_total A counter.
+# TYPE test_total counter
+test_total{mode="cdi"} 2
+test_total{mode="legacy"} 2
+# HELP test_gauge A gauge.
+# TYPE test_gauge gauge
+test_gauge 1.5
+# HELP test_seconds A summary.
+# TYPE test_seconds summary
+test_seconds_sum{name="quoted \"name\""} 0.75
+test_seconds_count{name="quoted \"name\""} 2
+`
+	require.Equal(t, expected, buf.String())
+}
+
+func TestUpdateTextfile(t *testing.T) {
+	textfile := filepath.Join(t.TempDir(), "nvidia.prom")
+	require.NoError(t, os.WriteFile(textfile, []byte(`# HELP other_metric Another metric.
+# TYPE other_metric gauge
+other_metric 42
+# HELP test_total A counter.
+# TYPE test_total counter
+test_total{mode="csv"} 3
+test_total{mode="cdi"} 1
+`), 0644))
+
+	counter := NewCounter("test_total", "A counter.", "mode")
+	gauge := NewGauge("test_gauge", "A gauge.")
+	counter.Inc("cdi")
+	counter.Inc("legacy")
+	gauge.Set(2)
+
+	registry := NewRegistry(counter, gauge)
+	require.NoError(t, registry.UpdateTextfile(textfile))
+	gauge.Set(3)
+	require.NoError(t, NewRegistry(gauge).UpdateTextfile(textfile))
+
+	contents, err := os.ReadFile(textfile)
+	require.NoError(t, err)
+	expected := `# HELP other_metric Another metric.
+# TYPE other_metric gauge
+other_metric 42
+# HELP test_total A counter.
+# TYPE test_total counter
+test_total{mode="csv"} 3
+test_total{mode="cdi"} 2
+test_total{mode="legacy"} 1
+# HELP test_gauge A gauge.
+# TYPE test_gauge gauge
+test_gauge 3
+`
+	require.Equal(t, expected, string(contents))
+
+	entries, err := os.ReadDir(filepath.Dir(textfile))
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.ElementsMatch(t, []string{"nvidia.prom", "nvidia.prom.lock"}, names)
+}
+
+func TestUpdateTextfileConcurrent(t *testing.T) {
+	textfile := filepath.Join(t.TempDir(), "nvidia.prom")
+
+	const updates = 20
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter := NewCounter("test_total", "A counter.")
+			counter.Inc()
+			require.NoError(t, NewRegistry(counter).UpdateTextfile(textfile))
+		}()
+	}
+	wg.Wait()
+
+	contents, err := os.ReadFile(textfile)
+	require.NoError(t, err)
+	families, err := parseFamilies(contents)
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, []sample{{series: "test_total", value: updates}}, families[0].samples)
+}
+
+func TestParseFamilies(t *testing.T) {
+	testCases := []struct {
+		description      string
+		contents         string
+		expectedError    bool
+		expectedFamilies []*family
+	}{
+		{
+			description: "other comments are ignored",
+			contents: `# A comment about test_total
+#
+# HELP test_total A counter.
+# TYPE test_total counter
+test_total 1
+`,
+			expectedFamilies: []*family{
+				{name: "test_total", help: "A counter.", metricType: "counter", samples: []sample{{series: "test_total", value: 1}}},
+			},
+		},
+		{
+			description: "samples are not matched by prefix",
+			contents: `# TYPE test gauge
+test 1
+test_total 2
+`,
+			expectedFamilies: []*family{
+				{name: "test", metricType: "gauge", samples: []sample{{series: "test", value: 1}}},
+				{name: "test_total", samples: []sample{{series: "test_total", value: 2}}},
+			},
+		},
+		{
+			description: "summary samples belong to the summary",
+			contents: `# HELP test_seconds A summary.
+# TYPE test_seconds summary
+test_seconds_sum{name="a b"} 0.75
+test_seconds_count{name="{}"} 2
+`,
+			expectedFamilies: []*family{
+				{
+					name:       "test_seconds",
+					help:       "A summary.",
+					metricType: "summary",
+					samples: []sample{
+						{series: `test_seconds_sum{name="a b"}`, value: 0.75},
+						{series: `test_seconds_count{name="{}"}`, value: 2},
+					},
+				},
+			},
+		},
+		{
+			description: "timestamps are ignored",
+			contents: `test_gauge{mode="cdi"} 3 1700000000000
+`,
+			expectedFamilies: []*family{
+				{name: "test_gauge", samples: []sample{{series: `test_gauge{mode="cdi"}`, value: 3}}},
+			},
+		},
+		{
+			description: "invalid type is an error",
+			contents: `# TYPE test_total count
+`,
+			expectedError: true,
+		},
+		{
+			description: "unterminated labels are an error",
+			contents: `test_total{mode="cdi} 1
+`,
+			expectedError: true,
+		},
+		{
+			description: "missing value is an error",
+			contents: `test_total
+`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			families, err := parseFamilies([]byte(tc.contents))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFamilies, families)
+		})
+	}
+}
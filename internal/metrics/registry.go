@@ -0,0 +1,84 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// contentType is the content type of the Prometheus text format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// A Registry holds a set of metrics that are exported together.
+type Registry struct {
+	sync.Mutex
+	collectors []Collector
+}
+
+var _ http.Handler = (*Registry)(nil)
+
+// NewRegistry creates a registry for the specified metrics.
+func NewRegistry(collectors ...Collector) *Registry {
+	return &Registry{collectors: collectors}
+}
+
+// Register adds the specified metrics to the registry.
+func (r *Registry) Register(collectors ...Collector) {
+	r.Lock()
+	defer r.Unlock()
+	r.collectors = append(r.collectors, collectors...)
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	for _, f := range r.collect() {
+		f.writeTo(&buf)
+	}
+	return buf.WriteTo(w)
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	_, _ = r.WriteTo(w)
+}
+
+func (r *Registry) collect() []*family {
+	r.Lock()
+	defer r.Unlock()
+
+	var families []*family
+	for _, c := range r.collectors {
+		families = append(families, c.collect())
+	}
+	return families
+}
+
+// writeTo writes the family in the Prometheus text format.
+func (f *family) writeTo(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.metricType)
+	for _, s := range f.samples {
+		fmt.Fprintf(w, "%s %s\n", s.series, strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+}
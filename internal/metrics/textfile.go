@@ -0,0 +1,293 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// UpdateTextfile merges the metrics of the registry into the specified file
+// for the node-exporter textfile collector. Counters and summaries are added
+// to the existing values so that the file accumulates the metrics of all
+// processes. Gauges replace the existing values.
+//
+// Updates are serialized using a lock file and the file is replaced
+// atomically so that concurrent processes do not lose updates and the
+// collector never reads a partially written file. Each call therefore takes
+// the lock and reads, syncs, and renames the file. Callers on a latency
+// sensitive path should call this once with all the updates of the process.
+func (r *Registry) UpdateTextfile(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create textfile directory: %w", err)
+	}
+
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock textfile: %w", err)
+	}
+	defer unlock()
+
+	existing, err := readTextfile(path)
+	if err != nil {
+		return err
+	}
+	merged := mergeFamilies(existing, r.collect())
+
+	var buf bytes.Buffer
+	for _, f := range merged {
+		f.writeTo(&buf)
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// readTextfile parses the families from an existing textfile. A missing file
+// is treated as empty.
+func readTextfile(path string) ([]*family, error) {
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read textfile: %w", err)
+	}
+	return parseFamilies(contents)
+}
+
+// parseFamilies parses the subset of the Prometheus text format written by
+// (*family).writeTo. Comments other than HELP and TYPE lines are ignored.
+// Samples are assigned to the family with a matching name or, for summaries
+// and histograms, to the family that the suffix of the sample name belongs
+// to.
+func parseFamilies(contents []byte) ([]*family, error) {
+	var families []*family
+	byName := make(map[string]*family)
+	getFamily := func(name string) *family {
+		if f, ok := byName[name]; ok {
+			return f
+		}
+		f := &family{name: name}
+		byName[name] = f
+		families = append(families, f)
+		return f
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			keyword, name, text := parseComment(comment)
+			switch keyword {
+			case "HELP":
+				getFamily(name).help = text
+			case "TYPE":
+				if !isValidMetricType(text) {
+					return nil, fmt.Errorf("invalid metric type in %q", line)
+				}
+				getFamily(name).metricType = text
+			}
+			continue
+		}
+
+		series, value, err := parseSample(line)
+		if err != nil {
+			return nil, err
+		}
+		f := getFamily(familyName(byName, metricName(series)))
+		f.samples = append(f.samples, sample{series: series, value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse textfile: %w", err)
+	}
+	return families, nil
+}
+
+// parseComment parses the keyword, metric name, and text of a HELP or TYPE
+// comment. The keyword is empty for other comments.
+func parseComment(comment string) (string, string, string) {
+	keyword, rest, _ := strings.Cut(strings.TrimLeft(comment, " \t"), " ")
+	if keyword != "HELP" && keyword != "TYPE" {
+		return "", "", ""
+	}
+	name, text, _ := strings.Cut(strings.TrimLeft(rest, " \t"), " ")
+	if name == "" {
+		return "", "", ""
+	}
+	return keyword, name, strings.TrimSpace(text)
+}
+
+// isValidMetricType checks whether the specified type is one of the metric
+// types of the Prometheus text format.
+func isValidMetricType(metricType string) bool {
+	switch metricType {
+	case "counter", "gauge", "summary", "histogram", "untyped":
+		return true
+	}
+	return false
+}
+
+// parseSample parses the series and value of a sample line. The series
+// includes the metric name and the labels, if any. A timestamp following the
+// value is ignored.
+func parseSample(line string) (string, float64, error) {
+	end := strings.IndexAny(line, "{ \t")
+	if end < 0 {
+		return "", 0, fmt.Errorf("invalid sample %q", line)
+	}
+	if line[end] == '{' {
+		labelsEnd := labelsEndIndex(line[end:])
+		if labelsEnd < 0 {
+			return "", 0, fmt.Errorf("invalid labels in sample %q", line)
+		}
+		end += labelsEnd + 1
+	}
+
+	fields := strings.Fields(line[end:])
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", 0, fmt.Errorf("invalid sample %q", line)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid value in sample %q: %w", line, err)
+	}
+	return line[:end], value, nil
+}
+
+// labelsEndIndex returns the index of the brace that closes the specified
+// labels or -1 if the labels are not closed. Braces in quoted label values
+// are skipped.
+func labelsEndIndex(labels string) int {
+	var quoted, escaped bool
+	for i, c := range labels {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+// metricName returns the name of the metric for the specified series.
+func metricName(series string) string {
+	if i := strings.Index(series, "{"); i >= 0 {
+		return series[:i]
+	}
+	return series
+}
+
+// familyName returns the name of the family for a sample with the specified
+// metric name. The _sum, _count, and _bucket samples of summaries and
+// histograms belong to the family without the suffix.
+func familyName(families map[string]*family, name string) string {
+	if _, ok := families[name]; ok {
+		return name
+	}
+	for _, suffix := range []string{"_sum", "_count", "_bucket"} {
+		base, ok := strings.CutSuffix(name, suffix)
+		if !ok {
+			continue
+		}
+		if f, ok := families[base]; ok && (f.metricType == "summary" || f.metricType == "histogram") {
+			return base
+		}
+	}
+	return name
+}
+
+// mergeFamilies merges the updates into the existing families. The order of
+// the existing families is maintained and new families are appended.
+func mergeFamilies(existing []*family, updates []*family) []*family {
+	byName := make(map[string]*family)
+	for _, f := range existing {
+		byName[f.name] = f
+	}
+
+	merged := existing
+	for _, update := range updates {
+		f, ok := byName[update.name]
+		if !ok {
+			f = &family{name: update.name}
+			byName[update.name] = f
+			merged = append(merged, f)
+		}
+		f.help = update.help
+		f.metricType = update.metricType
+
+		index := make(map[string]int)
+		for i, s := range f.samples {
+			index[s.series] = i
+		}
+		for _, s := range update.samples {
+			i, ok := index[s.series]
+			switch {
+			case !ok:
+				index[s.series] = len(f.samples)
+				f.samples = append(f.samples, s)
+			case update.metricType == "gauge":
+				f.samples[i].value = s.value
+			default:
+				f.samples[i].value += s.value
+			}
+		}
+	}
+	return merged
+}
+
+// writeFileAtomic writes the contents to a temporary file in the same
+// directory and renames this to the specified path.
+func writeFileAtomic(path string, contents []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions of temporary file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace textfile: %w", err)
+	}
+	return nil
+}
@@ -19,14 +19,18 @@ package modifier
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"tags.cncf.io/container-device-interface/pkg/parser"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devicepolicy"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier/cdi"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 )
@@ -34,7 +38,11 @@ import (
 // NewCDIModifier creates an OCI spec modifier that determines the modifications to make based on the
 // CDI specifications available on the system. The NVIDIA_VISIBLE_DEVICES environment variable is
 // used to select the devices to include.
-func NewCDIModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec) (oci.SpecModifier, error) {
+//
+// The NVIDIA_REQUIRE_* requirements of the container are checked against the
+// requested devices before any modifications are made. The specified tracer,
+// which may be nil, records the time taken to generate and inject CDI specs.
+func NewCDIModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec, driver *root.Driver, containerRoot string, tracer *tracing.Tracer) (oci.SpecModifier, error) {
 	devices, err := getDevicesFromSpec(logger, ociSpec, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get required devices from OCI specification: %v", err)
@@ -45,12 +53,25 @@ func NewCDIModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spe
 	}
 	logger.Debugf("Creating CDI modifier for devices: %v", devices)
 
+	rawSpec, err := ociSpec.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OCI spec: %v", err)
+	}
+	container, err := image.NewCUDAImageFromSpec(rawSpec)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckRequirements(logger, cfg, container, driver, containerRoot, devices); err != nil {
+		RequirementCheckFailures.Inc("cdi")
+		return nil, fmt.Errorf("requirements not met: %v", err)
+	}
+
 	automaticDevices := filterAutomaticDevices(devices)
 	if len(automaticDevices) != len(devices) && len(automaticDevices) > 0 {
 		return nil, fmt.Errorf("requesting a CDI device with vendor 'runtime.nvidia.com' is not supported when requesting other CDI devices")
 	}
 	if len(automaticDevices) > 0 {
-		automaticModifier, err := newAutomaticCDISpecModifier(logger, cfg, automaticDevices)
+		automaticModifier, err := newAutomaticCDISpecModifier(logger, cfg, automaticDevices, tracer)
 		if err == nil {
 			return automaticModifier, nil
 		}
@@ -62,21 +83,30 @@ func NewCDIModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spe
 		cdi.WithLogger(logger),
 		cdi.WithDevices(devices...),
 		cdi.WithSpecDirs(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs...),
+		cdi.WithTracer(tracer),
 	)
 }
 
+// getDevicesFromSpec returns the CDI devices requested by the container.
+// The configured device policy is applied to the requested devices.
 func getDevicesFromSpec(logger logger.Interface, ociSpec oci.Spec, cfg *config.Config) ([]string, error) {
 	rawSpec, err := ociSpec.Load()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OCI spec: %v", err)
 	}
 
+	policy, err := devicepolicy.New(logger, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct device policy: %w", err)
+	}
+	policyContainer := devicepolicy.NewContainerFromSpec(rawSpec)
+
 	annotationDevices, err := getAnnotationDevices(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes, rawSpec.Annotations)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse container annotations: %v", err)
 	}
 	if len(annotationDevices) > 0 {
-		return annotationDevices, nil
+		return policy.Apply(policyContainer, devicepolicy.SourceAnnotation, annotationDevices)
 	}
 
 	container, err := image.NewCUDAImageFromSpec(rawSpec)
@@ -86,7 +116,7 @@ func getDevicesFromSpec(logger logger.Interface, ociSpec oci.Spec, cfg *config.C
 	if cfg.AcceptDeviceListAsVolumeMounts {
 		mountDevices := container.CDIDevicesFromMounts()
 		if len(mountDevices) > 0 {
-			return mountDevices, nil
+			return policy.Apply(policyContainer, devicepolicy.SourceMount, mountDevices)
 		}
 	}
 
@@ -108,7 +138,7 @@ func getDevicesFromSpec(logger logger.Interface, ociSpec oci.Spec, cfg *config.C
 	}
 
 	if cfg.AcceptEnvvarUnprivileged || image.IsPrivileged(rawSpec) {
-		return devices, nil
+		return policy.Apply(policyContainer, devicepolicy.SourceEnvvar, devices)
 	}
 
 	logger.Warningf("Ignoring devices specified in NVIDIA_VISIBLE_DEVICES: %v", devices)
@@ -163,9 +193,11 @@ func filterAutomaticDevices(devices []string) []string {
 	return automatic
 }
 
-func newAutomaticCDISpecModifier(logger logger.Interface, cfg *config.Config, devices []string) (oci.SpecModifier, error) {
+func newAutomaticCDISpecModifier(logger logger.Interface, cfg *config.Config, devices []string, tracer *tracing.Tracer) (oci.SpecModifier, error) {
 	logger.Debugf("Generating in-memory CDI specs for devices %v", devices)
-	spec, err := generateAutomaticCDISpec(logger, cfg, devices)
+	start := time.Now()
+	spec, err := generateAutomaticCDISpec(logger, cfg, devices, tracer)
+	AutomaticCDIGenerationSeconds.Observe(time.Since(start).Seconds())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate CDI spec: %w", err)
 	}
@@ -180,13 +212,14 @@ func newAutomaticCDISpecModifier(logger logger.Interface, cfg *config.Config, de
 	return cdiModifier, nil
 }
 
-func generateAutomaticCDISpec(logger logger.Interface, cfg *config.Config, devices []string) (spec.Interface, error) {
+func generateAutomaticCDISpec(logger logger.Interface, cfg *config.Config, devices []string, tracer *tracing.Tracer) (spec.Interface, error) {
 	cdilib, err := nvcdi.New(
 		nvcdi.WithLogger(logger),
 		nvcdi.WithNVIDIACDIHookPath(cfg.NVIDIACTKConfig.Path),
 		nvcdi.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
 		nvcdi.WithVendor("runtime.nvidia.com"),
 		nvcdi.WithClass("gpu"),
+		nvcdi.WithTracer(tracer),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct CDI library: %w", err)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 )
 
 type builder struct {
@@ -31,6 +32,7 @@ type builder struct {
 	specDirs []string
 	devices  []string
 	cdiSpec  *specs.Spec
+	tracer   *tracing.Tracer
 }
 
 // Option represents a functional option for creating a CDI mofifier.
@@ -61,10 +63,12 @@ func (m builder) build() (oci.SpecModifier, error) {
 		return modifier, nil
 	}
 
+	span := m.tracer.Start("cdi.NewCache")
 	registry, err := cdi.NewCache(
 		cdi.WithAutoRefresh(false),
 		cdi.WithSpecDirs(m.specDirs...),
 	)
+	span.End()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CDI registry: %v", err)
 	}
@@ -73,6 +77,7 @@ func (m builder) build() (oci.SpecModifier, error) {
 		logger:   m.logger,
 		registry: registry,
 		devices:  m.devices,
+		tracer:   m.tracer,
 	}
 
 	return modifier, nil
@@ -105,3 +110,10 @@ func WithSpec(spec *specs.Spec) Option {
 		b.cdiSpec = spec
 	}
 }
+
+// WithTracer sets the tracer for the CDI modifier builder.
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(b *builder) {
+		b.tracer = tracer
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 )
 
 // fromRegistry represents the modifications performed using a CDI registry.
@@ -32,18 +33,24 @@ type fromRegistry struct {
 	logger   logger.Interface
 	registry *cdi.Cache
 	devices  []string
+	tracer   *tracing.Tracer
 }
 
 var _ oci.SpecModifier = (*fromRegistry)(nil)
 
 // Modify applies the modifications defined by the CDI registry to the incoming OCI spec.
 func (m fromRegistry) Modify(spec *specs.Spec) error {
+	span := m.tracer.Start("cdi.Refresh")
 	if err := m.registry.Refresh(); err != nil {
 		m.logger.Debugf("The following error was triggered when refreshing the CDI registry: %v", err)
 	}
+	span.End()
 
 	m.logger.Debugf("Injecting devices using CDI: %v", m.devices)
+	span = m.tracer.Start("cdi.InjectDevices")
 	unresolvedDevices, err := m.registry.InjectDevices(spec, m.devices...)
+	span.RecordError(err)
+	span.End()
 	if unresolvedDevices != nil {
 		m.logger.Warningf("could not resolve CDI devices: %v", unresolvedDevices)
 	}
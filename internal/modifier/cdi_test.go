@@ -20,7 +20,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 )
 
 func TestGetAnnotationDevices(t *testing.T) {
@@ -90,3 +95,57 @@ func TestGetAnnotationDevices(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDevicesFromSpecAppliesPolicy(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		onDeny          string
+		spec            *specs.Spec
+		expectedDevices []string
+		expectedError   string
+	}{
+		{
+			description: "denied annotation device is rejected",
+			spec: &specs.Spec{
+				Annotations: map[string]string{
+					"cdi.k8s.io/test": "nvidia.com/gpu=0,nvidia.com/imex-channel=0",
+				},
+			},
+			expectedError: "device request denied by policy: nvidia.com/imex-channel=0",
+		},
+		{
+			description: "denied envvar device is filtered",
+			onDeny:      "filter",
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=0,nvidia.com/imex-channel=0"},
+				},
+			},
+			expectedDevices: []string{"nvidia.com/gpu=0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg, err := config.GetDefault()
+			require.NoError(t, err)
+			cfg.AcceptEnvvarUnprivileged = true
+			cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.Policy = config.DevicePolicyConfig{
+				OnDeny: tc.onDeny,
+				Rules: []config.DevicePolicyRule{
+					{Action: "deny", Classes: []string{"imex-channel"}},
+				},
+			}
+
+			devices, err := getDevicesFromSpec(logger, oci.NewMemorySpec(tc.spec), cfg)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, devices)
+		})
+	}
+}
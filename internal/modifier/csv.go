@@ -21,25 +21,25 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
-	"github.com/NVIDIA/nvidia-container-toolkit/internal/cuda"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier/cdi"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
-	"github.com/NVIDIA/nvidia-container-toolkit/internal/requirements"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
 )
 
 // NewCSVModifier creates a modifier that applies modications to an OCI spec if required by the runtime wrapper.
 // The modifications are defined by CSV MountSpecs.
-func NewCSVModifier(logger logger.Interface, cfg *config.Config, container image.CUDA) (oci.SpecModifier, error) {
+func NewCSVModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, driver *root.Driver, containerRoot string) (oci.SpecModifier, error) {
 	if devices := container.VisibleDevicesFromEnvVar(); len(devices) == 0 {
 		logger.Infof("No modification required; no devices requested")
 		return nil, nil
 	}
 	logger.Infof("Constructing modifier from config: %+v", *cfg)
 
-	if err := checkRequirements(logger, container); err != nil {
+	if err := CheckRequirements(logger, cfg, container, driver, containerRoot, container.VisibleDevicesFromEnvVar()); err != nil {
+		RequirementCheckFailures.Inc("csv")
 		return nil, fmt.Errorf("requirements not met: %v", err)
 	}
 
@@ -58,6 +58,7 @@ func NewCSVModifier(logger logger.Interface, cfg *config.Config, container image
 		nvcdi.WithNVIDIACDIHookPath(cfg.NVIDIACTKConfig.Path),
 		nvcdi.WithMode(nvcdi.ModeCSV),
 		nvcdi.WithCSVFiles(csvFiles),
+		nvcdi.WithCSVDriverCapabilities(getCSVDriverCapabilities(cfg, container)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct CDI library: %v", err)
@@ -74,34 +75,18 @@ func NewCSVModifier(logger logger.Interface, cfg *config.Config, container image
 	)
 }
 
-func checkRequirements(logger logger.Interface, image image.CUDA) error {
-	if image.HasDisableRequire() {
-		// TODO: We could print the real value here instead
-		logger.Debugf("NVIDIA_DISABLE_REQUIRE=%v; skipping requirement checks", true)
-		return nil
+// getCSVDriverCapabilities returns the driver capabilities that the entries in
+// the CSV files are filtered by. These are the capabilities requested by the
+// container that are also supported by the config. If the container does not
+// request specific capabilities, all entries are included as has always been
+// the case in CSV mode.
+func getCSVDriverCapabilities(cfg *config.Config, container image.CUDA) image.DriverCapabilities {
+	if !container.HasEnvvar(image.EnvVarNvidiaDriverCapabilities) {
+		return image.NewDriverCapabilities(string(image.DriverCapabilityAll))
 	}
-
-	imageRequirements, err := image.GetRequirements()
-	if err != nil {
-		//  TODO: Should we treat this as a failure, or just issue a warning?
-		return fmt.Errorf("failed to get image requirements: %v", err)
-	}
-
-	r := requirements.New(logger, imageRequirements)
-
-	cudaVersion, err := cuda.Version()
-	if err != nil {
-		logger.Warningf("Failed to get CUDA version: %v", err)
-	} else {
-		r.AddVersionProperty(requirements.CUDA, cudaVersion)
+	supported := image.SupportedDriverCapabilities
+	if cfg.SupportedDriverCapabilities != "" {
+		supported = image.NewDriverCapabilities(cfg.SupportedDriverCapabilities)
 	}
-
-	compteCapability, err := cuda.ComputeCapability(0)
-	if err != nil {
-		logger.Warningf("Failed to get CUDA Compute Capability: %v", err)
-	} else {
-		r.AddVersionProperty(requirements.ARCH, compteCapability)
-	}
-
-	return r.Assert()
+	return supported.Intersection(container.GetDriverCapabilities())
 }
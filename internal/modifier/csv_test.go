@@ -58,7 +58,7 @@ func TestNewCSVModifier(t *testing.T) {
 			image, _ := image.New(
 				image.WithEnvMap(tc.envmap),
 			)
-			m, err := NewCSVModifier(logger, tc.cfg, image)
+			m, err := NewCSVModifier(logger, tc.cfg, image, nil, "")
 			if tc.expectedError != nil {
 				require.Error(t, err)
 			} else {
@@ -73,3 +73,46 @@ func TestNewCSVModifier(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCSVDriverCapabilities(t *testing.T) {
+	testCases := []struct {
+		description  string
+		cfg          *config.Config
+		envmap       map[string]string
+		expectedList string
+	}{
+		{
+			description:  "capabilities not set includes all",
+			cfg:          &config.Config{},
+			expectedList: "all",
+		},
+		{
+			description:  "requested capabilities are selected",
+			cfg:          &config.Config{},
+			envmap:       map[string]string{"NVIDIA_DRIVER_CAPABILITIES": "compute,video"},
+			expectedList: "compute,video",
+		},
+		{
+			description:  "unsupported capabilities are removed",
+			cfg:          &config.Config{SupportedDriverCapabilities: "compute,utility"},
+			envmap:       map[string]string{"NVIDIA_DRIVER_CAPABILITIES": "compute,video"},
+			expectedList: "compute",
+		},
+		{
+			description:  "all selects supported capabilities",
+			cfg:          &config.Config{SupportedDriverCapabilities: "compute,utility"},
+			envmap:       map[string]string{"NVIDIA_DRIVER_CAPABILITIES": "all"},
+			expectedList: "compute,utility",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(image.WithEnvMap(tc.envmap))
+			require.NoError(t, err)
+
+			capabilities := getCSVDriverCapabilities(tc.cfg, container)
+			require.Equal(t, tc.expectedList, capabilities.String())
+		})
+	}
+}
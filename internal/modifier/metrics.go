@@ -0,0 +1,35 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import "github.com/NVIDIA/nvidia-container-toolkit/internal/metrics"
+
+var (
+	// RequirementCheckFailures counts the containers for which the
+	// NVIDIA_REQUIRE_* requirements were not met.
+	RequirementCheckFailures = metrics.NewCounter(
+		"nvidia_container_runtime_requirement_check_failures_total",
+		"The number of containers for which the NVIDIA_REQUIRE_* requirements were not met.",
+		"mode",
+	)
+	// AutomaticCDIGenerationSeconds tracks the time taken to generate CDI
+	// specs for runtime.nvidia.com/gpu devices.
+	AutomaticCDIGenerationSeconds = metrics.NewSummary(
+		"nvidia_container_runtime_automatic_cdi_generation_seconds",
+		"The time taken to generate the CDI spec for automatic CDI devices.",
+	)
+)
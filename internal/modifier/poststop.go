@@ -0,0 +1,44 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// NewPoststopHookModifier creates a modifier that adds the specified hooks to
+// the OCI spec. The hooks are added as poststop hooks and run in the runtime
+// namespace once the container has stopped, regardless of the lifecycle of the
+// specified hooks.
+func NewPoststopHookModifier(logger logger.Interface, hooks ...discover.Hook) (oci.SpecModifier, error) {
+	var poststopHooks []discover.Discover
+	for _, hook := range hooks {
+		hook.Lifecycle = cdi.PoststopHook
+		poststopHooks = append(poststopHooks, hook)
+	}
+	return NewModifierFromDiscoverer(logger, discover.Merge(poststopHooks...))
+}
+
+// NewCleanupHookModifier creates a modifier that adds a poststop hook which
+// removes the per-container state tracked for the container.
+func NewCleanupHookModifier(logger logger.Interface, nvidiaCDIHookPath string) (oci.SpecModifier, error) {
+	return NewPoststopHookModifier(logger, discover.CreateCleanupHook(nvidiaCDIHookPath))
+}
@@ -0,0 +1,118 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestPoststopHookModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description  string
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description: "cleanup hook is added as poststop hook",
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					Poststop: []specs.Hook{
+						{
+							Path: "/usr/bin/nvidia-cdi-hook",
+							Args: []string{"nvidia-cdi-hook", "cleanup"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "existing hooks are maintained",
+			spec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					Prestart: []specs.Hook{
+						{
+							Path: "/hook/a",
+							Args: []string{"/hook/a", "arga"},
+						},
+					},
+					Poststop: []specs.Hook{
+						{
+							Path: "/hook/b",
+							Args: []string{"/hook/b", "argb"},
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					Prestart: []specs.Hook{
+						{
+							Path: "/hook/a",
+							Args: []string{"/hook/a", "arga"},
+						},
+					},
+					Poststop: []specs.Hook{
+						{
+							Path: "/hook/b",
+							Args: []string{"/hook/b", "argb"},
+						},
+						{
+							Path: "/usr/bin/nvidia-cdi-hook",
+							Args: []string{"nvidia-cdi-hook", "cleanup"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			m, err := NewCleanupHookModifier(logger, "/usr/bin/nvidia-cdi-hook")
+			require.NoError(t, err)
+
+			require.NoError(t, m.Modify(tc.spec))
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestPoststopHookModifierSetsLifecycle(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	m, err := NewPoststopHookModifier(logger, discover.CreateNvidiaCDIHook("/usr/bin/nvidia-ctk", "some-hook"))
+	require.NoError(t, err)
+
+	spec := &specs.Spec{}
+	require.NoError(t, m.Modify(spec))
+	require.Empty(t, spec.Hooks.CreateContainer)
+	require.EqualValues(t, []specs.Hook{
+		{
+			Path: "/usr/bin/nvidia-ctk",
+			Args: []string{"nvidia-ctk", "hook", "some-hook"},
+		},
+	}, spec.Hooks.Poststop)
+}
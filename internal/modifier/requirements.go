@@ -0,0 +1,304 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerroot"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/cuda"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/cudacompat"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/deviceinfo"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/requirements"
+)
+
+// CheckRequirements checks the NVIDIA_REQUIRE_* requirements of the image
+// against the properties of the specified devices. The device properties are
+// read from the configured device-info file or queried using NVML. If neither
+// is available, the properties of the first CUDA device are used.
+//
+// If the container root includes CUDA Forward Compatibility libraries that are
+// newer than the host driver, these are also considered.
+func CheckRequirements(logger logger.Interface, cfg *config.Config, image image.CUDA, driver *root.Driver, containerRoot string, devices []string) error {
+	if cfg.DisableRequire || image.HasDisableRequire() {
+		// TODO: We could print the real value here instead
+		logger.Debugf("NVIDIA_DISABLE_REQUIRE=%v; skipping requirement checks", true)
+		return nil
+	}
+
+	imageRequirements, err := image.GetRequirements()
+	if err != nil {
+		//  TODO: Should we treat this as a failure, or just issue a warning?
+		return fmt.Errorf("failed to get image requirements: %v", err)
+	}
+	if len(imageRequirements) == 0 {
+		return nil
+	}
+
+	matrix, err := cudacompat.LoadMatrix(cfg.NVIDIAContainerRuntimeConfig.CUDACompatibilityMatrix)
+	if err != nil {
+		return err
+	}
+
+	hasDeviceConstraints := requirements.HasDeviceConstraints(imageRequirements)
+	info := getDeviceInfo(logger, cfg, driver, hasDeviceConstraints)
+
+	var hostDriverVersion string
+	if info != nil {
+		hostDriverVersion = info.DriverVersion
+	} else if hostDriverVersion, err = cudacompat.HostDriverVersion(driver); err != nil {
+		logger.Warningf("Failed to get driver version: %v", err)
+	}
+
+	var forwardCompatDriverVersion string
+	if hostDriverVersion != "" {
+		decision, err := cudacompat.Select(logger, containerroot.Root(containerRoot), nil, hostDriverVersion)
+		if err != nil {
+			logger.Warningf("Failed to detect CUDA forward compatibility libraries: %v", err)
+		} else if decision.Selected != nil {
+			forwardCompatDriverVersion = decision.Selected.Version
+		}
+	}
+
+	newRequirements := func() *requirements.Requirements {
+		r := requirements.New(logger, imageRequirements)
+		r.SetCompatibilityMatrix(matrix)
+		if v, err := cudacompat.ParseVersion(hostDriverVersion); err == nil {
+			// We normalize the version so that it is a valid semantic version
+			// (e.g. 535.54.03 -> 535.54.3).
+			r.AddVersionProperty(requirements.DRIVER, v.String())
+		}
+		r.AddForwardCompatLibraries(forwardCompatDriverVersion)
+		return r
+	}
+
+	if info == nil {
+		return checkCUDARequirements(logger, newRequirements, driver, devices, hasDeviceConstraints)
+	}
+	if info.CUDAVersion == "" {
+		logger.Warningf("CUDA version is unknown; skipping checks of the %v property", requirements.CUDA)
+	}
+
+	selected := info.Select(devices...)
+	if len(selected) == 0 {
+		r := newRequirements()
+		addCUDAVersion(r, info.CUDAVersion)
+		return r.Assert()
+	}
+
+	for _, d := range selected {
+		r := newRequirements()
+		addCUDAVersion(r, info.CUDAVersion)
+		r.AddVersionProperty(requirements.ARCH, d.ComputeCapability)
+		r.AddVersionProperty(requirements.COMPUTE_CAPABILITY, d.ComputeCapability)
+		r.AddStringProperty(requirements.BRAND, d.Brand)
+		r.AddSizeProperty(requirements.MEMORY, strconv.FormatUint(d.Memory, 10))
+		r.AddIntProperty(requirements.DEVICE_COUNT, strconv.Itoa(len(selected)))
+		r.AddBoolProperty(requirements.MIG, d.MIGEnabled)
+		r.AddBoolProperty(requirements.NVLINK, d.NVLink)
+		if err := r.Assert(); err != nil {
+			return fmt.Errorf("device %d (%v): %w", d.Index, d.UUID, err)
+		}
+	}
+	return nil
+}
+
+// getDeviceInfo returns the device info from the configured device-info file or
+// from NVML. Since initializing NVML is comparatively expensive, NVML is only
+// queried if the requirements include constraints on device properties. If the
+// device info cannot be determined, nil is returned.
+func getDeviceInfo(logger logger.Interface, cfg *config.Config, driver *root.Driver, queryNVML bool) *deviceinfo.Info {
+	if path := cfg.NVIDIAContainerRuntimeConfig.DeviceInfoPath; path != "" {
+		info, err := deviceinfo.Load(path)
+		if err == nil {
+			return info
+		}
+		logger.Warningf("Ignoring device-info file: %v", err)
+	}
+	if !queryNVML {
+		return nil
+	}
+
+	var nvmlOpts []nvml.LibraryOption
+	if driver != nil {
+		if candidates, err := driver.Libraries().Locate("libnvidia-ml.so.1"); err == nil {
+			nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+		}
+	}
+	info, err := deviceinfo.New(nvml.New(nvmlOpts...))
+	if err != nil {
+		logger.Debugf("Failed to query device info using NVML: %v", err)
+		return nil
+	}
+	return info
+}
+
+// checkCUDARequirements checks the requirements against the properties of the
+// requested devices as determined using the CUDA driver API. This is used if
+// the device info cannot be determined using NVML.
+//
+// The CUDA driver library is loaded from the driver root. If it cannot be
+// located there, or if the CUDA version cannot be determined, constraints on
+// the CUDA version are not checked.
+//
+// Only the requested devices are made visible to the CUDA driver API. If the
+// requested devices cannot be mapped to CUDA devices (e.g. for MIG devices), no
+// device properties are added. Since GPUs with MIG mode enabled are not
+// enumerated by the CUDA driver API and NVLink state cannot be queried, the
+// mig and nvlink properties default to false. If the requirements do not
+// include constraints on device properties, only the CUDA version is queried.
+func checkCUDARequirements(logger logger.Interface, newRequirements func() *requirements.Requirements, driver *root.Driver, devices []string, hasDeviceConstraints bool) error {
+	if err := setCUDALibraryPath(driver); err != nil {
+		logger.Warningf("Cannot use the CUDA driver API: %v; skipping checks of the %v property", err, requirements.CUDA)
+		r := newRequirements()
+		addCUDAVersion(r, "")
+		return r.Assert()
+	}
+
+	visibleDevices, ok := getCUDAVisibleDevices(devices)
+	if ok && visibleDevices != "all" {
+		if err := cuda.SetVisibleDevices(visibleDevices); err != nil {
+			logger.Warningf("Failed to set CUDA visible devices: %v", err)
+		}
+	}
+
+	cudaVersion, err := cuda.Version()
+	if err != nil {
+		logger.Warningf("Failed to get CUDA version: %v; skipping checks of the %v property", err, requirements.CUDA)
+	}
+
+	if !hasDeviceConstraints {
+		r := newRequirements()
+		addCUDAVersion(r, cudaVersion)
+		return r.Assert()
+	}
+	if !ok {
+		logger.Warningf("Cannot map requested devices %v to CUDA devices; skipping device properties", devices)
+		r := newRequirements()
+		addCUDAVersion(r, cudaVersion)
+		return r.Assert()
+	}
+
+	deviceCount, err := cuda.DeviceCount()
+	if err != nil {
+		logger.Warningf("Failed to get CUDA device count: %v", err)
+		r := newRequirements()
+		addCUDAVersion(r, cudaVersion)
+		return r.Assert()
+	}
+	logger.Debugf("Checking requirements against %d CUDA devices (%v)", deviceCount, visibleDevices)
+
+	if deviceCount == 0 {
+		r := newRequirements()
+		addCUDAVersion(r, cudaVersion)
+		r.AddIntProperty(requirements.DEVICE_COUNT, "0")
+		return r.Assert()
+	}
+
+	for index := 0; index < deviceCount; index++ {
+		r := newRequirements()
+		addCUDAVersion(r, cudaVersion)
+		addCUDAProperties(logger, r, index)
+		r.AddIntProperty(requirements.DEVICE_COUNT, strconv.Itoa(deviceCount))
+		r.AddBoolProperty(requirements.MIG, false)
+		r.AddBoolProperty(requirements.NVLINK, false)
+		if err := r.Assert(); err != nil {
+			return fmt.Errorf("CUDA device %d: %w", index, err)
+		}
+	}
+	return nil
+}
+
+// setCUDALibraryPath configures the CUDA driver API to use the CUDA driver
+// library from the specified driver root.
+func setCUDALibraryPath(driver *root.Driver) error {
+	if driver == nil {
+		return nil
+	}
+	candidates, err := driver.Libraries().Locate("libcuda.so.1")
+	if err != nil {
+		return fmt.Errorf("failed to locate libcuda.so.1 in driver root %v: %w", driver.Root, err)
+	}
+	cuda.SetLibraryPath(candidates[0])
+	return nil
+}
+
+// addCUDAVersion adds the specified CUDA version to the requirements. If the
+// CUDA version is unknown, constraints on the CUDA version cannot be checked
+// and are ignored.
+func addCUDAVersion(r *requirements.Requirements, cudaVersion string) {
+	if cudaVersion == "" {
+		r.IgnoreProperty(requirements.CUDA)
+		return
+	}
+	r.AddVersionProperty(requirements.CUDA, cudaVersion)
+}
+
+// getCUDAVisibleDevices returns the value of CUDA_VISIBLE_DEVICES that makes
+// only the requested devices visible to the CUDA driver API. Device indices and
+// UUIDs are supported, as are fully-qualified CDI device names for GPUs. If all
+// devices are requested, "all" is returned. The second return value is false if
+// a requested device cannot be mapped to a CUDA device.
+func getCUDAVisibleDevices(devices []string) (string, bool) {
+	var visible []string
+	for _, device := range devices {
+		id := device
+		if kind, name, found := strings.Cut(device, "="); found {
+			if !strings.HasSuffix(kind, "/gpu") {
+				return "", false
+			}
+			id = name
+		}
+		switch {
+		case id == "all":
+			return "all", true
+		case id == "none" || id == "void" || id == "":
+			continue
+		case strings.Contains(id, ":") || strings.HasPrefix(id, "MIG-"):
+			return "", false
+		}
+		visible = append(visible, id)
+	}
+	return strings.Join(visible, ","), true
+}
+
+// addCUDAProperties adds the properties of the CUDA device with the specified
+// index as determined using the CUDA driver API.
+func addCUDAProperties(logger logger.Interface, r *requirements.Requirements, index int) {
+	computeCapability, err := cuda.ComputeCapability(index)
+	if err != nil {
+		logger.Warningf("Failed to get CUDA Compute Capability: %v", err)
+	} else {
+		r.AddVersionProperty(requirements.ARCH, computeCapability)
+		r.AddVersionProperty(requirements.COMPUTE_CAPABILITY, computeCapability)
+	}
+
+	totalMemory, err := cuda.TotalMemory(index)
+	if err != nil {
+		logger.Warningf("Failed to get CUDA device memory: %v", err)
+	} else {
+		r.AddSizeProperty(requirements.MEMORY, strconv.FormatUint(totalMemory, 10))
+	}
+}
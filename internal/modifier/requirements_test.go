@@ -0,0 +1,270 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/deviceinfo"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestCheckRequirements(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	info := &deviceinfo.Info{
+		DriverVersion: "535.54.03",
+		CUDAVersion:   "12.2",
+		Devices: []deviceinfo.Device{
+			{Index: 0, UUID: "GPU-0", Brand: "tesla", ComputeCapability: "8.0", Memory: 80 << 30},
+			{Index: 1, UUID: "GPU-1", Brand: "geforce", ComputeCapability: "7.5", Memory: 8 << 30},
+		},
+	}
+	deviceInfoPath := filepath.Join(t.TempDir(), "device-info.json")
+	require.NoError(t, info.Save(deviceInfoPath))
+
+	testCases := []struct {
+		description        string
+		disableRequire     bool
+		env                map[string]string
+		devices            []string
+		compatLibraries    []string
+		expectedError      bool
+		expectedErrorMatch string
+	}{
+		{
+			description: "no requirements",
+			devices:     []string{"all"},
+		},
+		{
+			description: "cuda requirement met",
+			env:         map[string]string{"NVIDIA_REQUIRE_CUDA": "cuda>=12.2"},
+			devices:     []string{"nvidia.com/gpu=all"},
+		},
+		{
+			description:        "cuda requirement not met",
+			env:                map[string]string{"NVIDIA_REQUIRE_CUDA": "cuda>=12.4"},
+			devices:            []string{"nvidia.com/gpu=all"},
+			expectedError:      true,
+			expectedErrorMatch: "CUDA 12.4 requires driver >= 550.54.14",
+		},
+		{
+			description:     "cuda requirement met by forward compat libraries",
+			env:             map[string]string{"NVIDIA_REQUIRE_CUDA": "cuda>=12.4"},
+			devices:         []string{"nvidia.com/gpu=all"},
+			compatLibraries: []string{"libcuda.so.550.54.15"},
+		},
+		{
+			description:    "disable-require config option",
+			disableRequire: true,
+			env:            map[string]string{"NVIDIA_REQUIRE_CUDA": "cuda>=12.4"},
+			devices:        []string{"all"},
+		},
+		{
+			description: "NVIDIA_DISABLE_REQUIRE",
+			env: map[string]string{
+				"NVIDIA_REQUIRE_CUDA":    "cuda>=12.4",
+				"NVIDIA_DISABLE_REQUIRE": "true",
+			},
+			devices: []string{"all"},
+		},
+		{
+			description: "arch requirement met by selected device",
+			env:         map[string]string{"NVIDIA_REQUIRE_ARCH": "arch>=8.0"},
+			devices:     []string{"nvidia.com/gpu=GPU-0"},
+		},
+		{
+			description:        "arch requirement checked for all requested devices",
+			env:                map[string]string{"NVIDIA_REQUIRE_ARCH": "arch>=8.0"},
+			devices:            []string{"0", "1"},
+			expectedError:      true,
+			expectedErrorMatch: "device 1 (GPU-1)",
+		},
+		{
+			description: "expression with device properties",
+			env:         map[string]string{"NVIDIA_REQUIRE_GPU": "(arch>=8.0,memory>=24G) | brand=geforce"},
+			devices:     []string{"all"},
+		},
+		{
+			description: "negated mig requirement",
+			env:         map[string]string{"NVIDIA_REQUIRE_MIG": "!mig=true"},
+			devices:     []string{"all"},
+		},
+		{
+			description:   "device count",
+			env:           map[string]string{"NVIDIA_REQUIRE_GPUS": "device_count>=2"},
+			devices:       []string{"1"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			containerRoot := t.TempDir()
+			if len(tc.compatLibraries) > 0 {
+				require.NoError(t, os.MkdirAll(filepath.Join(containerRoot, "etc"), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(containerRoot, "etc/ld.so.cache"), nil, 0600))
+				compatDir := filepath.Join(containerRoot, "usr/local/cuda/compat")
+				require.NoError(t, os.MkdirAll(compatDir, 0755))
+				for _, lib := range tc.compatLibraries {
+					require.NoError(t, os.WriteFile(filepath.Join(compatDir, lib), nil, 0600))
+				}
+			}
+
+			cfg := &config.Config{
+				DisableRequire: tc.disableRequire,
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					DeviceInfoPath: deviceInfoPath,
+				},
+			}
+			container, err := image.New(image.WithEnvMap(tc.env))
+			require.NoError(t, err)
+			driver := root.New(root.WithDriverRoot(t.TempDir()))
+
+			err = CheckRequirements(logger, cfg, container, driver, containerRoot, tc.devices)
+			if !tc.expectedError {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expectedErrorMatch)
+		})
+	}
+}
+
+func TestCheckRequirementsWithDriverRoot(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		libraries     []string
+		requirements  map[string]string
+		expectedError bool
+	}{
+		{
+			description:  "cuda requirement is skipped if libcuda is not in the driver root",
+			requirements: map[string]string{"NVIDIA_REQUIRE_CUDA": "cuda>=12.0"},
+		},
+		{
+			description:  "negated cuda requirement is skipped if libcuda is not in the driver root",
+			requirements: map[string]string{"NVIDIA_REQUIRE_CUDA": "!cuda>=12.0"},
+		},
+		{
+			description:  "cuda requirement is skipped if the CUDA version cannot be determined",
+			libraries:    []string{"usr/lib64/libcuda.so.1"},
+			requirements: map[string]string{"NVIDIA_REQUIRE_CUDA": "cuda>=12.0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			for _, library := range tc.libraries {
+				require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, filepath.Dir(library)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(driverRoot, library), nil, 0600))
+			}
+
+			container, err := image.New(image.WithEnvMap(tc.requirements))
+			require.NoError(t, err)
+			driver := root.New(root.WithDriverRoot(driverRoot))
+
+			err = CheckRequirements(logger, &config.Config{}, container, driver, t.TempDir(), []string{"all"})
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetCUDALibraryPath(t *testing.T) {
+	driverRoot := t.TempDir()
+	driver := root.New(root.WithDriverRoot(driverRoot))
+
+	require.Error(t, setCUDALibraryPath(driver))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "usr/lib64"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "usr/lib64/libcuda.so.1"), nil, 0600))
+	require.NoError(t, setCUDALibraryPath(driver))
+}
+
+func TestGetCUDAVisibleDevices(t *testing.T) {
+	testCases := []struct {
+		description     string
+		devices         []string
+		expectedDevices string
+		expectedOK      bool
+	}{
+		{
+			description:     "all devices",
+			devices:         []string{"all"},
+			expectedDevices: "all",
+			expectedOK:      true,
+		},
+		{
+			description:     "indices and UUIDs",
+			devices:         []string{"0", "GPU-1"},
+			expectedDevices: "0,GPU-1",
+			expectedOK:      true,
+		},
+		{
+			description:     "CDI device names",
+			devices:         []string{"nvidia.com/gpu=1", "nvidia.com/gpu=GPU-2"},
+			expectedDevices: "1,GPU-2",
+			expectedOK:      true,
+		},
+		{
+			description:     "all CDI devices",
+			devices:         []string{"nvidia.com/gpu=all"},
+			expectedDevices: "all",
+			expectedOK:      true,
+		},
+		{
+			description: "no devices",
+			devices:     []string{"none"},
+			expectedOK:  true,
+		},
+		{
+			description: "MIG device",
+			devices:     []string{"0:1"},
+		},
+		{
+			description: "MIG device UUID",
+			devices:     []string{"MIG-0123"},
+		},
+		{
+			description: "non-GPU CDI device",
+			devices:     []string{"nvidia.com/imex-channel=0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devices, ok := getCUDAVisibleDevices(tc.devices)
+			require.Equal(t, tc.expectedOK, ok)
+			require.Equal(t, tc.expectedDevices, devices)
+		})
+	}
+}
@@ -26,11 +26,17 @@ import (
 )
 
 // NewStableRuntimeModifier creates an OCI spec modifier that inserts the NVIDIA Container Runtime Hook into an OCI
-// spec. The specified logger is used to capture log output.
-func NewStableRuntimeModifier(logger logger.Interface, nvidiaContainerRuntimeHookPath string) oci.SpecModifier {
+// spec. The specified logger is used to capture log output. If a driver root is
+// specified, it is passed to the hook so that a driver root that was
+// auto-discovered by the runtime does not have to be discovered again. If a
+// profile is specified, it is passed to the hook so that the hook applies the
+// same profile as the runtime.
+func NewStableRuntimeModifier(logger logger.Interface, nvidiaContainerRuntimeHookPath string, driverRoot string, profile string) oci.SpecModifier {
 	m := stableRuntimeModifier{
 		logger:                         logger,
 		nvidiaContainerRuntimeHookPath: nvidiaContainerRuntimeHookPath,
+		driverRoot:                     driverRoot,
+		profile:                        profile,
 	}
 
 	return &m
@@ -41,6 +47,8 @@ func NewStableRuntimeModifier(logger logger.Interface, nvidiaContainerRuntimeHoo
 type stableRuntimeModifier struct {
 	logger                         logger.Interface
 	nvidiaContainerRuntimeHookPath string
+	driverRoot                     string
+	profile                        string
 }
 
 // Modify applies the required modification to the incoming OCI spec, inserting the nvidia-container-runtime-hook
@@ -60,6 +68,12 @@ func (m stableRuntimeModifier) Modify(spec *specs.Spec) error {
 	path := m.nvidiaContainerRuntimeHookPath
 	m.logger.Infof("Using prestart hook path: %v", path)
 	args := []string{filepath.Base(path)}
+	if m.driverRoot != "" {
+		args = append(args, "-driver-root="+m.driverRoot)
+	}
+	if m.profile != "" {
+		args = append(args, "-profile="+m.profile)
+	}
 	if spec.Hooks == nil {
 		spec.Hooks = &specs.Hooks{}
 	}
@@ -68,6 +68,8 @@ func TestAddHookModifier(t *testing.T) {
 
 	testCases := []struct {
 		description   string
+		driverRoot    string
+		profile       string
 		spec          specs.Spec
 		expectedError error
 		expectedSpec  specs.Spec
@@ -102,6 +104,37 @@ func TestAddHookModifier(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "driver root is passed to hook",
+			driverRoot:  "/run/nvidia/driver",
+			spec:        specs.Spec{},
+			expectedSpec: specs.Spec{
+				Hooks: &specs.Hooks{
+					Prestart: []specs.Hook{
+						{
+							Path: testHookPath,
+							Args: []string{"nvidia-container-runtime-hook", "-driver-root=/run/nvidia/driver", "prestart"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "profile is passed to hook",
+			driverRoot:  "/run/nvidia/driver",
+			profile:     "restricted",
+			spec:        specs.Spec{},
+			expectedSpec: specs.Spec{
+				Hooks: &specs.Hooks{
+					Prestart: []specs.Hook{
+						{
+							Path: testHookPath,
+							Args: []string{"nvidia-container-runtime-hook", "-driver-root=/run/nvidia/driver", "-profile=restricted", "prestart"},
+						},
+					},
+				},
+			},
+		},
 		{
 			description: "hook is not replaced",
 			spec: specs.Spec{
@@ -157,7 +190,7 @@ func TestAddHookModifier(t *testing.T) {
 
 		t.Run(tc.description, func(t *testing.T) {
 
-			m := NewStableRuntimeModifier(logger, testHookPath)
+			m := NewStableRuntimeModifier(logger, testHookPath, tc.driverRoot, tc.profile)
 
 			err := m.Modify(&tc.spec)
 			if tc.expectedError != nil {
@@ -0,0 +1,97 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
+)
+
+// traceContext is a spec modifier that propagates the trace context of the
+// NVIDIA Container Runtime to the NVIDIA hooks in the spec.
+type traceContext string
+
+var _ oci.SpecModifier = (*traceContext)(nil)
+
+// NewTraceContextModifier creates a modifier that sets the TRACEPARENT
+// environment variable for the NVIDIA hooks in the spec to the specified
+// W3C traceparent. Hooks that do not specify an environment are given the
+// environment that they would otherwise inherit. This allows the spans recorded by the hooks to be
+// correlated with the spans recorded by the runtime.
+func NewTraceContextModifier(traceparent string) oci.SpecModifier {
+	return traceContext(traceparent)
+}
+
+// Modify sets the TRACEPARENT environment variable for the NVIDIA hooks.
+func (m traceContext) Modify(spec *specs.Spec) error {
+	if spec == nil || spec.Hooks == nil {
+		return nil
+	}
+	for _, hooks := range [][]specs.Hook{
+		spec.Hooks.Prestart,
+		spec.Hooks.CreateRuntime,
+		spec.Hooks.CreateContainer,
+		spec.Hooks.StartContainer,
+		spec.Hooks.Poststart,
+		spec.Hooks.Poststop,
+	} {
+		for i := range hooks {
+			if !isNVIDIAHook(&hooks[i]) {
+				continue
+			}
+			env := hooks[i].Env
+			// A nil environment means that the hook inherits the environment
+			// of the low-level runtime, which is the environment of the
+			// current process. This is made explicit so that setting the
+			// trace context does not remove variables such as PATH.
+			if env == nil {
+				env = os.Environ()
+			}
+			hooks[i].Env = setEnv(env, tracing.EnvVar, string(m))
+		}
+	}
+	return nil
+}
+
+// isNVIDIAHook checks whether the specified hook is implemented by the
+// NVIDIA Container Toolkit.
+func isNVIDIAHook(hook *specs.Hook) bool {
+	switch filepath.Base(hook.Path) {
+	case "nvidia-cdi-hook", "nvidia-ctk":
+		return true
+	}
+	return isNVIDIAContainerRuntimeHook(hook)
+}
+
+// setEnv sets the value of the specified environment variable, replacing any
+// existing value.
+func setEnv(env []string, key string, value string) []string {
+	var updated []string
+	for _, e := range env {
+		if strings.HasPrefix(e, key+"=") {
+			continue
+		}
+		updated = append(updated, e)
+	}
+	return append(updated, key+"="+value)
+}
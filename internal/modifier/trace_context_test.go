@@ -0,0 +1,54 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceContextModifier(t *testing.T) {
+	const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	t.Setenv("PATH", "/usr/local/bin:/usr/bin")
+	t.Setenv("XDG_CONFIG_HOME", "/etc/xdg")
+	t.Setenv("TRACEPARENT", "inherited")
+
+	spec := &specs.Spec{
+		Hooks: &specs.Hooks{
+			Prestart: []specs.Hook{
+				{Path: "/usr/bin/nvidia-container-runtime-hook", Args: []string{"nvidia-container-runtime-hook", "prestart"}},
+			},
+			CreateContainer: []specs.Hook{
+				{Path: "/usr/bin/nvidia-cdi-hook", Env: []string{"TRACEPARENT=old", "FOO=bar"}},
+				{Path: "/usr/bin/other-hook", Env: []string{"FOO=bar"}},
+			},
+		},
+	}
+
+	require.NoError(t, NewTraceContextModifier(traceparent).Modify(spec))
+
+	// The hook without an environment keeps the inherited environment.
+	prestartEnv := spec.Hooks.Prestart[0].Env
+	require.Contains(t, prestartEnv, "PATH=/usr/local/bin:/usr/bin")
+	require.Contains(t, prestartEnv, "XDG_CONFIG_HOME=/etc/xdg")
+	require.Contains(t, prestartEnv, "TRACEPARENT="+traceparent)
+	require.NotContains(t, prestartEnv, "TRACEPARENT=inherited")
+	require.Equal(t, []string{"FOO=bar", "TRACEPARENT=" + traceparent}, spec.Hooks.CreateContainer[0].Env)
+	require.Equal(t, []string{"FOO=bar"}, spec.Hooks.CreateContainer[1].Env)
+}
@@ -36,6 +36,15 @@ const (
 	nvcapsDevicePath     = "/dev/nvidia-caps"
 )
 
+const (
+	// MigConfigCap is the global capability required to create and destroy
+	// MIG GPU and compute instances.
+	MigConfigCap = MigCap("config")
+	// MigMonitorCap is the global capability required to monitor MIG GPU and
+	// compute instances.
+	MigMonitorCap = MigCap("monitor")
+)
+
 // MigMinor represents the minor number of a MIG device
 type MigMinor int
 
@@ -159,6 +168,12 @@ func (m MigCap) ProcPath() string {
 	return filepath.Join(nvidiaCapabilitiesPath, path)
 }
 
+// GPUMigProcPath returns the proc path containing the MIG capabilities of the
+// GPU with the specified minor number.
+func GPUMigProcPath(gpu int) string {
+	return filepath.Join(nvidiaCapabilitiesPath, fmt.Sprintf("gpu%d", gpu), "mig")
+}
+
 // DevicePath returns the path for the nvidia-caps device with the specified
 // minor number
 func (m MigMinor) DevicePath() string {
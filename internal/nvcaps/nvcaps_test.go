@@ -98,3 +98,7 @@ func TestMigMinorDevicePath(t *testing.T) {
 	m := MigMinor(0)
 	require.Equal(t, "/dev/nvidia-caps/nvidia-cap0", m.DevicePath())
 }
+
+func TestGPUMigProcPath(t *testing.T) {
+	require.Equal(t, "/proc/driver/nvidia/capabilities/gpu3/mig", GPUMigProcPath(3))
+}
@@ -19,6 +19,7 @@ package oci
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -113,3 +114,180 @@ func HasCreateSubcommand(args []string) bool {
 
 	return false
 }
+
+// globalFlagsWithValues are the global flags of low-level runtimes such as
+// runc and crun that accept a value as a separate argument.
+var globalFlagsWithValues = map[string]bool{
+	"log":        true,
+	"log-format": true,
+	"root":       true,
+	"criu":       true,
+	"rootless":   true,
+}
+
+// GetSubcommand returns the subcommand of a low-level runtime specified in the
+// supplied command line arguments. The first argument is the name of the
+// executable and the subcommand is the first argument that is not a global
+// flag or the value of a global flag. An empty string is returned if no
+// subcommand is specified.
+func GetSubcommand(args []string) string {
+	i := subcommandIndex(args)
+	if i < 0 {
+		return ""
+	}
+	return args[i]
+}
+
+// subcommandIndex returns the index of the subcommand in the supplied command
+// line arguments or -1 if no subcommand is specified.
+func subcommandIndex(args []string) int {
+	var previousTakesValue bool
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if previousTakesValue {
+			previousTakesValue = false
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return i
+		}
+		previousTakesValue = IsGlobalFlagWithValue(arg)
+	}
+	return -1
+}
+
+// IsGlobalFlagWithValue checks whether the specified argument is a global flag
+// of a low-level runtime whose value is specified as the next argument.
+func IsGlobalFlagWithValue(arg string) bool {
+	if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+		return false
+	}
+	return globalFlagsWithValues[strings.TrimLeft(arg, "-")]
+}
+
+// subcommandFlagsWithValues are the flags of low-level runtime subcommands
+// such as create, run, exec, and update that accept a value as a separate
+// argument.
+var subcommandFlagsWithValues = map[string]bool{
+	"bundle":              true,
+	"b":                   true,
+	"console-socket":      true,
+	"pid-file":            true,
+	"preserve-fds":        true,
+	"process":             true,
+	"p":                   true,
+	"cwd":                 true,
+	"env":                 true,
+	"e":                   true,
+	"user":                true,
+	"u":                   true,
+	"additional-gids":     true,
+	"g":                   true,
+	"apparmor":            true,
+	"cap":                 true,
+	"c":                   true,
+	"process-label":       true,
+	"format":              true,
+	"f":                   true,
+	"interval":            true,
+	"resources":           true,
+	"r":                   true,
+	"blkio-weight":        true,
+	"cpu-period":          true,
+	"cpu-quota":           true,
+	"cpu-share":           true,
+	"cpu-rt-period":       true,
+	"cpu-rt-runtime":      true,
+	"cpuset-cpus":         true,
+	"cpuset-mems":         true,
+	"kernel-memory":       true,
+	"kernel-memory-tcp":   true,
+	"memory":              true,
+	"memory-reservation":  true,
+	"memory-swap":         true,
+	"pids-limit":          true,
+	"l3-cache-schema":     true,
+	"mem-bw-schema":       true,
+	"image-path":          true,
+	"work-path":           true,
+	"parent-path":         true,
+	"page-server":         true,
+	"manage-cgroups-mode": true,
+	"empty-ns":            true,
+	"status-fd":           true,
+	"lsm-profile":         true,
+	"lsm-mount-context":   true,
+}
+
+// GetContainerID returns the container ID specified in the supplied command
+// line arguments. This is the first positional argument following the
+// subcommand. An empty string is returned if no container ID is specified.
+func GetContainerID(args []string) string {
+	i := subcommandIndex(args)
+	if i < 0 {
+		return ""
+	}
+
+	var previousTakesValue bool
+	for _, arg := range args[i+1:] {
+		if previousTakesValue {
+			previousTakesValue = false
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+		if !strings.Contains(arg, "=") {
+			previousTakesValue = subcommandFlagsWithValues[strings.TrimLeft(arg, "-")]
+		}
+	}
+	return ""
+}
+
+// GetPreserveFDs returns the number of additional file descriptors that are
+// passed to the container as specified by the preserve-fds flag of the
+// subcommand in the supplied command line arguments. The following are
+// supported:
+// --preserve-fds{{SEP}}N
+// -preserve-fds{{SEP}}N
+// where {{SEP}} is either ' ' or '='
+func GetPreserveFDs(args []string) (int, error) {
+	i := subcommandIndex(args)
+	if i < 0 {
+		return 0, nil
+	}
+
+	var preserveFDs int
+	for j := i + 1; j < len(args); j++ {
+		arg := args[j]
+		if !strings.HasPrefix(arg, "-") {
+			// This is the container ID.
+			break
+		}
+		parts := strings.SplitN(arg, "=", 2)
+		name := strings.TrimLeft(parts[0], "-")
+		if name != "preserve-fds" {
+			if len(parts) == 1 && subcommandFlagsWithValues[name] {
+				j++
+			}
+			continue
+		}
+
+		value := ""
+		switch {
+		case len(parts) == 2:
+			value = parts[1]
+		case j+1 < len(args):
+			value = args[j+1]
+			j++
+		default:
+			return 0, fmt.Errorf("preserve-fds option requires an argument")
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid preserve-fds value %q", value)
+		}
+		preserveFDs = n
+	}
+	return preserveFDs, nil
+}
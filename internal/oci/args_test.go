@@ -182,3 +182,138 @@ func TestHasCreateSubcommand(t *testing.T) {
 		require.Equal(t, tc.shouldModify, HasCreateSubcommand(tc.args), "%d: %v", i, tc)
 	}
 }
+
+func TestGetSubcommand(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{
+			args: []string{"runtime"},
+		},
+		{
+			args:     []string{"runtime", "features"},
+			expected: "features",
+		},
+		{
+			args:     []string{"runtime", "--root", "/run/runc", "--debug", "delete", "ctr"},
+			expected: "delete",
+		},
+		{
+			args:     []string{"runtime", "--log=/var/log/runc.log", "features"},
+			expected: "features",
+		},
+		{
+			args:     []string{"runtime", "--root", "features", "list"},
+			expected: "list",
+		},
+		{
+			args: []string{"runtime", "--version"},
+		},
+	}
+
+	for i, tc := range testCases {
+		require.Equal(t, tc.expected, GetSubcommand(tc.args), "%d: %v", i, tc)
+	}
+}
+
+func TestGetContainerID(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{
+			args: []string{"runtime"},
+		},
+		{
+			args: []string{"runtime", "create"},
+		},
+		{
+			args:     []string{"runtime", "create", "ctr"},
+			expected: "ctr",
+		},
+		{
+			args:     []string{"runtime", "--root", "/run/runc", "create", "--bundle", "/bundle", "ctr"},
+			expected: "ctr",
+		},
+		{
+			args:     []string{"runtime", "create", "--bundle=/bundle", "--pid-file", "/run/ctr.pid", "ctr"},
+			expected: "ctr",
+		},
+		{
+			args:     []string{"runtime", "create", "-b", "create", "ctr", "--no-pivot"},
+			expected: "ctr",
+		},
+		{
+			args:     []string{"runtime", "delete", "--force", "ctr"},
+			expected: "ctr",
+		},
+		{
+			args:     []string{"runtime", "update", "--memory", "1G", "-r", "/resources.json", "ctr"},
+			expected: "ctr",
+		},
+		{
+			args:     []string{"runtime", "ps", "--format", "json", "ctr"},
+			expected: "ctr",
+		},
+		{
+			args: []string{"runtime", "list", "--format", "json"},
+		},
+	}
+
+	for i, tc := range testCases {
+		require.Equal(t, tc.expected, GetContainerID(tc.args), "%d: %v", i, tc)
+	}
+}
+
+func TestGetPreserveFDs(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		expected      int
+		expectedError bool
+	}{
+		{
+			args: []string{"runtime"},
+		},
+		{
+			args: []string{"runtime", "create", "--bundle", "/bundle", "ctr"},
+		},
+		{
+			args:     []string{"runtime", "--root", "/run/runc", "create", "--preserve-fds", "2", "--bundle", "/bundle", "ctr"},
+			expected: 2,
+		},
+		{
+			args:     []string{"runtime", "create", "--bundle=/bundle", "-preserve-fds=1", "ctr"},
+			expected: 1,
+		},
+		{
+			args:     []string{"runtime", "exec", "--cwd", "--preserve-fds", "--preserve-fds", "3", "ctr"},
+			expected: 3,
+		},
+		{
+			args: []string{"runtime", "create", "ctr", "--preserve-fds", "2"},
+		},
+		{
+			args:          []string{"runtime", "create", "--preserve-fds"},
+			expectedError: true,
+		},
+		{
+			args:          []string{"runtime", "create", "--preserve-fds=-1", "ctr"},
+			expectedError: true,
+		},
+		{
+			args:          []string{"runtime", "create", "--preserve-fds", "many", "ctr"},
+			expectedError: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		preserveFDs, err := GetPreserveFDs(tc.args)
+		if tc.expectedError {
+			require.Error(t, err, "%d: %v", i, tc)
+			continue
+		}
+		require.NoError(t, err, "%d: %v", i, tc)
+		require.Equal(t, tc.expected, preserveFDs, "%d: %v", i, tc)
+	}
+}
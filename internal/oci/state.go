@@ -80,14 +80,20 @@ func (s *State) GetContainerRoot() (string, error) {
 		return "", err
 	}
 
+	return GetContainerRoot(s.Bundle, spec), nil
+}
+
+// GetContainerRoot returns the root for the container defined by the specified
+// spec. A relative root path is interpreted as relative to the bundle directory.
+func GetContainerRoot(bundleDir string, spec *specs.Spec) string {
 	var containerRoot string
 	if spec.Root != nil {
 		containerRoot = spec.Root.Path
 	}
 
 	if filepath.IsAbs(containerRoot) {
-		return containerRoot, nil
+		return containerRoot
 	}
 
-	return filepath.Join(s.Bundle, containerRoot), nil
+	return filepath.Join(bundleDir, containerRoot)
 }
@@ -0,0 +1,92 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package dgpu
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+)
+
+// NewForMigCapability creates a discoverer for the specified global MIG
+// capability (config or monitor) on the specified device.
+// In addition to the device node for the capability, this includes the device
+// node for the GPU and the procfs files that MIG management tools use to check
+// the capability. For the config capability, the MIG capabilities of the GPU
+// are also included so that created GPU and compute instances can be accessed.
+func NewForMigCapability(d device.Device, migCap nvcaps.MigCap, opts ...Option) (discover.Discover, error) {
+	o := new(opts...)
+	return o.newNvmlMigCapabilityDiscoverer(&toRequiredInfo{d}, migCap)
+}
+
+func (o *options) newNvmlMigCapabilityDiscoverer(d requiredInfo, migCap nvcaps.MigCap) (discover.Discover, error) {
+	if o.migCaps == nil || o.migCapsError != nil {
+		return nil, fmt.Errorf("error getting MIG capability device paths: %v", o.migCapsError)
+	}
+
+	capDevicePath, err := o.migCaps.GetCapDevicePath(migCap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %v cap device path: %v", migCap, err)
+	}
+
+	gpu, err := d.GetMinorNumber()
+	if err != nil {
+		return nil, fmt.Errorf("error getting GPU minor: %w", err)
+	}
+
+	parentPath, err := d.getDevNodePath()
+	if err != nil {
+		return nil, err
+	}
+
+	deviceNodes := discover.NewCharDeviceDiscoverer(
+		o.logger,
+		o.devRoot,
+		[]string{
+			parentPath,
+			capDevicePath,
+		},
+	)
+
+	procFiles := discover.NewMounts(
+		o.logger,
+		lookup.NewFileLocator(lookup.WithLogger(o.logger), lookup.WithRoot(o.procRoot)),
+		o.procRoot,
+		[]string{migCap.ProcPath()},
+	)
+
+	var procDirs []string
+	if migCap == nvcaps.MigConfigCap {
+		procDirs = append(procDirs, nvcaps.GPUMigProcPath(gpu))
+	}
+	procDirectories := discover.NewMounts(
+		o.logger,
+		lookup.NewDirectoryLocator(lookup.WithLogger(o.logger), lookup.WithRoot(o.procRoot)),
+		o.procRoot,
+		procDirs,
+	)
+
+	return discover.Merge(
+		deviceNodes,
+		procFiles,
+		procDirectories,
+	), nil
+}
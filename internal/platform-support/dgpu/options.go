@@ -25,6 +25,7 @@ import (
 type options struct {
 	logger            logger.Interface
 	devRoot           string
+	procRoot          string
 	nvidiaCDIHookPath string
 
 	isMigDevice bool
@@ -45,6 +46,13 @@ func WithDevRoot(root string) Option {
 	}
 }
 
+// WithProcRoot sets the root where /proc is located.
+func WithProcRoot(root string) Option {
+	return func(l *options) {
+		l.procRoot = root
+	}
+}
+
 // WithLogger sets the logger for the library
 func WithLogger(logger logger.Interface) Option {
 	return func(l *options) {
@@ -0,0 +1,191 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tegra
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+)
+
+// l4tCapabilities maps the entries of the l4t.csv files shipped with Jetson
+// Linux to the driver capabilities that they are required for. The patterns
+// are matched against the filename of the path in the mount spec. Entries that
+// do not match any pattern (e.g. the core nvrm and nvos libraries) are required
+// for all capabilities.
+var l4tCapabilities = []struct {
+	capability image.DriverCapability
+	patterns   []string
+}{
+	{
+		capability: image.DriverCapabilityCompute,
+		patterns: []string{
+			"libcuda.so*",
+			"libcudla.so*",
+			"libnvcudla.so*",
+			"libnvdla_runtime.so*",
+			"libnvdla_compiler.so*",
+			"libnvidia-ptxjitcompiler.so*",
+			"libnvidia-nvvm.so*",
+			"libcupva*.so*",
+			"libnvpva*.so*",
+		},
+	},
+	{
+		capability: image.DriverCapabilityGraphics,
+		patterns: []string{
+			"libEGL_nvidia.so*",
+			"libGLESv1_CM_nvidia.so*",
+			"libGLESv2_nvidia.so*",
+			"libGLX_nvidia.so*",
+			"libnvidia-eglcore.so*",
+			"libnvidia-glcore.so*",
+			"libnvidia-glsi.so*",
+			"libnvidia-glvkspirv.so*",
+			"libnvidia-tls.so*",
+			"libnvidia-vulkan-producer.so*",
+			"libnvidia-egl-wayland.so*",
+			"libnvidia-egl-gbm.so*",
+			"libnvgbm.so*",
+			"nvidia-drm_gbm.so",
+			"tegra_gbm.so",
+			"nvidia_icd.json",
+			"nvidia_layers.json",
+			"10_nvidia.json",
+			"10_nvidia_wayland.json",
+			"15_nvidia_gbm.json",
+		},
+	},
+	{
+		capability: image.DriverCapabilityVideo,
+		patterns: []string{
+			"libnvmedia*.so*",
+			"libnvv4l2.so*",
+			"libv4l2_nv*.so*",
+			"libtegrav4l2.so*",
+			"libnvvideo*.so*",
+			"libnvmm*.so*",
+			"libnvdec*.so*",
+			"libnvenc*.so*",
+			"libnvjpeg.so*",
+			"libnvbuf*.so*",
+			"libnvparser.so*",
+			"libnvtvmr.so*",
+			"libnvosd.so*",
+			"libnvvic.so*",
+			"libgstnv*.so*",
+			"libnvargus*.so*",
+			"libnvscf.so*",
+			"libnvcam*.so*",
+			"libnvisp*.so*",
+			"libnvodm_imager.so*",
+			"nvargus-daemon",
+		},
+	},
+	{
+		capability: image.DriverCapabilityDisplay,
+		patterns: []string{
+			"libnvdc.so*",
+			"libnvimp.so*",
+			"libdrm_nvdc.so*",
+		},
+	},
+	{
+		capability: image.DriverCapabilityUtility,
+		patterns: []string{
+			"libnvidia-ml.so*",
+			"nvidia-smi",
+			"tegrastats",
+		},
+	},
+}
+
+// isL4TFile checks whether the specified file is one of the l4t.csv files
+// shipped with Jetson Linux. The built-in capability mapping is only applied to
+// these files.
+func isL4TFile(filename string) bool {
+	return filepath.Base(filename) == "l4t.csv"
+}
+
+// addL4TCapabilities tags the mount specs from an l4t.csv file with the driver
+// capabilities from the built-in mapping. Mount specs that already specify
+// capabilities are left unchanged.
+func addL4TCapabilities(targets []*csv.MountSpec) {
+	for _, t := range targets {
+		if len(t.Capabilities) > 0 {
+			continue
+		}
+		name := filepath.Base(t.Path)
+		for _, c := range l4tCapabilities {
+			for _, pattern := range c.patterns {
+				if match, _ := filepath.Match(pattern, name); match {
+					t.Capabilities = append(t.Capabilities, string(c.capability))
+					break
+				}
+			}
+		}
+	}
+}
+
+// filterByCapabilities returns the mount specs that are required for the
+// configured driver capabilities. Mount specs without capabilities are
+// required for all capabilities. If no driver capabilities are configured, no
+// filtering is performed.
+func (o tegraOptions) filterByCapabilities(targets []*csv.MountSpec) []*csv.MountSpec {
+	if o.driverCapabilities == nil {
+		return targets
+	}
+
+	var filtered []*csv.MountSpec
+	for _, t := range targets {
+		if len(t.Capabilities) == 0 || o.driverCapabilities.Any(toDriverCapabilities(t.Capabilities)...) {
+			filtered = append(filtered, t)
+			continue
+		}
+		o.logger.Debugf("Skipping %v; capabilities %v not requested", t.Path, t.Capabilities)
+	}
+	return filtered
+}
+
+// CapabilitiesFromCSVFiles returns the (sorted) list of driver capabilities
+// that the mount specs in the specified CSV files are tagged with.
+func CapabilitiesFromCSVFiles(logger logger.Interface, csvFiles []string) []string {
+	seen := make(map[string]bool)
+	var capabilities []string
+	for _, t := range getTargetsFromCSVFiles(logger, csvFiles) {
+		for _, c := range t.Capabilities {
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			capabilities = append(capabilities, c)
+		}
+	}
+	sort.Strings(capabilities)
+	return capabilities
+}
+
+func toDriverCapabilities(capabilities []string) []image.DriverCapability {
+	var dc []image.DriverCapability
+	for _, c := range capabilities {
+		dc = append(dc, image.DriverCapability(c))
+	}
+	return dc
+}
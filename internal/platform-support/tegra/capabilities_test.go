@@ -0,0 +1,131 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tegra
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+)
+
+func TestAddL4TCapabilities(t *testing.T) {
+	targets := []*csv.MountSpec{
+		{Type: csv.MountSpecLib, Path: "/usr/lib/aarch64-linux-gnu/tegra/libcuda.so.1.1"},
+		{Type: csv.MountSpecLib, Path: "/usr/lib/aarch64-linux-gnu/tegra/libnvidia-eglcore.so.36.4.3"},
+		{Type: csv.MountSpecLib, Path: "/usr/lib/aarch64-linux-gnu/tegra/libnvmedia_ide_sci.so"},
+		{Type: csv.MountSpecSym, Path: "/usr/lib/aarch64-linux-gnu/tegra/libnvrm_gpu.so"},
+		{Type: csv.MountSpecLib, Path: "/usr/lib/aarch64-linux-gnu/tegra/libnvdla_runtime.so", Capabilities: []string{"video"}},
+	}
+
+	addL4TCapabilities(targets)
+
+	var capabilities [][]string
+	for _, t := range targets {
+		capabilities = append(capabilities, t.Capabilities)
+	}
+	require.EqualValues(t,
+		[][]string{
+			{"compute"},
+			{"graphics"},
+			{"video"},
+			nil,
+			{"video"},
+		},
+		capabilities,
+	)
+}
+
+func TestFilterByCapabilities(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	targets := []*csv.MountSpec{
+		{Type: csv.MountSpecLib, Path: "/lib/libcuda.so.1.1", Capabilities: []string{"compute"}},
+		{Type: csv.MountSpecLib, Path: "/lib/libnvidia-eglcore.so.36.4.3", Capabilities: []string{"graphics"}},
+		{Type: csv.MountSpecLib, Path: "/lib/libnvidia-ml.so.1", Capabilities: []string{"compute", "utility"}},
+		{Type: csv.MountSpecLib, Path: "/lib/libnvrm_gpu.so"},
+	}
+
+	testCases := []struct {
+		description        string
+		driverCapabilities image.DriverCapabilities
+		expectedPaths      []string
+	}{
+		{
+			description:   "no capabilities does not filter",
+			expectedPaths: []string{"/lib/libcuda.so.1.1", "/lib/libnvidia-eglcore.so.36.4.3", "/lib/libnvidia-ml.so.1", "/lib/libnvrm_gpu.so"},
+		},
+		{
+			description:        "all does not filter",
+			driverCapabilities: image.NewDriverCapabilities("all"),
+			expectedPaths:      []string{"/lib/libcuda.so.1.1", "/lib/libnvidia-eglcore.so.36.4.3", "/lib/libnvidia-ml.so.1", "/lib/libnvrm_gpu.so"},
+		},
+		{
+			description:        "compute excludes graphics",
+			driverCapabilities: image.NewDriverCapabilities("compute"),
+			expectedPaths:      []string{"/lib/libcuda.so.1.1", "/lib/libnvidia-ml.so.1", "/lib/libnvrm_gpu.so"},
+		},
+		{
+			description:        "utility selects entries with any matching capability",
+			driverCapabilities: image.NewDriverCapabilities("utility"),
+			expectedPaths:      []string{"/lib/libnvidia-ml.so.1", "/lib/libnvrm_gpu.so"},
+		},
+		{
+			description:        "empty capabilities only includes untagged entries",
+			driverCapabilities: image.NewDriverCapabilities(),
+			expectedPaths:      []string{"/lib/libnvrm_gpu.so"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			o := tegraOptions{
+				logger:             logger,
+				driverCapabilities: tc.driverCapabilities,
+			}
+
+			var paths []string
+			for _, t := range o.filterByCapabilities(targets) {
+				paths = append(paths, t.Path)
+			}
+			require.EqualValues(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func TestCapabilitiesFromCSVFiles(t *testing.T) {
+	nullLogger, _ := testlog.NewNullLogger()
+
+	original := getTargetsFromCSVFiles
+	defer func() {
+		getTargetsFromCSVFiles = original
+	}()
+	getTargetsFromCSVFiles = func(_ logger.Interface, _ []string) []*csv.MountSpec {
+		return []*csv.MountSpec{
+			{Type: csv.MountSpecLib, Path: "/lib/libnvidia-eglcore.so.36.4.3", Capabilities: []string{"graphics"}},
+			{Type: csv.MountSpecLib, Path: "/lib/libnvidia-ml.so.1", Capabilities: []string{"utility", "compute"}},
+			{Type: csv.MountSpecLib, Path: "/lib/libcuda.so.1.1", Capabilities: []string{"compute"}},
+			{Type: csv.MountSpecLib, Path: "/lib/libnvrm_gpu.so"},
+		}
+	}
+
+	require.EqualValues(t, []string{"compute", "graphics", "utility"}, CapabilitiesFromCSVFiles(nullLogger, nil))
+}
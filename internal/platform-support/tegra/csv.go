@@ -34,7 +34,25 @@ func (o tegraOptions) newDiscovererFromCSVFiles() (discover.Discover, error) {
 		return discover.None{}, nil
 	}
 
-	targetsByType := getTargetsFromCSVFiles(o.logger, o.csvFiles)
+	targetsByType := make(map[csv.MountSpecType][]string)
+	var overridden []*csv.MountSpec
+	for _, t := range o.filterByCapabilities(getTargetsFromCSVFiles(o.logger, o.csvFiles)) {
+		if t.HasOverrides() {
+			overridden = append(overridden, t)
+			continue
+		}
+		targetsByType[t.Type] = append(targetsByType[t.Type], t.Path)
+	}
+
+	var overrides []discover.Discover
+	var libraryOverrides []discover.Discover
+	for _, t := range overridden {
+		if t.Type == csv.MountSpecLib {
+			libraryOverrides = append(libraryOverrides, o.newMountSpecDiscoverer(t))
+			continue
+		}
+		overrides = append(overrides, o.newMountSpecDiscoverer(t))
+	}
 
 	devices := discover.NewCharDeviceDiscoverer(
 		o.logger,
@@ -52,11 +70,15 @@ func (o tegraOptions) newDiscovererFromCSVFiles() (discover.Discover, error) {
 	// We create a discoverer for mounted libraries and add additional .so
 	// symlinks for the driver.
 	libraries := discover.WithDriverDotSoSymlinks(
-		discover.NewMounts(
-			o.logger,
-			o.symlinkLocator,
-			o.driverRoot,
-			targetsByType[csv.MountSpecLib],
+		discover.Merge(
+			append([]discover.Discover{
+				discover.NewMounts(
+					o.logger,
+					o.symlinkLocator,
+					o.driverRoot,
+					targetsByType[csv.MountSpecLib],
+				),
+			}, libraryOverrides...)...,
 		),
 		"",
 		o.nvidiaCDIHookPath,
@@ -74,45 +96,71 @@ func (o tegraOptions) newDiscovererFromCSVFiles() (discover.Discover, error) {
 	createSymlinks := o.createCSVSymlinkHooks(symlinkTargets)
 
 	d := discover.Merge(
-		devices,
-		directories,
-		libraries,
-		symlinks,
-		createSymlinks,
+		append([]discover.Discover{
+			devices,
+			directories,
+			libraries,
+			symlinks,
+			createSymlinks,
+		}, overrides...)...,
 	)
 
 	return d, nil
 }
 
+// newMountSpecDiscoverer creates a discoverer for a single mount spec that
+// overrides the container path, mount options, or matched paths.
+func (o tegraOptions) newMountSpecDiscoverer(m *csv.MountSpec) discover.Discover {
+	var d discover.Discover
+	switch m.Type {
+	case csv.MountSpecDev:
+		d = discover.NewCharDeviceDiscoverer(o.logger, o.devRoot, []string{m.Path})
+	case csv.MountSpecDir:
+		d = discover.NewMounts(
+			o.logger,
+			lookup.NewDirectoryLocator(lookup.WithLogger(o.logger), lookup.WithRoot(o.driverRoot)),
+			o.driverRoot,
+			[]string{m.Path},
+		)
+	default:
+		d = discover.NewMounts(o.logger, o.symlinkLocator, o.driverRoot, []string{m.Path})
+	}
+	return &mountSpecOverrides{
+		Discover: d,
+		spec:     m,
+	}
+}
+
 // getTargetsFromCSVFiles returns the list of mount specs from the specified CSV files.
-// These are aggregated by mount spec type.
 // TODO: We use a function variable here to allow this to be overridden for testing.
 // This should be properly mocked.
-var getTargetsFromCSVFiles = func(logger logger.Interface, files []string) map[csv.MountSpecType][]string {
-	targetsByType := make(map[csv.MountSpecType][]string)
+var getTargetsFromCSVFiles = func(logger logger.Interface, files []string) []*csv.MountSpec {
+	var targets []*csv.MountSpec
 	for _, filename := range files {
-		targets, err := loadCSVFile(logger, filename)
+		fileTargets, err := loadCSVFile(logger, filename)
 		if err != nil {
 			logger.Warningf("Skipping CSV file %v: %v", filename, err)
 			continue
 		}
-		for _, t := range targets {
-			targetsByType[t.Type] = append(targetsByType[t.Type], t.Path)
-		}
+		targets = append(targets, fileTargets...)
 	}
-	return targetsByType
+	return targets
 }
 
-// loadCSVFile loads the specified CSV file and returns the list of mount specs
+// loadCSVFile loads the specified CSV (or structured mount spec) file and
+// returns the list of mount specs
 func loadCSVFile(logger logger.Interface, filename string) ([]*csv.MountSpec, error) {
 	// Create a discoverer for each file-kind combination
-	targets, err := csv.NewCSVFileParser(logger, filename).Parse()
+	targets, err := csv.NewFileParser(logger, filename).Parse()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse CSV file: %v", err)
 	}
 	if len(targets) == 0 {
 		return nil, fmt.Errorf("CSV file is empty")
 	}
+	if isL4TFile(filename) {
+		addL4TCapabilities(targets)
+	}
 
 	return targets, nil
 }
@@ -49,8 +49,9 @@ func DefaultFileList() []string {
 	return paths
 }
 
-// GetFileList returns the (non-recursive) list of CSV files in the specified
-// folder
+// GetFileList returns the (non-recursive) list of mount spec files in the
+// specified folder. This includes both CSV files and structured (YAML or JSON)
+// mount spec files.
 func GetFileList(root string) ([]string, error) {
 	contents, err := os.ReadDir(root)
 	if err != nil && errors.Is(err, os.ErrNotExist) {
@@ -64,11 +65,11 @@ func GetFileList(root string) ([]string, error) {
 		if c.IsDir() {
 			continue
 		}
-		if c.Name() == ".csv" {
+		ext := strings.ToLower(filepath.Ext(c.Name()))
+		if c.Name() == ext {
 			continue
 		}
-		ext := strings.ToLower(filepath.Ext(c.Name()))
-		if ext != ".csv" {
+		if ext != ".csv" && !isStructuredFileExtension(ext) {
 			continue
 		}
 
@@ -79,17 +80,18 @@ func GetFileList(root string) ([]string, error) {
 }
 
 // BaseFilesOnly filters out non-base CSV files from the list of CSV files.
+// Structured mount spec files with the same base names are also selected.
 func BaseFilesOnly(filenames []string) []string {
 	filter := map[string]bool{
-		"l4t.csv":     true,
-		"drivers.csv": true,
-		"devices.csv": true,
+		"l4t":     true,
+		"drivers": true,
+		"devices": true,
 	}
 
 	var selected []string
 	for _, file := range filenames {
 		base := filepath.Base(file)
-		if filter[base] {
+		if filter[strings.TrimSuffix(base, filepath.Ext(base))] {
 			selected = append(selected, file)
 		}
 	}
@@ -107,6 +109,17 @@ type csv struct {
 	filename string
 }
 
+// NewFileParser creates a new parser for reading MountSpecs from the specified
+// file. The format of the file is selected by its extension, with .yaml, .yml,
+// and .json files parsed as structured mount spec files and all other files
+// parsed as CSV files.
+func NewFileParser(logger logger.Interface, filename string) Parser {
+	if isStructuredFileExtension(strings.ToLower(filepath.Ext(filename))) {
+		return NewStructuredFileParser(logger, filename)
+	}
+	return NewCSVFileParser(logger, filename)
+}
+
 // NewCSVFileParser creates a new parser for reading MountSpecs from the specified CSV file
 func NewCSVFileParser(logger logger.Interface, filename string) Parser {
 	p := csv{
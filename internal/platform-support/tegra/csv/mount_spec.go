@@ -18,7 +18,10 @@ package csv
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 )
 
 // MountSpecType defines the mount types allowed in a CSV file
@@ -36,9 +39,27 @@ const (
 )
 
 // MountSpec represents a Jetson mount consisting of a type and a path.
+//
+// The remaining fields can only be specified in structured (YAML or JSON) mount
+// spec files.
 type MountSpec struct {
-	Type MountSpecType
-	Path string
+	Type MountSpecType `json:"type"`
+	Path string        `json:"path"`
+	// ContainerPath is the path of the mount in the container. If the path is
+	// a glob pattern, this is the directory that the matching files are
+	// mounted into. If empty, the host path (relative to the driver root) is
+	// used.
+	ContainerPath string `json:"containerPath,omitempty"`
+	// Options overrides the default mount options.
+	Options []string `json:"options,omitempty"`
+	// Capabilities lists the driver capabilities (e.g. compute, graphics) that
+	// the mount is required for. If empty, the mount is required for all
+	// capabilities.
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Exclude lists glob patterns for paths that are excluded from the
+	// matches of the path. Patterns starting with **/ are matched against the
+	// filename only.
+	Exclude []string `json:"exclude,omitempty"`
 }
 
 // NewMountSpecFromLine parses the specified line and returns the MountSpec or an error if the line is malformed
@@ -72,3 +93,34 @@ func NewMountSpec(mountType string, path string) (*MountSpec, error) {
 
 	return &mount, nil
 }
+
+// Validate checks whether the mount spec is valid.
+func (m *MountSpec) Validate() error {
+	if _, err := NewMountSpec(string(m.Type), m.Path); err != nil {
+		return err
+	}
+	if m.ContainerPath != "" && !filepath.IsAbs(m.ContainerPath) {
+		return fmt.Errorf("container path %v for %v is not absolute", m.ContainerPath, m.Path)
+	}
+	if m.Type == MountSpecSym && m.HasOverrides() {
+		return fmt.Errorf("container path, options, and exclude are not supported for symlink %v", m.Path)
+	}
+	for _, c := range m.Capabilities {
+		if !image.SupportedDriverCapabilities.Has(image.DriverCapability(c)) {
+			return fmt.Errorf("unsupported capability %q for %v", c, m.Path)
+		}
+	}
+	for _, pattern := range m.Exclude {
+		if _, err := filepath.Match(strings.TrimPrefix(pattern, "**/"), ""); err != nil {
+			return fmt.Errorf("invalid exclude pattern %q for %v: %w", pattern, m.Path, err)
+		}
+	}
+	return nil
+}
+
+// HasOverrides checks whether the mount spec overrides the container path,
+// mount options, or matched paths. Such mount specs cannot be aggregated with
+// other mount specs of the same type.
+func (m *MountSpec) HasOverrides() bool {
+	return m.ContainerPath != "" || len(m.Options) > 0 || len(m.Exclude) > 0
+}
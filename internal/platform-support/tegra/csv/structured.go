@@ -0,0 +1,142 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	// MountSpecFileVersion is the current version of the structured mount spec
+	// file format.
+	MountSpecFileVersion = "v1"
+)
+
+// A MountSpecFile represents a structured mount spec file. Such files can be
+// specified as YAML or JSON. For example:
+//
+//	version: v1
+//	mounts:
+//	- type: lib
+//	  path: /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1
+//	  capabilities: [compute]
+//	- type: dir
+//	  path: /usr/share/nvidia/*
+//	  containerPath: /usr/share/nvidia
+//	  options: [ro, nosuid, nodev, rbind]
+//	  exclude: ["**/*.txt"]
+type MountSpecFile struct {
+	Version string       `json:"version"`
+	Mounts  []*MountSpec `json:"mounts"`
+}
+
+type structured struct {
+	logger   logger.Interface
+	filename string
+}
+
+// NewStructuredFileParser creates a new parser for reading MountSpecs from the
+// specified YAML or JSON file.
+func NewStructuredFileParser(logger logger.Interface, filename string) Parser {
+	p := structured{
+		logger:   logger,
+		filename: filename,
+	}
+
+	return &p
+}
+
+// Parse parses the mount spec file and returns a list of MountSpecs in the file.
+// In contrast to CSV files, invalid mount specs are treated as an error.
+func (p structured) Parse() ([]*MountSpec, error) {
+	reader, err := os.Open(p.filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v for reading: %v", p.filename, err)
+	}
+	defer reader.Close()
+
+	return p.parseFromReader(reader)
+}
+
+func (p structured) parseFromReader(reader io.Reader) ([]*MountSpec, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mount spec file: %v", err)
+	}
+
+	var f MountSpecFile
+	// JSON is a subset of YAML and as such this handles both formats.
+	if err := yaml.UnmarshalStrict(contents, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse mount spec file: %v", err)
+	}
+	if f.Version != MountSpecFileVersion {
+		return nil, fmt.Errorf("unsupported mount spec file version %q", f.Version)
+	}
+
+	var targets []*MountSpec
+	for _, m := range f.Mounts {
+		if m == nil {
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid mount spec: %w", err)
+		}
+		targets = append(targets, m)
+	}
+	return targets, nil
+}
+
+// WriteMountSpecFile writes the specified MountSpecs as a structured mount spec
+// file in the specified format (yaml or json).
+func WriteMountSpecFile(w io.Writer, format string, specs []*MountSpec) error {
+	f := MountSpecFile{
+		Version: MountSpecFileVersion,
+		Mounts:  specs,
+	}
+
+	var contents []byte
+	var err error
+	switch format {
+	case "yaml":
+		contents, err = yaml.Marshal(f)
+	case "json":
+		contents, err = json.MarshalIndent(f, "", "  ")
+		contents = append(contents, '\n')
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal mount spec file: %v", err)
+	}
+	_, err = w.Write(contents)
+	return err
+}
+
+func isStructuredFileExtension(ext string) bool {
+	switch ext {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredParseFromReader(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		contents      string
+		expectedError bool
+		expected      []*MountSpec
+	}{
+		{
+			description: "yaml",
+			contents: `
+version: v1
+mounts:
+- type: lib
+  path: /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1
+  capabilities: [compute]
+- type: dir
+  path: /usr/share/nvidia/*
+  containerPath: /usr/share/nvidia
+  options: [ro, rbind]
+  exclude: ["**/*.txt"]
+`,
+			expected: []*MountSpec{
+				{
+					Type:         MountSpecLib,
+					Path:         "/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1",
+					Capabilities: []string{"compute"},
+				},
+				{
+					Type:          MountSpecDir,
+					Path:          "/usr/share/nvidia/*",
+					ContainerPath: "/usr/share/nvidia",
+					Options:       []string{"ro", "rbind"},
+					Exclude:       []string{"**/*.txt"},
+				},
+			},
+		},
+		{
+			description: "json",
+			contents:    `{"version": "v1", "mounts": [{"type": "dev", "path": "/dev/nvhost-ctrl", "containerPath": "/dev/nvhost-ctrl-0"}]}`,
+			expected: []*MountSpec{
+				{
+					Type:          MountSpecDev,
+					Path:          "/dev/nvhost-ctrl",
+					ContainerPath: "/dev/nvhost-ctrl-0",
+				},
+			},
+		},
+		{
+			description:   "unsupported version",
+			contents:      `{"version": "v2", "mounts": []}`,
+			expectedError: true,
+		},
+		{
+			description:   "unknown field",
+			contents:      `{"version": "v1", "mounts": [{"type": "dev", "path": "/dev/nvhost-ctrl", "target": "/dev/foo"}]}`,
+			expectedError: true,
+		},
+		{
+			description:   "invalid type",
+			contents:      `{"version": "v1", "mounts": [{"type": "foo", "path": "/dev/nvhost-ctrl"}]}`,
+			expectedError: true,
+		},
+		{
+			description:   "relative container path",
+			contents:      `{"version": "v1", "mounts": [{"type": "lib", "path": "/lib/foo.so", "containerPath": "lib/foo.so"}]}`,
+			expectedError: true,
+		},
+		{
+			description:   "unsupported capability",
+			contents:      `{"version": "v1", "mounts": [{"type": "lib", "path": "/lib/foo.so", "capabilities": ["all"]}]}`,
+			expectedError: true,
+		},
+		{
+			description:   "symlink with container path",
+			contents:      `{"version": "v1", "mounts": [{"type": "sym", "path": "/lib/foo.so", "containerPath": "/lib/bar.so"}]}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			p := structured{logger: logger}
+			specs, err := p.parseFromReader(strings.NewReader(tc.contents))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expected, specs)
+		})
+	}
+}
+
+func TestWriteMountSpecFile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	specs := (&csv{logger: logger}).parseFromReader(strings.NewReader("dev, /dev/nvhost-ctrl\nlib, /usr/lib/libcuda.so.1.1\n"))
+
+	for _, format := range []string{"yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteMountSpecFile(&buf, format, specs))
+
+			parsed, err := (&structured{logger: logger}).parseFromReader(&buf)
+			require.NoError(t, err)
+			require.EqualValues(t, specs, parsed)
+		})
+	}
+
+	require.Error(t, WriteMountSpecFile(&bytes.Buffer{}, "toml", specs))
+}
+
+func TestBaseFilesOnly(t *testing.T) {
+	files := []string{
+		"/etc/nvidia-container-runtime/host-files-for-container.d/l4t.csv",
+		"/etc/nvidia-container-runtime/host-files-for-container.d/drivers.yaml",
+		"/etc/nvidia-container-runtime/host-files-for-container.d/devices.json",
+		"/etc/nvidia-container-runtime/host-files-for-container.d/other.csv",
+		"/etc/nvidia-container-runtime/host-files-for-container.d/other.yaml",
+	}
+	require.EqualValues(t, files[:3], BaseFilesOnly(files))
+}
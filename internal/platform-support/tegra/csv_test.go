@@ -211,9 +211,16 @@ func TestDiscovererFromCSVFiles(t *testing.T) {
 }
 
 func setGetTargetsFromCSVFiles(ovverride map[csv.MountSpecType][]string) func() {
+	var targets []*csv.MountSpec
+	for mountSpecType, paths := range ovverride {
+		for _, path := range paths {
+			targets = append(targets, &csv.MountSpec{Type: mountSpecType, Path: path})
+		}
+	}
+
 	original := getTargetsFromCSVFiles
-	getTargetsFromCSVFiles = func(logger logger.Interface, files []string) map[csv.MountSpecType][]string {
-		return ovverride
+	getTargetsFromCSVFiles = func(logger logger.Interface, files []string) []*csv.MountSpec {
+		return targets
 	}
 
 	return func() {
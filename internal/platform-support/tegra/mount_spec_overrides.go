@@ -0,0 +1,90 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tegra
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+)
+
+// mountSpecOverrides applies the container path, mount options, and exclude
+// patterns of a mount spec to the devices and mounts of the wrapped discoverer.
+type mountSpecOverrides struct {
+	discover.Discover
+	spec *csv.MountSpec
+}
+
+var _ discover.Discover = (*mountSpecOverrides)(nil)
+
+func (d *mountSpecOverrides) Devices() ([]discover.Device, error) {
+	devices, err := d.Discover.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []discover.Device
+	for _, device := range devices {
+		if d.isExcluded(device.Path) {
+			continue
+		}
+		device.Path = d.containerPath(device.Path)
+		filtered = append(filtered, device)
+	}
+	return filtered, nil
+}
+
+func (d *mountSpecOverrides) Mounts() ([]discover.Mount, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []discover.Mount
+	for _, mount := range mounts {
+		if d.isExcluded(mount.Path) {
+			continue
+		}
+		mount.Path = d.containerPath(mount.Path)
+		if len(d.spec.Options) > 0 {
+			mount.Options = d.spec.Options
+		}
+		filtered = append(filtered, mount)
+	}
+	return filtered, nil
+}
+
+// isExcluded checks whether the specified path matches any of the exclude
+// patterns of the mount spec.
+func (d *mountSpecOverrides) isExcluded(path string) bool {
+	return ignoreMountSpecPatterns(d.spec.Exclude).Match(path)
+}
+
+// containerPath returns the container path for the specified (discovered) path.
+// If the mount spec path is a glob pattern, the container path of the mount
+// spec is treated as a directory.
+func (d *mountSpecOverrides) containerPath(path string) string {
+	if d.spec.ContainerPath == "" {
+		return path
+	}
+	if strings.ContainsAny(d.spec.Path, "*?[") {
+		return filepath.Join(d.spec.ContainerPath, filepath.Base(path))
+	}
+	return d.spec.ContainerPath
+}
@@ -0,0 +1,104 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tegra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+)
+
+func TestMountSpecOverrides(t *testing.T) {
+	defaultOptions := []string{"ro", "nosuid", "nodev", "bind"}
+	mounts := []discover.Mount{
+		{HostPath: "/driver-root/usr/share/nvidia/a.json", Path: "/usr/share/nvidia/a.json", Options: defaultOptions},
+		{HostPath: "/driver-root/usr/share/nvidia/b.txt", Path: "/usr/share/nvidia/b.txt", Options: defaultOptions},
+	}
+
+	testCases := []struct {
+		description    string
+		spec           csv.MountSpec
+		expectedMounts []discover.Mount
+	}{
+		{
+			description:    "no overrides",
+			spec:           csv.MountSpec{Type: csv.MountSpecLib, Path: "/usr/share/nvidia/*"},
+			expectedMounts: mounts,
+		},
+		{
+			description: "exclude pattern",
+			spec:        csv.MountSpec{Type: csv.MountSpecLib, Path: "/usr/share/nvidia/*", Exclude: []string{"**/*.txt"}},
+			expectedMounts: []discover.Mount{
+				{HostPath: "/driver-root/usr/share/nvidia/a.json", Path: "/usr/share/nvidia/a.json", Options: defaultOptions},
+			},
+		},
+		{
+			description: "container path for glob is a directory",
+			spec:        csv.MountSpec{Type: csv.MountSpecLib, Path: "/usr/share/nvidia/*", ContainerPath: "/opt/nvidia", Options: []string{"ro", "rbind"}},
+			expectedMounts: []discover.Mount{
+				{HostPath: "/driver-root/usr/share/nvidia/a.json", Path: "/opt/nvidia/a.json", Options: []string{"ro", "rbind"}},
+				{HostPath: "/driver-root/usr/share/nvidia/b.txt", Path: "/opt/nvidia/b.txt", Options: []string{"ro", "rbind"}},
+			},
+		},
+		{
+			description: "container path for file",
+			spec:        csv.MountSpec{Type: csv.MountSpecLib, Path: "/usr/share/nvidia/a.json", ContainerPath: "/etc/a.json"},
+			expectedMounts: []discover.Mount{
+				{HostPath: "/driver-root/usr/share/nvidia/a.json", Path: "/etc/a.json", Options: defaultOptions},
+				{HostPath: "/driver-root/usr/share/nvidia/b.txt", Path: "/etc/a.json", Options: defaultOptions},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			d := &mountSpecOverrides{
+				Discover: &discover.DiscoverMock{
+					MountsFunc: func() ([]discover.Mount, error) {
+						return mounts, nil
+					},
+				},
+				spec: &tc.spec,
+			}
+
+			m, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, m)
+		})
+	}
+}
+
+func TestMountSpecOverridesDevices(t *testing.T) {
+	d := &mountSpecOverrides{
+		Discover: &discover.DiscoverMock{
+			DevicesFunc: func() ([]discover.Device, error) {
+				return []discover.Device{
+					{HostPath: "/dev/nvhost-ctrl", Path: "/dev/nvhost-ctrl"},
+					{HostPath: "/dev/nvhost-gpu", Path: "/dev/nvhost-gpu"},
+				}, nil
+			},
+		},
+		spec: &csv.MountSpec{Type: csv.MountSpecDev, Path: "/dev/nvhost-*", ContainerPath: "/dev/tegra", Exclude: []string{"/dev/nvhost-gpu"}},
+	}
+
+	devices, err := d.Devices()
+	require.NoError(t, err)
+	require.EqualValues(t, []discover.Device{{HostPath: "/dev/nvhost-ctrl", Path: "/dev/tegra/nvhost-ctrl"}}, devices)
+}
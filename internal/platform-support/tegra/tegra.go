@@ -19,6 +19,7 @@ package tegra
 import (
 	"fmt"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
@@ -34,6 +35,7 @@ type tegraOptions struct {
 	ldconfigPath       string
 	librarySearchPaths []string
 	ignorePatterns     ignoreMountSpecPatterns
+	driverCapabilities image.DriverCapabilities
 
 	// The following can be overridden for testing
 	symlinkLocator      lookup.Locator
@@ -160,3 +162,11 @@ func WithIngorePatterns(ignorePatterns ...string) Option {
 		o.ignorePatterns = ignoreMountSpecPatterns(ignorePatterns)
 	}
 }
+
+// WithDriverCapabilities sets the driver capabilities that the mount specs are
+// filtered by. If this is unset, all mount specs are included.
+func WithDriverCapabilities(driverCapabilities image.DriverCapabilities) Option {
+	return func(o *tegraOptions) {
+		o.driverCapabilities = driverCapabilities
+	}
+}
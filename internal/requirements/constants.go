@@ -22,4 +22,15 @@ const (
 	BRAND  = "brand"
 	CUDA   = "cuda"
 	DRIVER = "driver"
+
+	// COMPUTE_CAPABILITY is the CUDA compute capability of the GPU as a version (e.g. 8.0).
+	COMPUTE_CAPABILITY = "compute_cap"
+	// DEVICE_COUNT is the number of devices visible to the container.
+	DEVICE_COUNT = "device_count"
+	// MEMORY is the total memory of the GPU. Values may include a unit (e.g. 24G).
+	MEMORY = "memory"
+	// MIG indicates whether MIG mode is enabled.
+	MIG = "mig"
+	// NVLINK indicates whether the GPU has active NVLink connections.
+	NVLINK = "nvlink"
 )
@@ -17,9 +17,14 @@
 package constraints
 
 import (
+	"errors"
 	"fmt"
 )
 
+// errUnsatisfied indicates that a constraint was evaluated successfully but
+// was not met.
+var errUnsatisfied = errors.New("unsatisfied condition")
+
 // binary represents a binary operation. This can be used to compare a specified
 // property to a value
 type binary struct {
@@ -44,7 +49,7 @@ func (c binary) Assert() error {
 	}
 
 	// error_setx(err, "unsatisfied condition: %s, please update your driver to a newer version, or use an earlier cuda container", predicate_format);
-	return fmt.Errorf("unsatisfied condition: %v (%v)", c.String(), c.left.String())
+	return fmt.Errorf("%w: %v (%v)", errUnsatisfied, c.String(), c.left.String())
 }
 
 func (c binary) eval() (bool, error) {
@@ -54,46 +54,59 @@ func New(logger logger.Interface, requirements []string, properties map[string]P
 	return AND(constraints), nil
 }
 
+// PropertyNames returns the names of the properties that are referenced by the
+// conditions in the supplied requirements.
+func PropertyNames(requirements []string) ([]string, error) {
+	var names []string
+	for _, r := range requirements {
+		tokens, err := tokenize(r)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tokens {
+			if t.kind != tokenCondition {
+				continue
+			}
+			propertyEnd := strings.IndexAny(t.value, "<>=!")
+			if propertyEnd == -1 {
+				return nil, fmt.Errorf("invalid constraint: %v", t.value)
+			}
+			names = append(names, t.value[:propertyEnd])
+		}
+	}
+	return names, nil
+}
+
 // newConstraintFromRequirement takes a requirement string and generates
-// the associated constraint(s). Invalid constraints are ignored.
-// Each requirement can consist of multiple constraints, with space-separated constraints being ORed
-// together and comma-separated constraints being ANDed together.
+// the associated constraint(s). Unsupported properties are ignored.
+// Each requirement is an expression of conditions with the following operators
+// (from lowest to highest precedence):
+//
+//	a b, a|b, a||b   OR (space-separated conditions are ORed)
+//	a,b, a&&b        AND
+//	!a               NOT
+//	(a)              grouping
+//
+// For example: (arch>=8.0,memory>=24G) | brand=datacenter
 func (r factory) newConstraintFromRequirement(requirement string) (Constraint, error) {
-	const (
-		orSeparator  = " "
-		andSeparator = ","
-	)
 	if strings.TrimSpace(requirement) == "" {
 		return nil, nil
 	}
 
-	var terms []Constraint
-	for _, term := range strings.Split(requirement, orSeparator) {
-		var factors []Constraint
-		for _, factor := range strings.Split(term, andSeparator) {
-			f, err := r.parse(factor)
-			if err != nil {
-				return nil, err
-			}
-			if f == nil {
-				r.logger.Debugf("Skipping unsupported constraint: %v", factor)
-				continue
-			}
-			factors = append(factors, f)
-		}
-
-		if len(factors) == 0 {
-			continue
-		}
-
-		if len(factors) == 1 {
-			terms = append(terms, factors[0])
-		} else {
-			terms = append(terms, and(factors))
-		}
+	tokens, err := tokenize(requirement)
+	if err != nil {
+		return nil, err
 	}
 
-	return OR(terms), nil
+	p := &parser{factory: r, tokens: tokens}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, fmt.Errorf("invalid requirement %q: unexpected %q", requirement, t.value)
+	}
+	return c, nil
 }
 
 // parse constructs a constraint from the specified string.
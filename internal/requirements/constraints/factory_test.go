@@ -170,6 +170,56 @@ func TestNewConstraintFromRequirement(t *testing.T) {
 				}),
 			}),
 		},
+		{
+			description: "parentheses group conditions",
+			requirement: "(cuda>=11.6 cuda<11.0),arch=5.3",
+			expected: and([]Constraint{
+				or([]Constraint{
+					binary{cuda, greaterEqual, "11.6"},
+					binary{cuda, less, "11.0"},
+				}),
+				binary{arch, equal, "5.3"},
+			}),
+		},
+		{
+			description: "pipe is or",
+			requirement: "(cuda>=11.6, arch=5.3) | arch=8.0",
+			expected: or([]Constraint{
+				and([]Constraint{
+					binary{cuda, greaterEqual, "11.6"},
+					binary{arch, equal, "5.3"},
+				}),
+				binary{arch, equal, "8.0"},
+			}),
+		},
+		{
+			description: "negation",
+			requirement: "!(cuda>=11.6) && arch!=5.3",
+			expected: and([]Constraint{
+				not{binary{cuda, greaterEqual, "11.6"}},
+				binary{arch, notEqual, "5.3"},
+			}),
+		},
+		{
+			description: "negation of unsupported property is ignored",
+			requirement: "!foo=bar,cuda>=11.6",
+			expected:    binary{cuda, greaterEqual, "11.6"},
+		},
+		{
+			description:   "unbalanced parentheses are invalid",
+			requirement:   "(cuda>=11.6",
+			expectedError: true,
+		},
+		{
+			description:   "unexpected closing parenthesis is invalid",
+			requirement:   "cuda>=11.6)",
+			expectedError: true,
+		},
+		{
+			description: "empty operand is ignored",
+			requirement: "cuda>=11.6,",
+			expected:    binary{cuda, greaterEqual, "11.6"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -185,3 +235,86 @@ func TestNewConstraintFromRequirement(t *testing.T) {
 	}
 
 }
+
+func TestAssertRequirement(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	properties := map[string]Property{
+		"arch":         NewVersionProperty("arch", "8.0"),
+		"brand":        NewStringProperty("brand", "nvidia"),
+		"memory":       NewSizeProperty("memory", "17179869184"),
+		"device_count": NewIntProperty("device_count", "2"),
+		"mig":          NewBoolProperty("mig", "false"),
+		"nvlink":       NewBoolProperty("nvlink", ""),
+	}
+
+	testCases := []struct {
+		requirement   string
+		expectedError bool
+	}{
+		{requirement: "(arch>=8.0,memory>=16G) | brand=datacenter"},
+		{requirement: "(arch>=8.0,memory>=24G) | brand=datacenter", expectedError: true},
+		{requirement: "(arch>=8.0,memory>=24G) | brand=nvidia"},
+		{requirement: "memory>=16384MiB,memory<17G"},
+		{requirement: "device_count>=2,device_count<3"},
+		{requirement: "mig=false"},
+		{requirement: "!mig=true"},
+		{requirement: "!(arch>=8.0)", expectedError: true},
+		{requirement: "!(arch<7.0 | brand=tesla)"},
+		{requirement: "nvlink=true", expectedError: true},
+		{requirement: "!nvlink=true", expectedError: true},
+		{requirement: "nvlink=true | arch>=8.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.requirement, func(t *testing.T) {
+			c, err := New(logger, []string{tc.requirement}, properties)
+			require.NoError(t, err)
+
+			err = c.Assert()
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAssertUnknownProperty(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	properties := map[string]Property{
+		"arch":         NewVersionProperty("arch", ""),
+		"compute_cap":  NewVersionProperty("compute_cap", ""),
+		"cuda":         NewVersionProperty("cuda", ""),
+		"brand":        NewStringProperty("brand", ""),
+		"memory":       NewSizeProperty("memory", ""),
+		"device_count": NewIntProperty("device_count", ""),
+		"mig":          NewBoolProperty("mig", ""),
+	}
+
+	testCases := []string{
+		"arch>=8.0",
+		"!arch>=8.0",
+		"!(compute_cap>=9.0)",
+		"!cuda>=12.0",
+		"brand=tesla",
+		"!brand=tesla",
+		"!(memory>=24G)",
+		"!device_count>=2",
+		"!mig=true",
+		"!(arch>=8.0 | brand=tesla)",
+	}
+
+	for _, requirement := range testCases {
+		t.Run(requirement, func(t *testing.T) {
+			c, err := New(logger, []string{requirement}, properties)
+			require.NoError(t, err)
+
+			err = c.Assert()
+			require.ErrorIs(t, err, errUnknownValue)
+			require.NotErrorIs(t, err, errUnsatisfied)
+		})
+	}
+}
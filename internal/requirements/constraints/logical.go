@@ -17,6 +17,7 @@
 package constraints
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -27,6 +28,11 @@ type or []Constraint
 // and represents an AND (ALL) operation on a set of contraints
 type and []Constraint
 
+// not represents the negation of a constraint
+type not struct {
+	operand Constraint
+}
+
 // AND constructs a new constraint that is the logical AND of the supplied constraints
 func AND(constraints []Constraint) Constraint {
 	if len(constraints) == 0 {
@@ -51,13 +57,23 @@ func OR(constraints []Constraint) Constraint {
 }
 
 func (operands or) Assert() error {
+	var errs []error
 	for _, o := range operands {
 		// We stop on the first nil
-		if err := o.Assert(); err == nil {
+		err := o.Assert()
+		if err == nil {
 			return nil
 		}
+		if !errors.Is(err, errUnsatisfied) {
+			errs = append(errs, err)
+		}
 	}
-	return fmt.Errorf("%v not met", operands)
+	if len(errs) == 0 {
+		return fmt.Errorf("%w: %v not met", errUnsatisfied, operands)
+	}
+	// If any of the operands could not be evaluated, the errors are returned
+	// so that these are not treated as unsatisfied.
+	return fmt.Errorf("%v not met: %w", operands, errors.Join(errs...))
 }
 
 func (operands or) String() string {
@@ -89,3 +105,21 @@ func (operands and) String() string {
 
 	return strings.Join(terms, "&&")
 }
+
+// Assert is met if the operand is not. Errors that occur while evaluating the
+// operand (e.g. errUnknownValue if a property is not set) are returned as is
+// so that the negation of a condition that cannot be checked is also not met.
+func (c not) Assert() error {
+	err := c.operand.Assert()
+	if err == nil {
+		return fmt.Errorf("%w: %v", errUnsatisfied, c.String())
+	}
+	if errors.Is(err, errUnsatisfied) {
+		return nil
+	}
+	return err
+}
+
+func (c not) String() string {
+	return fmt.Sprintf("!(%v)", c.operand.String())
+}
@@ -0,0 +1,200 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package constraints
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenCondition tokenKind = iota
+	tokenOr
+	tokenAnd
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// tokenize splits a requirement string into tokens. Whitespace is only
+// significant in that it separates tokens.
+func tokenize(requirement string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(requirement); {
+		c := requirement[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenClose, ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokenAnd, ","})
+			i++
+		case strings.HasPrefix(requirement[i:], "&&"):
+			tokens = append(tokens, token{tokenAnd, "&&"})
+			i += 2
+		case strings.HasPrefix(requirement[i:], "||"):
+			tokens = append(tokens, token{tokenOr, "||"})
+			i += 2
+		case c == '|':
+			tokens = append(tokens, token{tokenOr, "|"})
+			i++
+		case c == '!' && !strings.HasPrefix(requirement[i:], notEqual):
+			tokens = append(tokens, token{tokenNot, "!"})
+			i++
+		default:
+			end := strings.IndexFunc(requirement[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune("(),|&", r)
+			})
+			if end == -1 {
+				end = len(requirement) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid requirement %q: unexpected %q", requirement, c)
+			}
+			tokens = append(tokens, token{tokenCondition, requirement[i : i+end]})
+			i += end
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser for requirement expressions.
+// Conditions on unsupported properties evaluate to nil constraints which are
+// dropped from the enclosing expression.
+type parser struct {
+	factory
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+// parseOr parses an OR expression. Terms that are separated by whitespace only
+// are also ORed.
+func (p *parser) parseOr() (Constraint, error) {
+	var terms []Constraint
+	for {
+		term, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if term != nil {
+			terms = append(terms, term)
+		}
+
+		t := p.peek()
+		if t == nil || t.kind == tokenClose {
+			break
+		}
+		if t.kind == tokenOr {
+			p.next()
+		}
+	}
+	return OR(terms), nil
+}
+
+func (p *parser) parseAnd() (Constraint, error) {
+	var factors []Constraint
+	for {
+		factor, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if factor != nil {
+			factors = append(factors, factor)
+		}
+
+		if t := p.peek(); t == nil || t.kind != tokenAnd {
+			break
+		}
+		p.next()
+	}
+
+	switch len(factors) {
+	case 0:
+		return nil, nil
+	case 1:
+		return factors[0], nil
+	}
+	return and(factors), nil
+}
+
+// parseUnary parses a negation, a parenthesised expression, or a condition.
+// As was the case before grouping was supported, empty operands (e.g. due to
+// a trailing comma) are ignored.
+func (p *parser) parseUnary() (Constraint, error) {
+	if t := p.peek(); t == nil || t.kind == tokenOr || t.kind == tokenAnd || t.kind == tokenClose {
+		return nil, nil
+	}
+
+	t := p.next()
+
+	switch t.kind {
+	case tokenNot:
+		operand, err := p.parseUnary()
+		if err != nil || operand == nil {
+			return nil, err
+		}
+		return not{operand}, nil
+	case tokenOpen:
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t == nil || t.kind != tokenClose {
+			return nil, fmt.Errorf("invalid requirement: missing ')'")
+		}
+		return c, nil
+	case tokenCondition:
+		c, err := p.parse(t.value)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			p.logger.Debugf("Skipping unsupported constraint: %v", t.value)
+			return nil, nil
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("invalid requirement: unexpected %q", t.value)
+}
@@ -17,12 +17,19 @@
 package constraints
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"golang.org/x/mod/semver"
 )
 
+// errUnknownValue indicates that a constraint cannot be evaluated since the
+// value of the property is not known. Since this is not an errUnsatisfied
+// error, the negation of such a constraint is also not met.
+var errUnknownValue = errors.New("unknown value")
+
 // Property represents a property that is used to check requirements
 //
 //go:generate moq -rm -fmt=goimports -stub -out property_mock.go . Property
@@ -56,6 +63,43 @@ func NewVersionProperty(name string, value string) Property {
 	return p
 }
 
+// NewSizeProperty creates a property representing a size in bytes based on the
+// name-value pair. Values may include a binary unit suffix such as 24G or 512MiB.
+func NewSizeProperty(name string, value string) Property {
+	p := sizeProperty{
+		stringProperty: stringProperty{
+			name:  name,
+			value: value,
+		},
+	}
+
+	return p
+}
+
+// NewIntProperty creates a property representing a non-negative integer based on the name-value pair
+func NewIntProperty(name string, value string) Property {
+	p := intProperty{
+		stringProperty: stringProperty{
+			name:  name,
+			value: value,
+		},
+	}
+
+	return p
+}
+
+// NewBoolProperty creates a property representing a boolean flag based on the name-value pair
+func NewBoolProperty(name string, value string) Property {
+	p := boolProperty{
+		stringProperty: stringProperty{
+			name:  name,
+			value: value,
+		},
+	}
+
+	return p
+}
+
 // stringProperty represents a property that is used to check requirements
 type stringProperty struct {
 	name  string
@@ -66,6 +110,18 @@ type versionProperty struct {
 	stringProperty
 }
 
+type sizeProperty struct {
+	stringProperty
+}
+
+type intProperty struct {
+	stringProperty
+}
+
+type boolProperty struct {
+	stringProperty
+}
+
 // Name returns a stringProperty's name
 func (p stringProperty) Name() string {
 	return p.name
@@ -77,7 +133,11 @@ func (p stringProperty) Value() (string, error) {
 }
 
 // CompareTo compares two strings to each other
+// An unset property value cannot be compared and results in an error.
 func (p stringProperty) CompareTo(other string) (int, error) {
+	if err := p.checkKnown(); err != nil {
+		return 0, err
+	}
 	value := p.value
 
 	if value < other {
@@ -96,6 +156,14 @@ func (p stringProperty) Validate(string) error {
 	return nil
 }
 
+// checkKnown returns an error if the value of the property is not set.
+func (p stringProperty) checkKnown() error {
+	if p.value == "" {
+		return fmt.Errorf("%w of %v", errUnknownValue, p.name)
+	}
+	return nil
+}
+
 // String returns the string representation of the name value combination
 func (p stringProperty) String() string {
 	v, err := p.Value()
@@ -106,7 +174,11 @@ func (p stringProperty) String() string {
 }
 
 // CompareTo compares two versions to each other as semantic versions
+// An unset property value cannot be compared and results in an error.
 func (p versionProperty) CompareTo(other string) (int, error) {
+	if err := p.checkKnown(); err != nil {
+		return 0, err
+	}
 	if err := p.Validate(other); err != nil {
 		return 0, fmt.Errorf("invailid value for %v: %v", p.name, err)
 	}
@@ -125,6 +197,113 @@ func (p versionProperty) Validate(value string) error {
 	return nil
 }
 
+// CompareTo compares two sizes to each other as a number of bytes
+func (p sizeProperty) CompareTo(other string) (int, error) {
+	return compareParsed(p.stringProperty, other, parseSize)
+}
+
+// Validate checks whether the supplied value is a valid size
+func (p sizeProperty) Validate(value string) error {
+	_, err := parseSize(value)
+	return err
+}
+
+// CompareTo compares two integers to each other
+func (p intProperty) CompareTo(other string) (int, error) {
+	return compareParsed(p.stringProperty, other, parseInt)
+}
+
+// Validate checks whether the supplied value is a valid non-negative integer
+func (p intProperty) Validate(value string) error {
+	_, err := parseInt(value)
+	return err
+}
+
+// CompareTo compares two boolean values to each other with false < true
+func (p boolProperty) CompareTo(other string) (int, error) {
+	return compareParsed(p.stringProperty, other, parseBool)
+}
+
+// Validate checks whether the supplied value is a valid boolean
+func (p boolProperty) Validate(value string) error {
+	_, err := parseBool(value)
+	return err
+}
+
+// compareParsed compares the value of the property to the other value after
+// both have been converted to numbers using the specified parse function.
+// An unset property value cannot be compared and results in an error.
+func compareParsed(p stringProperty, other string, parse func(string) (uint64, error)) (int, error) {
+	if err := p.checkKnown(); err != nil {
+		return 0, err
+	}
+	value, err := parse(p.value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %v: %v", p.name, err)
+	}
+	o, err := parse(other)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %v: %v", p.name, err)
+	}
+
+	switch {
+	case value < o:
+		return -1, nil
+	case value > o:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// parseSize parses a size such as 1024, 24G, 24GB, or 24GiB to a number of
+// bytes. Units are always interpreted as powers of 1024.
+func parseSize(value string) (uint64, error) {
+	units := []struct {
+		suffix     string
+		multiplier uint64
+	}{
+		{"T", 1 << 40},
+		{"G", 1 << 30},
+		{"M", 1 << 20},
+		{"K", 1 << 10},
+	}
+
+	number := strings.TrimSuffix(strings.TrimSuffix(strings.ToUpper(value), "B"), "I")
+	multiplier := uint64(1)
+	for _, u := range units {
+		if strings.HasSuffix(number, u.suffix) {
+			number = strings.TrimSuffix(number, u.suffix)
+			multiplier = u.multiplier
+			break
+		}
+	}
+
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %v; expected a size such as 24G", value)
+	}
+	return n * multiplier, nil
+}
+
+func parseInt(value string) (uint64, error) {
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %v; expected a non-negative integer", value)
+	}
+	return n, nil
+}
+
+func parseBool(value string) (uint64, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %v; expected true or false", value)
+	}
+	if b {
+		return 1, nil
+	}
+	return 0, nil
+}
+
 func ensurePrefix(s string, prefix string) string {
 	return prefix + strings.TrimPrefix(s, prefix)
 }
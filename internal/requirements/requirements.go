@@ -17,15 +17,30 @@
 package requirements
 
 import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/cudacompat"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/requirements/constraints"
 )
 
+// cudaRequirementPattern matches the lower bounds on the CUDA version in a
+// requirement string.
+var cudaRequirementPattern = regexp.MustCompile(`(?:^|[\s,(|&])` + CUDA + `(?:>=|>|=)([0-9]+\.[0-9]+)`)
+
 // Requirements represents a collection of requirements that can be compared to properties
 type Requirements struct {
 	logger       logger.Interface
 	requirements []string
 	properties   map[string]constraints.Property
+
+	matrix *cudacompat.Matrix
+	// forwardCompatDriverVersion is the driver version of the CUDA Forward
+	// Compatibility libraries in the container (if any).
+	forwardCompatDriverVersion string
 }
 
 // New creates a new set of requirements
@@ -39,12 +54,37 @@ func New(logger logger.Interface, requirements []string) *Requirements {
 			ARCH:   constraints.NewVersionProperty(ARCH, ""),
 			DRIVER: constraints.NewVersionProperty(DRIVER, ""),
 			BRAND:  constraints.NewStringProperty(BRAND, ""),
+
+			COMPUTE_CAPABILITY: constraints.NewVersionProperty(COMPUTE_CAPABILITY, ""),
+			DEVICE_COUNT:       constraints.NewIntProperty(DEVICE_COUNT, ""),
+			MEMORY:             constraints.NewSizeProperty(MEMORY, ""),
+			MIG:                constraints.NewBoolProperty(MIG, ""),
+			NVLINK:             constraints.NewBoolProperty(NVLINK, ""),
 		},
+		matrix: cudacompat.DefaultMatrix(),
 	}
 
 	return &r
 }
 
+// HasDeviceConstraints checks whether the specified requirements include
+// constraints on device properties such as the architecture or the memory of
+// a GPU. That is, constraints on properties other than the CUDA and driver
+// versions. Requirements that cannot be parsed are assumed to include such
+// constraints.
+func HasDeviceConstraints(requirements []string) bool {
+	names, err := constraints.PropertyNames(requirements)
+	if err != nil {
+		return true
+	}
+	for _, name := range names {
+		if name != CUDA && name != DRIVER {
+			return true
+		}
+	}
+	return false
+}
+
 // AddVersionProperty adds the specified property (name, value pair) to the requirements
 func (r *Requirements) AddVersionProperty(name string, value string) {
 	r.properties[name] = constraints.NewVersionProperty(name, value)
@@ -55,16 +95,150 @@ func (r *Requirements) AddStringProperty(name string, value string) {
 	r.properties[name] = constraints.NewStringProperty(name, value)
 }
 
-// Assert checks the specified requirements
+// AddSizeProperty adds the specified size property (name, value pair) to the requirements
+func (r *Requirements) AddSizeProperty(name string, value string) {
+	r.properties[name] = constraints.NewSizeProperty(name, value)
+}
+
+// AddIntProperty adds the specified integer property (name, value pair) to the requirements
+func (r *Requirements) AddIntProperty(name string, value string) {
+	r.properties[name] = constraints.NewIntProperty(name, value)
+}
+
+// AddBoolProperty adds the specified boolean property (name, value pair) to the requirements
+func (r *Requirements) AddBoolProperty(name string, value bool) {
+	r.properties[name] = constraints.NewBoolProperty(name, strconv.FormatBool(value))
+}
+
+// IgnoreProperty removes the specified property so that constraints on this
+// property are not checked. This is used if the value of a property cannot be
+// determined.
+func (r *Requirements) IgnoreProperty(name string) {
+	delete(r.properties, name)
+}
+
+// SetCompatibilityMatrix sets the CUDA compatibility matrix that is used to
+// evaluate forward compatibility. By default the embedded matrix is used.
+func (r *Requirements) SetCompatibilityMatrix(m *cudacompat.Matrix) {
+	if m == nil {
+		return
+	}
+	r.matrix = m
+}
+
+// AddForwardCompatLibraries indicates that the container includes CUDA Forward
+// Compatibility libraries with the specified driver version.
+func (r *Requirements) AddForwardCompatLibraries(driverVersion string) {
+	r.forwardCompatDriverVersion = driverVersion
+}
+
+// Assert checks the specified requirements.
+// If the requirements are not met by the host properties, but the container
+// includes CUDA Forward Compatibility libraries that can be used with the host
+// driver, the requirements are checked against the CUDA version of these
+// libraries instead. If the requirements are still not met, the returned
+// error includes a hint as to which driver is required.
 func (r Requirements) Assert() error {
 	if len(r.requirements) == 0 {
 		return nil
 	}
 
-	r.logger.Debugf("Checking properties %+v against requirements %v", r.properties, r.requirements)
-	c, err := constraints.New(r.logger, r.requirements, r.properties)
+	err := r.assert(r.properties)
+	if err == nil {
+		return nil
+	}
+
+	if properties := r.forwardCompatProperties(); properties != nil {
+		if r.assert(properties) == nil {
+			r.logger.Infof("Requirements met using CUDA forward compatibility libraries (%v)", r.forwardCompatDriverVersion)
+			return nil
+		}
+	}
+
+	if hint := r.hint(); hint != "" {
+		return fmt.Errorf("%w; %v", err, hint)
+	}
+	return err
+}
+
+func (r Requirements) assert(properties map[string]constraints.Property) error {
+	r.logger.Debugf("Checking properties %+v against requirements %v", properties, r.requirements)
+	c, err := constraints.New(r.logger, r.requirements, properties)
 	if err != nil {
 		return err
 	}
 	return c.Assert()
 }
+
+// forwardCompatProperties returns the properties that apply when the CUDA
+// Forward Compatibility libraries in the container are used. If there are no
+// such libraries, or if they cannot be used with the host driver, nil is
+// returned.
+func (r Requirements) forwardCompatProperties() map[string]constraints.Property {
+	if r.forwardCompatDriverVersion == "" {
+		return nil
+	}
+	compatCUDAVersion := r.matrix.CUDAVersionForDriver(r.forwardCompatDriverVersion)
+	if compatCUDAVersion == "" {
+		return nil
+	}
+	entry := r.matrix.Lookup(compatCUDAVersion)
+	hostDriverVersion := r.value(DRIVER)
+	if entry == nil || !entry.IsForwardCompatible(hostDriverVersion) {
+		r.logger.Debugf("CUDA %v forward compatibility is not supported on driver %q", compatCUDAVersion, hostDriverVersion)
+		return nil
+	}
+
+	properties := maps.Clone(r.properties)
+	properties[CUDA] = constraints.NewVersionProperty(CUDA, compatCUDAVersion)
+	return properties
+}
+
+// hint returns a description of the driver required to satisfy the CUDA
+// version requirements based on the compatibility matrix.
+func (r Requirements) hint() string {
+	var required *cudacompat.MatrixEntry
+	for _, requirement := range r.requirements {
+		for _, match := range cudaRequirementPattern.FindAllStringSubmatch(requirement, -1) {
+			entry := r.matrix.Lookup(match[1])
+			if entry == nil {
+				continue
+			}
+			if required == nil || compareCUDA(entry, required) > 0 {
+				required = entry
+			}
+		}
+	}
+	if required == nil {
+		return ""
+	}
+
+	hint := fmt.Sprintf("CUDA %v requires driver >= %v", required.CUDA, required.MinimumDriverVersion)
+	switch hostDriverVersion := r.value(DRIVER); {
+	case required.ForwardCompat == nil:
+		hint += "; forward compatibility is not supported"
+	case hostDriverVersion != "" && required.IsForwardCompatible(hostDriverVersion):
+		hint += fmt.Sprintf("; the image can run on driver %v using the CUDA %v forward compatibility package", hostDriverVersion, required.CUDA)
+	default:
+		hint += fmt.Sprintf("; forward compatibility requires driver >= %v", required.ForwardCompat.MinimumDriverVersion)
+	}
+	return hint
+}
+
+// value returns the value of the specified property or an empty string if it
+// is not set.
+func (r Requirements) value(name string) string {
+	p, ok := r.properties[name]
+	if !ok {
+		return ""
+	}
+	v, _ := p.Value()
+	return v
+}
+
+// compareCUDA compares the CUDA versions of two matrix entries.
+func compareCUDA(a *cudacompat.MatrixEntry, b *cudacompat.MatrixEntry) int {
+	va, _ := cudacompat.ParseVersion(a.CUDA)
+	vb, _ := cudacompat.ParseVersion(b.CUDA)
+	return va.Compare(vb)
+}
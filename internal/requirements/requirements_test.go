@@ -0,0 +1,141 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package requirements
+
+import (
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssert(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description             string
+		requirements            []string
+		cudaVersion             string
+		driverVersion           string
+		compatDriverVersion     string
+		expectError             bool
+		expectedErrorSubstrings []string
+	}{
+		{
+			description:   "requirement met by host",
+			requirements:  []string{"cuda>=12.2"},
+			cudaVersion:   "12.4",
+			driverVersion: "550.54.15",
+		},
+		{
+			description:   "requirement not met reports forward compat hint",
+			requirements:  []string{"cuda>=12.4"},
+			cudaVersion:   "12.2",
+			driverVersion: "535.54.3",
+			expectError:   true,
+			expectedErrorSubstrings: []string{
+				"CUDA 12.4 requires driver >= 550.54.14",
+				"the image can run on driver 535.54.3 using the CUDA 12.4 forward compatibility package",
+			},
+		},
+		{
+			description:   "requirement not met reports forward compat driver",
+			requirements:  []string{"cuda>=13.0"},
+			cudaVersion:   "12.0",
+			driverVersion: "525.60.13",
+			expectError:   true,
+			expectedErrorSubstrings: []string{
+				"CUDA 13.0 requires driver >= 580.65.06",
+				"forward compatibility requires driver >= 535.54.03",
+			},
+		},
+		{
+			description:   "highest CUDA requirement is reported",
+			requirements:  []string{"cuda>=12.0 brand=tesla,cuda>=12.4"},
+			cudaVersion:   "11.8",
+			driverVersion: "520.61.05",
+			expectError:   true,
+			expectedErrorSubstrings: []string{
+				"CUDA 12.4 requires driver >= 550.54.14",
+			},
+		},
+		{
+			description:         "requirement met by forward compat libraries",
+			requirements:        []string{"cuda>=12.4"},
+			cudaVersion:         "12.2",
+			driverVersion:       "535.54.3",
+			compatDriverVersion: "550.54.15",
+		},
+		{
+			description:         "forward compat libraries too old",
+			requirements:        []string{"cuda>=12.8"},
+			cudaVersion:         "12.2",
+			driverVersion:       "535.54.3",
+			compatDriverVersion: "550.54.15",
+			expectError:         true,
+		},
+		{
+			description:         "forward compat not supported on host driver",
+			requirements:        []string{"cuda>=13.0"},
+			cudaVersion:         "12.0",
+			driverVersion:       "525.60.13",
+			compatDriverVersion: "580.65.06",
+			expectError:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r := New(logger, tc.requirements)
+			r.AddVersionProperty(CUDA, tc.cudaVersion)
+			r.AddVersionProperty(DRIVER, tc.driverVersion)
+			r.AddForwardCompatLibraries(tc.compatDriverVersion)
+
+			err := r.Assert()
+			if !tc.expectError {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, s := range tc.expectedErrorSubstrings {
+				require.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func TestHasDeviceConstraints(t *testing.T) {
+	testCases := []struct {
+		requirements []string
+		expected     bool
+	}{
+		{requirements: nil},
+		{requirements: []string{"cuda>=12.2 brand=tesla,driver>=535"}, expected: true},
+		{requirements: []string{"cuda>=12.2", "driver>=535"}},
+		{requirements: []string{"(cuda>=12.2 | driver>=535),!cuda=12.3"}},
+		{requirements: []string{"cuda>=12.2", "(arch>=8.0,memory>=24G)"}, expected: true},
+		{requirements: []string{"!mig=true"}, expected: true},
+		{requirements: []string{"cuda"}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.requirements, ";"), func(t *testing.T) {
+			require.Equal(t, tc.expected, HasDeviceConstraints(tc.requirements))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	// featuresSubcommand is the OCI runtime subcommand that reports the
+	// features supported by the runtime.
+	featuresSubcommand = "features"
+	// featuresKey is the key of the NVIDIA section that is added to the
+	// features reported by the low-level runtime.
+	featuresKey = "nvidia"
+	// versionAnnotation is added to the annotations of the features reported
+	// by the low-level runtime.
+	versionAnnotation = "com.nvidia.container-toolkit.version"
+)
+
+// supportedModes are the modes supported by the NVIDIA Container Runtime.
+var supportedModes = []string{"auto", "cdi", "csv", "legacy"}
+
+// nvidiaFeatures describes the capabilities of the NVIDIA Container Runtime.
+type nvidiaFeatures struct {
+	Version string      `json:"version"`
+	Mode    string      `json:"mode"`
+	Modes   []string    `json:"modes"`
+	CDI     cdiFeatures `json:"cdi"`
+}
+
+type cdiFeatures struct {
+	// Enabled indicates whether CDI devices can be injected. This is the
+	// case for the cdi mode and for the auto mode where the cdi mode is
+	// selected if CDI devices are requested.
+	Enabled            bool     `json:"enabled"`
+	SpecDirs           []string `json:"specDirs,omitempty"`
+	AnnotationPrefixes []string `json:"annotationPrefixes,omitempty"`
+	DefaultKind        string   `json:"defaultKind,omitempty"`
+}
+
+// featuresRuntime reports the features of the low-level runtime with an
+// NVIDIA section added.
+type featuresRuntime struct {
+	logger          logger.Interface
+	cfg             *config.Config
+	lowLevelRuntime oci.Runtime
+	stdout          io.Writer
+}
+
+var _ oci.Runtime = (*featuresRuntime)(nil)
+
+func newFeaturesRuntime(logger logger.Interface, cfg *config.Config, lowLevelRuntime oci.Runtime) oci.Runtime {
+	return &featuresRuntime{
+		logger:          logger,
+		cfg:             cfg,
+		lowLevelRuntime: lowLevelRuntime,
+		stdout:          os.Stdout,
+	}
+}
+
+// Exec runs the features subcommand of the low-level runtime and writes the
+// merged output to stdout.
+func (r *featuresRuntime) Exec(args []string) error {
+	var runtimeArgs []string
+	if len(args) > 1 {
+		runtimeArgs = args[1:]
+	}
+	cmd := exec.Command(r.lowLevelRuntime.String(), runtimeArgs...)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to get features of low-level runtime %v: %w", r.lowLevelRuntime, err)
+	}
+
+	merged, err := r.merge(output)
+	if err != nil {
+		return err
+	}
+	_, err = r.stdout.Write(merged)
+	return err
+}
+
+func (r *featuresRuntime) String() string {
+	return r.lowLevelRuntime.String()
+}
+
+// merge adds the NVIDIA section to the features reported by the low-level
+// runtime. Fields that are not known to the NVIDIA Container Runtime are
+// preserved.
+func (r *featuresRuntime) merge(output []byte) ([]byte, error) {
+	features := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(output, &features); err != nil {
+		return nil, fmt.Errorf("failed to parse features of low-level runtime: %w", err)
+	}
+
+	annotations := make(map[string]string)
+	if raw, ok := features["annotations"]; ok {
+		if err := json.Unmarshal(raw, &annotations); err != nil {
+			return nil, fmt.Errorf("failed to parse annotations of low-level runtime: %w", err)
+		}
+	}
+	annotations[versionAnnotation] = info.GetVersionParts()[0]
+
+	var err error
+	features["annotations"], err = json.Marshal(annotations)
+	if err != nil {
+		return nil, err
+	}
+	features[featuresKey], err = json.Marshal(r.nvidiaFeatures())
+	if err != nil {
+		return nil, err
+	}
+
+	var merged bytes.Buffer
+	encoder := json.NewEncoder(&merged)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(features); err != nil {
+		return nil, fmt.Errorf("failed to encode features: %w", err)
+	}
+	return merged.Bytes(), nil
+}
+
+func (r *featuresRuntime) nvidiaFeatures() nvidiaFeatures {
+	mode := r.cfg.NVIDIAContainerRuntimeConfig.Mode
+	cdi := r.cfg.NVIDIAContainerRuntimeConfig.Modes.CDI
+	return nvidiaFeatures{
+		Version: info.GetVersionParts()[0],
+		Mode:    mode,
+		Modes:   supportedModes,
+		CDI: cdiFeatures{
+			Enabled:            mode == "cdi" || mode == "auto",
+			SpecDirs:           cdi.SpecDirs,
+			AnnotationPrefixes: cdi.AnnotationPrefixes,
+			DefaultKind:        cdi.DefaultKind,
+		},
+	}
+}
+
+// hasFeaturesSubcommand checks whether the features subcommand is specified.
+func hasFeaturesSubcommand(args []string) bool {
+	return oci.GetSubcommand(args) == featuresSubcommand
+}
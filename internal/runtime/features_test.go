@@ -0,0 +1,138 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestHasFeaturesSubcommand(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected bool
+	}{
+		{
+			args: []string{"nvidia-container-runtime"},
+		},
+		{
+			args:     []string{"nvidia-container-runtime", "features"},
+			expected: true,
+		},
+		{
+			args:     []string{"nvidia-container-runtime", "--root", "/run/runc", "--debug", "features"},
+			expected: true,
+		},
+		{
+			args:     []string{"nvidia-container-runtime", "--log=/var/log/runc.log", "features"},
+			expected: true,
+		},
+		{
+			args: []string{"nvidia-container-runtime", "--root", "features", "list"},
+		},
+		{
+			args: []string{"nvidia-container-runtime", "delete", "features"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(filepath.Join(tc.args...), func(t *testing.T) {
+			require.Equal(t, tc.expected, hasFeaturesSubcommand(tc.args))
+		})
+	}
+}
+
+func TestFeaturesRuntimeExec(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	lowLevelRuntimePath := filepath.Join(t.TempDir(), "runc")
+	script := `#!/bin/sh
+echo '{"ociVersionMin":"1.0.0","ociVersionMax":"1.2.0","annotations":{"org.opencontainers.runc.version":"1.2.0"}}'
+`
+	require.NoError(t, os.WriteFile(lowLevelRuntimePath, []byte(script), 0755))
+	lowLevelRuntime, err := oci.NewRuntimeForPath(logger, lowLevelRuntimePath)
+	require.NoError(t, err)
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	cfg.NVIDIAContainerRuntimeConfig.Mode = "cdi"
+
+	stdout := &bytes.Buffer{}
+	r := &featuresRuntime{
+		logger:          logger,
+		cfg:             cfg,
+		lowLevelRuntime: lowLevelRuntime,
+		stdout:          stdout,
+	}
+	require.NoError(t, r.Exec([]string{"nvidia-container-runtime", "features"}))
+
+	var features struct {
+		OCIVersionMin string            `json:"ociVersionMin"`
+		OCIVersionMax string            `json:"ociVersionMax"`
+		Annotations   map[string]string `json:"annotations"`
+		NVIDIA        nvidiaFeatures    `json:"nvidia"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &features))
+
+	require.Equal(t, "1.0.0", features.OCIVersionMin)
+	require.Equal(t, "1.2.0", features.OCIVersionMax)
+	require.Equal(t, "1.2.0", features.Annotations["org.opencontainers.runc.version"])
+	require.Contains(t, features.Annotations, versionAnnotation)
+	require.Equal(t,
+		nvidiaFeatures{
+			Version: features.Annotations[versionAnnotation],
+			Mode:    "cdi",
+			Modes:   []string{"auto", "cdi", "csv", "legacy"},
+			CDI: cdiFeatures{
+				Enabled:            true,
+				SpecDirs:           []string{"/etc/cdi", "/var/run/cdi"},
+				AnnotationPrefixes: []string{"cdi.k8s.io/"},
+				DefaultKind:        "nvidia.com/gpu",
+			},
+		},
+		features.NVIDIA,
+	)
+}
+
+func TestFeaturesRuntimeExecInvalidOutput(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	lowLevelRuntimePath := filepath.Join(t.TempDir(), "runc")
+	require.NoError(t, os.WriteFile(lowLevelRuntimePath, []byte("#!/bin/sh\necho 'unknown command'\n"), 0755))
+	lowLevelRuntime, err := oci.NewRuntimeForPath(logger, lowLevelRuntimePath)
+	require.NoError(t, err)
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+
+	r := &featuresRuntime{
+		logger:          logger,
+		cfg:             cfg,
+		lowLevelRuntime: lowLevelRuntime,
+		stdout:          &bytes.Buffer{},
+	}
+	require.ErrorContains(t, r.Exec([]string{"nvidia-container-runtime", "features"}), "failed to parse features of low-level runtime")
+}
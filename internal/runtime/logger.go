@@ -28,9 +28,15 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 )
 
+// binaryName is the name used to look up the log file for the NVIDIA Container
+// Runtime in the shared logging config.
+const binaryName = "nvidia-container-runtime"
+
 // Logger adds a way to manage output to a log file to a logrus.Logger
 type Logger struct {
 	logger.Interface
@@ -47,8 +53,23 @@ func NewLogger() *Logger {
 
 // Update constructs a Logger with a preddefined formatter
 func (l *Logger) Update(filename string, logLevel string, argv []string) {
+	l.UpdateWithConfig(config.LoggingConfig{}, filename, logLevel, argv)
+}
+
+// UpdateWithConfig constructs a Logger as for Update, also applying the
+// logging config shared by all binaries. A log level in the shared config takes
+// precedence over the specified level, and the format requested in the
+// arguments takes precedence over the configured format. For create commands,
+// the container ID and bundle are added to all records.
+func (l *Logger) UpdateWithConfig(cfg config.LoggingConfig, filename string, logLevel string, argv []string) {
 
 	configFromArgs := parseArgs(argv)
+	if cfg.Level != "" {
+		logLevel = cfg.Level
+	}
+	if configFromArgs.format == "" {
+		configFromArgs.format = cfg.Format
+	}
 
 	level, logLevelError := configFromArgs.getLevel(logLevel)
 	defer func() {
@@ -70,6 +91,16 @@ func (l *Logger) Update(filename string, logLevel string, argv []string) {
 			logFiles = append(logFiles, configLogFile)
 		}
 
+		if sharedFilename := cfg.GetFile(binaryName); sharedFilename != filename {
+			sharedLogFile, err := createLogFile(sharedFilename)
+			if err != nil {
+				argLogFileError = errors.Join(argLogFileError, err)
+			}
+			if sharedLogFile != nil {
+				logFiles = append(logFiles, sharedLogFile)
+			}
+		}
+
 		argLogFile, err := createLogFile(configFromArgs.file)
 		if argLogFile != nil {
 			logFiles = append(logFiles, argLogFile)
@@ -105,6 +136,11 @@ func (l *Logger) Update(filename string, logLevel string, argv []string) {
 		newLogger.SetFormatter(new(logrus.JSONFormatter))
 	}
 
+	if oci.HasCreateSubcommand(argv) {
+		bundleDir, _ := oci.GetBundleDirFromArgs(argv)
+		logger.AddContainerFields(newLogger, oci.GetContainerID(argv), bundleDir)
+	}
+
 	switch len(logFiles) {
 	case 0:
 		newLogger.SetOutput(io.Discard)
@@ -17,10 +17,16 @@
 package runtime
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
 func TestLogger(t *testing.T) {
@@ -34,3 +40,38 @@ func TestLogger(t *testing.T) {
 	lp := l.previousLogger.(*logrus.Logger)
 	require.Equal(t, logrus.InfoLevel, lp.Level)
 }
+
+func TestLoggerWithConfig(t *testing.T) {
+	logDir := t.TempDir()
+	runtimeLog := filepath.Join(logDir, "nvidia-container-runtime.log")
+
+	l := NewLogger()
+	l.UpdateWithConfig(
+		config.LoggingConfig{
+			Format: "json",
+			Level:  "warning",
+			Files: map[string]string{
+				"nvidia-container-runtime": runtimeLog,
+			},
+		},
+		"",
+		"info",
+		[]string{"nvidia-container-runtime", "create", "--bundle", "/run/bundle", "ctr"},
+	)
+
+	ll := l.Interface.(*logrus.Logger)
+	require.Equal(t, logrus.WarnLevel, ll.Level)
+
+	l.Infof("not logged")
+	l.Warningf("logged")
+	require.NoError(t, l.Reset())
+
+	contents, err := os.ReadFile(runtimeLog)
+	require.NoError(t, err)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(contents, &record))
+	require.Equal(t, "logged", record["msg"])
+	require.Equal(t, "ctr", record[logger.ContainerIDField])
+	require.Equal(t, "/run/bundle", record[logger.BundleField])
+}
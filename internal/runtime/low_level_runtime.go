@@ -0,0 +1,282 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerstate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// LowLevelRuntimeAnnotation is the container annotation that selects one of
+// the allow-listed low-level runtimes for a container.
+const LowLevelRuntimeAnnotation = "nvidia.com/low-level-runtime"
+
+// lowLevelRuntimeArtifact is the name of the per-container artifact in which
+// the resolved low-level runtime of each container that selects one is
+// recorded. This ensures that all the commands for a container are forwarded
+// to the same low-level runtime.
+const lowLevelRuntimeArtifact = "low-level-runtime"
+
+// containerIDPattern matches valid container IDs. This is the same pattern
+// that is used by runc.
+var containerIDPattern = regexp.MustCompile(`^[\w+-\.]+$`)
+
+// lowLevelRuntimeCache records the path of the low-level runtime selected for
+// each container in the per-container state store.
+type lowLevelRuntimeCache struct {
+	logger logger.Interface
+	store  *containerstate.Store
+}
+
+// newLowLevelRuntime returns the low-level runtime for the specified command.
+// For the create command, the runtime selected by the annotation of the
+// container is used and recorded. For other commands, the recorded runtime
+// for the container is used. If no runtime is selected or recorded, the
+// first of the configured runtimes that exists is used.
+func newLowLevelRuntime(logger logger.Interface, cfg *config.Config, argv []string) (oci.Runtime, error) {
+	cache := &lowLevelRuntimeCache{
+		logger: logger,
+		store:  containerstate.New(containerstate.DefaultRoot),
+	}
+	return cache.newLowLevelRuntime(cfg, argv)
+}
+
+func (c *lowLevelRuntimeCache) newLowLevelRuntime(cfg *config.Config, argv []string) (oci.Runtime, error) {
+	if oci.HasCreateSubcommand(argv) {
+		return c.newLowLevelRuntimeForCreate(cfg, argv)
+	}
+
+	containerID, path := c.lookup(argv)
+	if path == "" {
+		return oci.NewLowLevelRuntime(c.logger, cfg.NVIDIAContainerRuntimeConfig.Runtimes)
+	}
+	c.logger.Debugf("Using low-level runtime %v recorded for container %v", path, containerID)
+	runtime, err := oci.NewRuntimeForPath(c.logger, path)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing low-level runtime for container %v: %w", containerID, err)
+	}
+	if hasDeleteSubcommand(argv) {
+		return &deletingRuntime{Runtime: runtime, cache: c, containerID: containerID}, nil
+	}
+	return runtime, nil
+}
+
+func (c *lowLevelRuntimeCache) newLowLevelRuntimeForCreate(cfg *config.Config, argv []string) (oci.Runtime, error) {
+	ociSpec, err := oci.NewSpec(c.logger, argv)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing OCI specification: %v", err)
+	}
+	rawSpec, err := ociSpec.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OCI spec: %v", err)
+	}
+
+	name := rawSpec.Annotations[LowLevelRuntimeAnnotation]
+	if name == "" {
+		return oci.NewLowLevelRuntime(c.logger, cfg.NVIDIAContainerRuntimeConfig.Runtimes)
+	}
+	candidates, ok := cfg.NVIDIAContainerRuntimeConfig.LowLevelRuntimes[name]
+	if !ok {
+		return nil, fmt.Errorf("low-level runtime %q requested in %v annotation is not allowed", name, LowLevelRuntimeAnnotation)
+	}
+	runtime, err := oci.NewLowLevelRuntime(c.logger, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing low-level runtime %q: %w", name, err)
+	}
+	c.logger.Debugf("Using low-level runtime %q (%v) requested in %v annotation", name, runtime, LowLevelRuntimeAnnotation)
+
+	containerID := oci.GetContainerID(argv)
+	if err := c.record(containerID, runtime.String()); err != nil {
+		return nil, err
+	}
+	return &creatingRuntime{Runtime: runtime, cache: c, containerID: containerID}, nil
+}
+
+// record records the path of the low-level runtime for the specified
+// container. The record is written to a temporary file that is then renamed
+// so that a partially written record is never read. The record is tracked as
+// an artifact of the container so that it is also removed by the cleanup hook.
+func (c *lowLevelRuntimeCache) record(containerID string, path string) error {
+	if !isValidContainerID(containerID) {
+		return fmt.Errorf("invalid container ID %q", containerID)
+	}
+	dir, err := c.store.Dir(containerID)
+	if err != nil {
+		return fmt.Errorf("failed to create low-level runtime cache: %w", err)
+	}
+	filename, err := c.store.ArtifactPath(containerID, lowLevelRuntimeArtifact)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+lowLevelRuntimeArtifact+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create low-level runtime record for container %v: %w", containerID, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.WriteString(path); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to record low-level runtime for container %v: %w", containerID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to record low-level runtime for container %v: %w", containerID, err)
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("failed to record low-level runtime for container %v: %w", containerID, err)
+	}
+	if err := c.store.Track(containerID, filename); err != nil {
+		return errors.Join(
+			fmt.Errorf("failed to track low-level runtime for container %v: %w", containerID, err),
+			c.remove(containerID),
+		)
+	}
+	return nil
+}
+
+// lookup returns the container ID and the path of the low-level runtime
+// recorded for the container referenced in the specified arguments. The
+// container ID is the first positional argument following the subcommand.
+func (c *lowLevelRuntimeCache) lookup(argv []string) (string, string) {
+	containerID := oci.GetContainerID(argv)
+	if !isValidContainerID(containerID) {
+		return "", ""
+	}
+	filename, err := c.store.ArtifactPath(containerID, lowLevelRuntimeArtifact)
+	if err != nil {
+		return "", ""
+	}
+	contents, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ""
+	}
+	if err != nil {
+		c.logger.Warningf("Failed to read low-level runtime for container %v: %v", containerID, err)
+		return "", ""
+	}
+	return containerID, string(contents)
+}
+
+// isValidContainerID checks whether the specified container ID is valid and
+// can safely be used as a file name.
+func isValidContainerID(containerID string) bool {
+	if containerID == "." || containerID == ".." || strings.HasPrefix(containerID, ".") {
+		return false
+	}
+	return containerIDPattern.MatchString(containerID)
+}
+
+// remove removes the record, along with any other state, for the specified
+// container.
+func (c *lowLevelRuntimeCache) remove(containerID string) error {
+	if err := c.store.Cleanup(containerID); err != nil {
+		return fmt.Errorf("failed to remove low-level runtime for container %v: %w", containerID, err)
+	}
+	return nil
+}
+
+// A creatingRuntime runs the create command of the low-level runtime as a
+// child process instead of exec'ing it. This allows the recorded low-level
+// runtime for the container to be removed if the container is not created.
+type creatingRuntime struct {
+	oci.Runtime
+	cache       *lowLevelRuntimeCache
+	containerID string
+}
+
+func (r *creatingRuntime) Exec(args []string) error {
+	if err := runLowLevelRuntime(r.Runtime, args); err != nil {
+		return errors.Join(
+			fmt.Errorf("failed to create container %v: %w", r.containerID, err),
+			r.cache.remove(r.containerID),
+		)
+	}
+	return nil
+}
+
+// A deletingRuntime runs the delete command of the low-level runtime as a
+// child process instead of exec'ing it. This allows the recorded low-level
+// runtime for the container to be removed once the container is deleted.
+type deletingRuntime struct {
+	oci.Runtime
+	cache       *lowLevelRuntimeCache
+	containerID string
+}
+
+func (r *deletingRuntime) Exec(args []string) error {
+	if err := runLowLevelRuntime(r.Runtime, args); err != nil {
+		return fmt.Errorf("failed to delete container %v: %w", r.containerID, err)
+	}
+	return r.cache.remove(r.containerID)
+}
+
+// runLowLevelRuntime runs the specified low-level runtime as a child process
+// with the standard streams of the current process.
+func runLowLevelRuntime(runtime oci.Runtime, args []string) error {
+	cmd, err := newLowLevelRuntimeCommand(runtime, args)
+	if err != nil {
+		return err
+	}
+	return cmd.Run()
+}
+
+// newLowLevelRuntimeCommand constructs the command to run the specified
+// low-level runtime as a child process. In addition to the standard streams,
+// the file descriptors that are passed to the container using the
+// preserve-fds flag are inherited by the child process. These start at file
+// descriptor 3.
+func newLowLevelRuntimeCommand(runtime oci.Runtime, args []string) (*exec.Cmd, error) {
+	preserveFDs, err := oci.GetPreserveFDs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var runtimeArgs []string
+	if len(args) > 1 {
+		runtimeArgs = args[1:]
+	}
+	//nolint:gosec // The arguments are forwarded to the low-level runtime as is the case for exec.
+	cmd := exec.Command(runtime.String(), runtimeArgs...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	for fd := 3; fd < 3+preserveFDs; fd++ {
+		cmd.ExtraFiles = append(cmd.ExtraFiles, inheritedFile(fd))
+	}
+	return cmd, nil
+}
+
+// inheritedFile returns the file for the specified file descriptor of the
+// current process. This is a variable so that it can be overridden in tests.
+var inheritedFile = func(fd int) *os.File {
+	return os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd))
+}
+
+// hasDeleteSubcommand checks whether the delete subcommand is specified.
+func hasDeleteSubcommand(args []string) bool {
+	return oci.GetSubcommand(args) == "delete"
+}
@@ -0,0 +1,211 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/containerstate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestLowLevelRuntimeSelection(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	binDir := t.TempDir()
+	runcPath := filepath.Join(binDir, "runc")
+	runscPath := filepath.Join(binDir, "runsc")
+	for _, path := range []string{runcPath, runscPath} {
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0755))
+	}
+	failingPath := filepath.Join(binDir, "failing")
+	require.NoError(t, os.WriteFile(failingPath, []byte("#!/bin/sh\nexit 1\n"), 0755))
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	cfg.NVIDIAContainerRuntimeConfig.Runtimes = []string{runcPath}
+	cfg.NVIDIAContainerRuntimeConfig.LowLevelRuntimes = map[string][]string{
+		"runsc":   {"missing-runsc", runscPath},
+		"failing": {failingPath},
+	}
+
+	newBundle := func(t *testing.T, annotations map[string]string) string {
+		bundleDir := t.TempDir()
+		contents, err := json.Marshal(specs.Spec{Annotations: annotations})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(bundleDir, "config.json"), contents, 0600))
+		return bundleDir
+	}
+
+	t.Run("default runtime is used without annotation", func(t *testing.T) {
+		cache := &lowLevelRuntimeCache{logger: logger, store: containerstate.New(t.TempDir())}
+		bundleDir := newBundle(t, nil)
+
+		runtime, err := cache.newLowLevelRuntime(cfg, []string{"runtime", "create", "--bundle", bundleDir, "ctr"})
+		require.NoError(t, err)
+		require.Equal(t, runcPath, runtime.String())
+		require.NoFileExists(t, recordPath(t, cache, "ctr"))
+	})
+
+	t.Run("disallowed runtime returns error", func(t *testing.T) {
+		cache := &lowLevelRuntimeCache{logger: logger, store: containerstate.New(t.TempDir())}
+		bundleDir := newBundle(t, map[string]string{LowLevelRuntimeAnnotation: "crun"})
+
+		_, err := cache.newLowLevelRuntime(cfg, []string{"runtime", "create", "--bundle", bundleDir, "ctr"})
+		require.EqualError(t, err, `low-level runtime "crun" requested in nvidia.com/low-level-runtime annotation is not allowed`)
+	})
+
+	t.Run("selected runtime is used for all commands", func(t *testing.T) {
+		cache := &lowLevelRuntimeCache{logger: logger, store: containerstate.New(t.TempDir())}
+		bundleDir := newBundle(t, map[string]string{LowLevelRuntimeAnnotation: "runsc"})
+
+		runtime, err := cache.newLowLevelRuntime(cfg, []string{"runtime", "--root", "/run/runc", "create", "--bundle", bundleDir, "ctr"})
+		require.NoError(t, err)
+		require.Equal(t, runscPath, runtime.String())
+		artifacts, err := cache.store.Artifacts("ctr")
+		require.NoError(t, err)
+		require.Equal(t, []string{recordPath(t, cache, "ctr")}, artifacts)
+
+		runtime, err = cache.newLowLevelRuntime(cfg, []string{"runtime", "--root", "/run/runc", "start", "ctr"})
+		require.NoError(t, err)
+		require.Equal(t, runscPath, runtime.String())
+
+		runtime, err = cache.newLowLevelRuntime(cfg, []string{"runtime", "start", "other"})
+		require.NoError(t, err)
+		require.Equal(t, runcPath, runtime.String())
+
+		runtime, err = cache.newLowLevelRuntime(cfg, []string{"runtime", "exec", "--cwd", "ctr", "other", "sh"})
+		require.NoError(t, err)
+		require.Equal(t, runcPath, runtime.String())
+
+		runtime, err = cache.newLowLevelRuntime(cfg, []string{"runtime", "--root", "/run/runc", "delete", "--force", "ctr"})
+		require.NoError(t, err)
+		require.IsType(t, &deletingRuntime{}, runtime)
+		require.FileExists(t, recordPath(t, cache, "ctr"))
+
+		require.NoError(t, runtime.Exec([]string{"runtime", "--root", "/run/runc", "delete", "--force", "ctr"}))
+		require.NoFileExists(t, recordPath(t, cache, "ctr"))
+	})
+	t.Run("record is removed if create fails", func(t *testing.T) {
+		cache := &lowLevelRuntimeCache{logger: logger, store: containerstate.New(t.TempDir())}
+		bundleDir := newBundle(t, map[string]string{LowLevelRuntimeAnnotation: "failing"})
+		argv := []string{"runtime", "create", "--bundle", bundleDir, "ctr"}
+
+		runtime, err := cache.newLowLevelRuntime(cfg, argv)
+		require.NoError(t, err)
+		require.FileExists(t, recordPath(t, cache, "ctr"))
+
+		require.ErrorContains(t, runtime.Exec(argv), "failed to create container ctr")
+		require.NoFileExists(t, recordPath(t, cache, "ctr"))
+		artifacts, err := cache.store.Artifacts("ctr")
+		require.NoError(t, err)
+		require.Empty(t, artifacts)
+	})
+}
+
+func recordPath(t *testing.T, cache *lowLevelRuntimeCache, containerID string) string {
+	path, err := cache.store.ArtifactPath(containerID, lowLevelRuntimeArtifact)
+	require.NoError(t, err)
+	return path
+}
+
+func TestNewLowLevelRuntimeCommand(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	// The runtime writes the name of each preserved file descriptor to it.
+	runtimePath := filepath.Join(t.TempDir(), "runtime")
+	require.NoError(t, os.WriteFile(runtimePath, []byte(`#!/bin/sh
+for fd in 3 4; do
+	if [ -e /proc/$$/fd/$fd ]; then
+		echo $fd >&$fd
+	fi
+done
+`), 0755))
+	runtime, err := oci.NewRuntimeForPath(logger, runtimePath)
+	require.NoError(t, err)
+
+	// Pipes are used in place of the file descriptors of the current process
+	// since these are owned by the test.
+	defer func(f func(int) *os.File) {
+		inheritedFile = f
+	}(inheritedFile)
+	readers := make(map[int]*os.File)
+	inheritedFile = func(fd int) *os.File {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		readers[fd] = r
+		return w
+	}
+
+	testCases := []struct {
+		description   string
+		args          []string
+		expectedFDs   map[int]string
+		expectedError string
+	}{
+		{
+			description: "no file descriptors are preserved by default",
+			args:        []string{"runtime", "create", "--bundle", "/bundle", "ctr"},
+			expectedFDs: map[int]string{},
+		},
+		{
+			description: "preserved file descriptors are inherited",
+			args:        []string{"runtime", "create", "--preserve-fds", "2", "--bundle", "/bundle", "ctr"},
+			expectedFDs: map[int]string{3: "3\n", 4: "4\n"},
+		},
+		{
+			description:   "invalid preserve-fds value is an error",
+			args:          []string{"runtime", "create", "--preserve-fds=many", "ctr"},
+			expectedError: `invalid preserve-fds value "many"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			clear(readers)
+
+			cmd, err := newLowLevelRuntimeCommand(runtime, tc.args)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.args[1:], cmd.Args[1:])
+
+			require.NoError(t, cmd.Run())
+
+			fds := make(map[int]string)
+			for fd, r := range readers {
+				require.NoError(t, cmd.ExtraFiles[fd-3].Close())
+				contents, err := io.ReadAll(r)
+				require.NoError(t, err)
+				require.NoError(t, r.Close())
+				fds[fd] = string(contents)
+			}
+			require.Equal(t, tc.expectedFDs, fds)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	// ProfileAnnotation is the container annotation used to select a profile
+	// if no profile is selected by the runtime handler.
+	ProfileAnnotation = "nvidia.com/runtime-profile"
+
+	// profileExecutablePrefix is the prefix of executable names that select a
+	// profile. For example, nvidia-container-runtime.restricted selects the
+	// restricted profile.
+	profileExecutablePrefix = binaryName + "."
+)
+
+// removeProfileFlag removes the global --profile flag from the specified
+// arguments and returns its value. The flag is removed since it is not
+// supported by the low-level runtime. Only the global flags preceding the
+// subcommand are considered so that the arguments of the subcommand are
+// forwarded unchanged. The following are supported:
+// --profile{{SEP}}PROFILE
+// -profile{{SEP}}PROFILE
+// where {{SEP}} is either ' ' or '='
+func removeProfileFlag(argv []string) ([]string, string, error) {
+	if len(argv) == 0 {
+		return argv, "", nil
+	}
+
+	var profile string
+	filtered := []string{argv[0]}
+	for i := 1; i < len(argv); i++ {
+		arg := argv[i]
+		if !strings.HasPrefix(arg, "-") {
+			// This is the subcommand.
+			filtered = append(filtered, argv[i:]...)
+			break
+		}
+		parts := strings.SplitN(arg, "=", 2)
+		if strings.TrimLeft(parts[0], "-") != "profile" {
+			filtered = append(filtered, arg)
+			if oci.IsGlobalFlagWithValue(arg) && i+1 < len(argv) {
+				filtered = append(filtered, argv[i+1])
+				i++
+			}
+			continue
+		}
+		if len(parts) == 2 {
+			profile = parts[1]
+			continue
+		}
+		if i+1 >= len(argv) {
+			return nil, "", fmt.Errorf("profile option requires an argument")
+		}
+		profile = argv[i+1]
+		i++
+	}
+	return filtered, profile, nil
+}
+
+// getProfileFromExecutable returns the profile selected by the name of the
+// executable, if any. Wrappers installed by the NVIDIA Container Toolkit
+// invoke the executable with a .real suffix.
+func getProfileFromExecutable(argv0 string) string {
+	name := strings.TrimSuffix(filepath.Base(argv0), ".real")
+	if !strings.HasPrefix(name, profileExecutablePrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(name, profileExecutablePrefix)
+}
+
+// selectProfile returns the profile for the command. A profile specified by
+// the --profile flag takes precedence over a profile selected by the name of
+// the executable. Since these are controlled by the runtime handler, the
+// profile annotation of a container is only considered if neither is set.
+// This ensures that a container cannot escape the profile of a restricted
+// runtime handler.
+//
+// Since the annotations of a container are controlled by its owner, an
+// annotation may only select one of the profiles listed in the
+// annotation-profiles config option. An error is returned for other profiles.
+func selectProfile(logger logger.Interface, cfg *config.Config, argv []string, profileFlag string) (string, error) {
+	if profileFlag != "" {
+		return profileFlag, nil
+	}
+	if len(argv) > 0 {
+		if profile := getProfileFromExecutable(argv[0]); profile != "" {
+			return profile, nil
+		}
+	}
+	if !oci.HasCreateSubcommand(argv) {
+		return "", nil
+	}
+
+	spec, err := oci.NewSpec(logger, argv)
+	if err != nil {
+		return "", nil
+	}
+	// Errors in loading the spec are reported when the spec is modified.
+	rawSpec, err := spec.Load()
+	if err != nil {
+		return "", nil
+	}
+	profile := rawSpec.Annotations[ProfileAnnotation]
+	if profile == "" {
+		return "", nil
+	}
+	if !slices.Contains(cfg.AnnotationProfiles, profile) {
+		return "", fmt.Errorf("profile %q may not be selected using the %v annotation", profile, ProfileAnnotation)
+	}
+	return profile, nil
+}
+
+// applyProfile applies the specified profile to the config. This is a no-op
+// if no profile is specified.
+func applyProfile(logger logger.Interface, cfg *config.Config, profile string) error {
+	if profile == "" {
+		return nil
+	}
+	logger.Debugf("Using profile %q", profile)
+	if err := cfg.ApplyProfile(profile); err != nil {
+		return fmt.Errorf("failed to apply profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+func TestRemoveProfileFlag(t *testing.T) {
+	testCases := []struct {
+		description     string
+		argv            []string
+		expectedArgv    []string
+		expectedProfile string
+		expectedError   bool
+	}{
+		{
+			description:  "no profile flag",
+			argv:         []string{"nvidia-container-runtime", "create", "--bundle", "/bundle", "ctr"},
+			expectedArgv: []string{"nvidia-container-runtime", "create", "--bundle", "/bundle", "ctr"},
+		},
+		{
+			description:     "profile flag with separate value",
+			argv:            []string{"nvidia-container-runtime", "--profile", "restricted", "create", "ctr"},
+			expectedArgv:    []string{"nvidia-container-runtime", "create", "ctr"},
+			expectedProfile: "restricted",
+		},
+		{
+			description:     "single dash profile flag with equals",
+			argv:            []string{"nvidia-container-runtime", "-profile=restricted", "create", "ctr"},
+			expectedArgv:    []string{"nvidia-container-runtime", "create", "ctr"},
+			expectedProfile: "restricted",
+		},
+		{
+			description:     "global flags with values are forwarded",
+			argv:            []string{"nvidia-container-runtime", "--root", "/run/runc", "--profile=restricted", "--debug", "create", "ctr"},
+			expectedArgv:    []string{"nvidia-container-runtime", "--root", "/run/runc", "--debug", "create", "ctr"},
+			expectedProfile: "restricted",
+		},
+		{
+			description:  "global flag value is not a profile flag",
+			argv:         []string{"nvidia-container-runtime", "--log", "--profile", "create", "ctr"},
+			expectedArgv: []string{"nvidia-container-runtime", "--log", "--profile", "create", "ctr"},
+		},
+		{
+			description:  "subcommand arguments are forwarded",
+			argv:         []string{"nvidia-container-runtime", "exec", "ctr", "app", "--profile", "restricted"},
+			expectedArgv: []string{"nvidia-container-runtime", "exec", "ctr", "app", "--profile", "restricted"},
+		},
+		{
+			description:   "profile flag without value",
+			argv:          []string{"nvidia-container-runtime", "--profile"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			argv, profile, err := removeProfileFlag(tc.argv)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedArgv, argv)
+			require.Equal(t, tc.expectedProfile, profile)
+		})
+	}
+}
+
+func TestSelectProfile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	bundle := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(bundle, "config.json"),
+		[]byte(`{"annotations": {"nvidia.com/runtime-profile": "annotated"}}`),
+		0600,
+	))
+
+	testCases := []struct {
+		description        string
+		argv               []string
+		profileFlag        string
+		annotationProfiles []string
+		expectedProfile    string
+		expectedError      bool
+	}{
+		{
+			description:     "flag takes precedence",
+			argv:            []string{"/usr/bin/nvidia-container-runtime.cdi", "create", "--bundle", bundle, "ctr"},
+			profileFlag:     "restricted",
+			expectedProfile: "restricted",
+		},
+		{
+			description:     "executable name selects profile",
+			argv:            []string{"/usr/bin/nvidia-container-runtime.restricted", "create", "--bundle", bundle, "ctr"},
+			expectedProfile: "restricted",
+		},
+		{
+			description:     "wrapped executable name selects profile",
+			argv:            []string{"/usr/local/nvidia/toolkit/nvidia-container-runtime.restricted.real", "create", "--bundle", bundle, "ctr"},
+			expectedProfile: "restricted",
+		},
+		{
+			description:        "annotation selects allowed profile",
+			argv:               []string{"/usr/local/nvidia/toolkit/nvidia-container-runtime.real", "create", "--bundle", bundle, "ctr"},
+			annotationProfiles: []string{"annotated"},
+			expectedProfile:    "annotated",
+		},
+		{
+			description:   "annotation may not select profiles by default",
+			argv:          []string{"/usr/local/nvidia/toolkit/nvidia-container-runtime.real", "create", "--bundle", bundle, "ctr"},
+			expectedError: true,
+		},
+		{
+			description:        "annotation may not select other profiles",
+			argv:               []string{"/usr/bin/nvidia-container-runtime", "create", "--bundle", bundle, "ctr"},
+			annotationProfiles: []string{"restricted"},
+			expectedError:      true,
+		},
+		{
+			description: "annotation is ignored for other commands",
+			argv:        []string{"/usr/bin/nvidia-container-runtime", "delete", "ctr"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{AnnotationProfiles: tc.annotationProfiles}
+			profile, err := selectProfile(logger, cfg, tc.argv, tc.profileFlag)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedProfile, profile)
+		})
+	}
+}
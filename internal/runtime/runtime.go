@@ -27,6 +27,8 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 )
 
 // Run is an entry point that allows for idiomatic handling of errors
@@ -38,6 +40,11 @@ func (r rt) Run(argv []string) (rerr error) {
 		}
 	}()
 
+	argv, profileFlag, err := removeProfileFlag(argv)
+	if err != nil {
+		return err
+	}
+
 	printVersion := hasVersionFlag(argv)
 	if printVersion {
 		fmt.Printf("%v version %v\n", "NVIDIA Container Runtime", info.GetVersionString(fmt.Sprintf("spec: %v", specs.Version)))
@@ -47,7 +54,8 @@ func (r rt) Run(argv []string) (rerr error) {
 	if err != nil {
 		return fmt.Errorf("error loading config: %v", err)
 	}
-	r.logger.Update(
+	r.logger.UpdateWithConfig(
+		cfg.Logging,
 		cfg.NVIDIAContainerRuntimeConfig.DebugFilePath,
 		cfg.NVIDIAContainerRuntimeConfig.LogLevel,
 		argv,
@@ -61,15 +69,66 @@ func (r rt) Run(argv []string) (rerr error) {
 		}
 	}()
 
+	profile, err := selectProfile(r.logger, cfg, argv, profileFlag)
+	if err != nil {
+		return err
+	}
+	if err := applyProfile(r.logger, cfg, profile); err != nil {
+		return err
+	}
+
+	// Only the create command is traced since this is the only command that
+	// modifies the OCI specification.
+	var telemetry *createTelemetry
+	if oci.HasCreateSubcommand(argv) {
+		tracer := tracing.NewFromConfig(r.logger, binaryName, cfg.Tracing)
+		span := tracer.Start(binaryName + " create")
+		span.SetAttribute(tracing.ContainerIDAttribute, oci.GetContainerID(argv))
+		if bundleDir, err := oci.GetBundleDirFromArgs(argv); err == nil {
+			span.SetAttribute(tracing.BundleAttribute, bundleDir)
+		}
+		telemetry = &createTelemetry{logger: r.logger, cfg: cfg, tracer: tracer}
+		// The telemetry is exported here if the low-level runtime is not
+		// exec'ed.
+		defer func() {
+			span.RecordError(rerr)
+			telemetry.export(rerr)
+		}()
+	}
+
 	// We apply some config updates here to ensure that the config is valid in
 	// all cases.
 	if r.modeOverride != "" {
 		cfg.NVIDIAContainerRuntimeConfig.Mode = r.modeOverride
 	}
+
+	// The features subcommand only reports the capabilities of the runtime
+	// and does not require the driver. It is handled before any further
+	// setup is performed.
+	if hasFeaturesSubcommand(argv) {
+		lowLevelRuntime, err := oci.NewLowLevelRuntime(r.logger, cfg.NVIDIAContainerRuntimeConfig.Runtimes)
+		if err != nil {
+			return fmt.Errorf("error constructing low-level runtime: %v", err)
+		}
+		r.logger.Tracef("Reporting features of low-level runtime %v", lowLevelRuntime.String())
+		return newFeaturesRuntime(r.logger, cfg, lowLevelRuntime).Exec(argv)
+	}
+
 	//nolint:staticcheck  // TODO(elezar): We should swith the nvidia-container-runtime from using nvidia-ctk to using nvidia-cdi-hook.
 	cfg.NVIDIACTKConfig.Path = config.ResolveNVIDIACTKPath(&logger.NullLogger{}, cfg.NVIDIACTKConfig.Path)
 	cfg.NVIDIAContainerRuntimeHookConfig.Path = config.ResolveNVIDIAContainerRuntimeHookPath(&logger.NullLogger{}, cfg.NVIDIAContainerRuntimeHookConfig.Path)
 
+	// The driver root is only required to modify the OCI specification. It is
+	// therefore only discovered for the create command and the result is
+	// passed on to the NVIDIA Container Runtime Hook where required.
+	if root.IsAuto(cfg.NVIDIAContainerCLIConfig.Root) && oci.HasCreateSubcommand(argv) {
+		driverRoot, err := root.DiscoverDriverRoot(root.WithAutoDiscoveryLogger(r.logger))
+		if err != nil {
+			r.logger.Warningf("Failed to discover driver root: %v; using %v", err, driverRoot)
+		}
+		cfg.NVIDIAContainerCLIConfig.Root = driverRoot
+	}
+
 	// Log the config at Trace to allow for debugging if required.
 	r.logger.Tracef("Running with config: %+v", cfg)
 
@@ -79,7 +138,7 @@ func (r rt) Run(argv []string) (rerr error) {
 	)
 
 	r.logger.Tracef("Command line arguments: %v", argv)
-	runtime, err := newNVIDIAContainerRuntime(r.logger, cfg, argv, driver)
+	runtime, err := newNVIDIAContainerRuntime(r.logger, cfg, argv, driver, profile, telemetry)
 	if err != nil {
 		return fmt.Errorf("failed to create NVIDIA Container Runtime: %v", err)
 	}
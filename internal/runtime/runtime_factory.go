@@ -26,11 +26,15 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 )
 
-// newNVIDIAContainerRuntime is a factory method that constructs a runtime based on the selected configuration and specified logger
-func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv []string, driver *root.Driver) (oci.Runtime, error) {
-	lowLevelRuntime, err := oci.NewLowLevelRuntime(logger, cfg.NVIDIAContainerRuntimeConfig.Runtimes)
+// newNVIDIAContainerRuntime is a factory method that constructs a runtime based on the selected configuration and specified logger.
+// The specified telemetry is exported before the low-level runtime is invoked.
+// The selected profile, if any, is passed to the NVIDIA Container Runtime Hook
+// in legacy mode.
+func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv []string, driver *root.Driver, profile string, telemetry *createTelemetry) (oci.Runtime, error) {
+	lowLevelRuntime, err := newLowLevelRuntime(logger, cfg, argv)
 	if err != nil {
 		return nil, fmt.Errorf("error constructing low-level runtime: %v", err)
 	}
@@ -41,15 +45,41 @@ func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv
 		return lowLevelRuntime, nil
 	}
 
+	// The per-container state recorded for containers that select a low-level
+	// runtime is also removed by a poststop hook in case the container is not
+	// deleted through the NVIDIA Container Runtime.
+	_, tracksState := lowLevelRuntime.(*creatingRuntime)
+
+	var tracer *tracing.Tracer
+	if telemetry != nil {
+		tracer = telemetry.tracer
+		lowLevelRuntime = &exportingRuntime{Runtime: lowLevelRuntime, telemetry: telemetry}
+	}
+
 	ociSpec, err := oci.NewSpec(logger, argv)
 	if err != nil {
 		return nil, fmt.Errorf("error constructing OCI specification: %v", err)
 	}
+	if tracer != nil {
+		ociSpec = &tracedSpec{Spec: ociSpec, tracer: tracer}
+	}
+
+	bundleDir, err := oci.GetBundleDir(argv)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bundle directory: %v", err)
+	}
 
-	specModifier, err := newSpecModifier(logger, cfg, ociSpec, driver)
+	specModifier, err := newSpecModifier(logger, cfg, ociSpec, bundleDir, driver, profile, tracer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct OCI spec modifier: %v", err)
 	}
+	if tracksState {
+		cleanupModifier, err := modifier.NewCleanupHookModifier(logger, cfg.NVIDIACTKConfig.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct cleanup hook modifier: %v", err)
+		}
+		specModifier = modifier.Merge(specModifier, cleanupModifier)
+	}
 
 	// Create the wrapping runtime with the specified modifier.
 	r := oci.NewModifyingRuntimeWrapper(
@@ -63,7 +93,9 @@ func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv
 }
 
 // newSpecModifier is a factory method that creates constructs an OCI spec modifer based on the provided config.
-func newSpecModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec, driver *root.Driver) (oci.SpecModifier, error) {
+// The bundle directory is used to resolve a relative container root. The
+// specified tracer, which may be nil, records the time taken by each modifier.
+func newSpecModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec, bundleDir string, driver *root.Driver, profile string, tracer *tracing.Tracer) (oci.SpecModifier, error) {
 	rawSpec, err := ociSpec.Load()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OCI spec: %v", err)
@@ -77,7 +109,12 @@ func newSpecModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Sp
 	mode := info.ResolveAutoMode(logger, cfg.NVIDIAContainerRuntimeConfig.Mode, image)
 	// We update the mode here so that we can continue passing just the config to other functions.
 	cfg.NVIDIAContainerRuntimeConfig.Mode = mode
-	modeModifier, err := newModeModifier(logger, mode, cfg, ociSpec, image)
+	containerRoot := oci.GetContainerRoot(bundleDir, rawSpec)
+	span := tracer.Start("modifier.New")
+	span.SetAttribute("mode", mode)
+	modeModifier, err := newModeModifier(logger, mode, cfg, ociSpec, image, driver, containerRoot, profile, tracer)
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		return nil, err
 	}
@@ -86,35 +123,38 @@ func newSpecModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Sp
 	for _, modifierType := range supportedModifierTypes(mode) {
 		switch modifierType {
 		case "mode":
-			modifiers = append(modifiers, modeModifier)
+			modifiers = append(modifiers, newTracedModifier(tracer, modifierType, modeModifier))
 		case "nvidia-hook-remover":
-			modifiers = append(modifiers, modifier.NewNvidiaContainerRuntimeHookRemover(logger))
+			modifiers = append(modifiers, newTracedModifier(tracer, modifierType, modifier.NewNvidiaContainerRuntimeHookRemover(logger)))
 		case "graphics":
 			graphicsModifier, err := modifier.NewGraphicsModifier(logger, cfg, image, driver)
 			if err != nil {
 				return nil, err
 			}
-			modifiers = append(modifiers, graphicsModifier)
+			modifiers = append(modifiers, newTracedModifier(tracer, modifierType, graphicsModifier))
 		case "feature-gated":
 			featureGatedModifier, err := modifier.NewFeatureGatedModifier(logger, cfg, image, driver)
 			if err != nil {
 				return nil, err
 			}
-			modifiers = append(modifiers, featureGatedModifier)
+			modifiers = append(modifiers, newTracedModifier(tracer, modifierType, featureGatedModifier))
 		}
 	}
+	if traceparent := tracer.Traceparent(); traceparent != "" {
+		modifiers = append(modifiers, modifier.NewTraceContextModifier(traceparent))
+	}
 
 	return modifiers, nil
 }
 
-func newModeModifier(logger logger.Interface, mode string, cfg *config.Config, ociSpec oci.Spec, image image.CUDA) (oci.SpecModifier, error) {
+func newModeModifier(logger logger.Interface, mode string, cfg *config.Config, ociSpec oci.Spec, image image.CUDA, driver *root.Driver, containerRoot string, profile string, tracer *tracing.Tracer) (oci.SpecModifier, error) {
 	switch mode {
 	case "legacy":
-		return modifier.NewStableRuntimeModifier(logger, cfg.NVIDIAContainerRuntimeHookConfig.Path), nil
+		return modifier.NewStableRuntimeModifier(logger, cfg.NVIDIAContainerRuntimeHookConfig.Path, cfg.NVIDIAContainerCLIConfig.Root, profile), nil
 	case "csv":
-		return modifier.NewCSVModifier(logger, cfg, image)
+		return modifier.NewCSVModifier(logger, cfg, image, driver, containerRoot)
 	case "cdi":
-		return modifier.NewCDIModifier(logger, cfg, ociSpec)
+		return modifier.NewCDIModifier(logger, cfg, ociSpec, driver, containerRoot, tracer)
 	}
 
 	return nil, fmt.Errorf("invalid runtime mode: %v", cfg.NVIDIAContainerRuntimeConfig.Mode)
@@ -157,7 +157,7 @@ func TestFactoryMethod(t *testing.T) {
 
 			argv := []string{"--bundle", bundleDir, "create"}
 
-			_, err = newNVIDIAContainerRuntime(logger, tc.cfg, argv, driver)
+			_, err = newNVIDIAContainerRuntime(logger, tc.cfg, argv, driver, "", nil)
 			if tc.expectedError {
 				require.Error(t, err)
 			} else {
@@ -335,7 +335,7 @@ func TestNewSpecModifier(t *testing.T) {
 					return tc.spec, nil
 				},
 			}
-			m, err := newSpecModifier(logger, tc.config, spec, driver)
+			m, err := newSpecModifier(logger, tc.config, spec, "", driver, "", nil)
 			require.NoError(t, err)
 
 			err = m.Modify(tc.spec)
@@ -0,0 +1,149 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/metrics"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
+)
+
+var (
+	containerCreates = metrics.NewCounter(
+		"nvidia_container_runtime_container_creates_total",
+		"The number of container create commands handled by the NVIDIA Container Runtime.",
+		"mode",
+	)
+	modificationFailures = metrics.NewCounter(
+		"nvidia_container_runtime_modification_failures_total",
+		"The number of container create commands for which the OCI spec could not be modified.",
+		"mode",
+	)
+
+	runtimeMetrics = metrics.NewRegistry(
+		containerCreates,
+		modificationFailures,
+		modifier.RequirementCheckFailures,
+		modifier.AutomaticCDIGenerationSeconds,
+	)
+)
+
+// createTelemetry exports the spans and metrics recorded for a create
+// command. These are exported once, either before the low-level runtime is
+// exec'ed or when an error is returned.
+type createTelemetry struct {
+	logger   logger.Interface
+	cfg      *config.Config
+	tracer   *tracing.Tracer
+	exported bool
+}
+
+// export exports the recorded spans and updates the configured metrics
+// textfile. The mode is read from the config since this is resolved while
+// constructing the spec modifier.
+func (t *createTelemetry) export(err error) {
+	if t == nil || t.exported {
+		return
+	}
+	t.exported = true
+
+	t.tracer.Flush()
+
+	textfile := t.cfg.NVIDIAContainerRuntimeConfig.MetricsTextfilePath
+	if textfile == "" {
+		return
+	}
+	mode := t.cfg.NVIDIAContainerRuntimeConfig.Mode
+	containerCreates.Inc(mode)
+	if err != nil {
+		modificationFailures.Inc(mode)
+	}
+	if err := runtimeMetrics.UpdateTextfile(textfile); err != nil {
+		t.logger.Warningf("Failed to update metrics textfile: %v", err)
+	}
+}
+
+// exportingRuntime exports the recorded spans and metrics before invoking the
+// wrapped runtime since deferred calls are not run if the runtime is exec'ed.
+type exportingRuntime struct {
+	oci.Runtime
+	telemetry *createTelemetry
+}
+
+// Exec exports the recorded spans and metrics and forwards the command to the
+// wrapped runtime.
+func (r *exportingRuntime) Exec(args []string) error {
+	r.telemetry.export(nil)
+	return r.Runtime.Exec(args)
+}
+
+// tracedSpec records a span each time the OCI spec is loaded or flushed.
+type tracedSpec struct {
+	oci.Spec
+	tracer *tracing.Tracer
+}
+
+// Load loads the wrapped spec.
+func (s *tracedSpec) Load() (*specs.Spec, error) {
+	span := s.tracer.Start("oci.LoadSpec")
+	defer span.End()
+
+	spec, err := s.Spec.Load()
+	span.RecordError(err)
+	return spec, err
+}
+
+// Flush flushes the wrapped spec.
+func (s *tracedSpec) Flush() error {
+	span := s.tracer.Start("oci.FlushSpec")
+	defer span.End()
+
+	err := s.Spec.Flush()
+	span.RecordError(err)
+	return err
+}
+
+// tracedModifier records a span for each modification of the OCI spec.
+type tracedModifier struct {
+	tracer   *tracing.Tracer
+	name     string
+	modifier oci.SpecModifier
+}
+
+// newTracedModifier wraps the specified modifier to record a span named after
+// the modifier type. A nil modifier is returned unchanged.
+func newTracedModifier(tracer *tracing.Tracer, name string, m oci.SpecModifier) oci.SpecModifier {
+	if m == nil {
+		return nil
+	}
+	return &tracedModifier{tracer: tracer, name: name, modifier: m}
+}
+
+// Modify applies the wrapped modifier.
+func (m *tracedModifier) Modify(spec *specs.Spec) error {
+	span := m.tracer.Start("modifier." + m.name)
+	defer span.End()
+
+	err := m.modifier.Modify(spec)
+	span.RecordError(err)
+	return err
+}
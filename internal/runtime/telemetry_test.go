@@ -0,0 +1,93 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
+)
+
+func TestCreateTelemetryExport(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	textfile := filepath.Join(t.TempDir(), "nvidia-container-runtime.prom")
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	cfg.NVIDIAContainerRuntimeConfig.Mode = "test-mode"
+	cfg.NVIDIAContainerRuntimeConfig.MetricsTextfilePath = textfile
+
+	telemetry := &createTelemetry{logger: logger, cfg: cfg}
+	telemetry.export(errors.New("failed"))
+	// The telemetry is only exported once.
+	telemetry.export(errors.New("failed"))
+
+	contents, err := os.ReadFile(textfile)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `nvidia_container_runtime_container_creates_total{mode="test-mode"} 1`+"\n")
+	require.Contains(t, string(contents), `nvidia_container_runtime_modification_failures_total{mode="test-mode"} 1`+"\n")
+	require.Contains(t, string(contents), "# TYPE nvidia_container_runtime_automatic_cdi_generation_seconds summary\n")
+}
+
+func TestTracedSpecAndModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	filename := filepath.Join(t.TempDir(), "traces.json")
+
+	tracer := tracing.NewFromConfig(logger, binaryName, config.TracingConfig{File: filename})
+	require.NotNil(t, tracer)
+
+	ociSpec := &tracedSpec{
+		Spec: &oci.SpecMock{
+			LoadFunc: func() (*specs.Spec, error) {
+				return &specs.Spec{}, nil
+			},
+			FlushFunc: func() error {
+				return errors.New("failed")
+			},
+		},
+		tracer: tracer,
+	}
+	require.Nil(t, newTracedModifier(tracer, "mode", nil))
+
+	spec, err := ociSpec.Load()
+	require.NoError(t, err)
+	require.NoError(t, newTracedModifier(tracer, "nvidia-hook-remover", &modifierMock{}).Modify(spec))
+	require.Error(t, ociSpec.Flush())
+	tracer.Flush()
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `"name":"oci.LoadSpec"`)
+	require.Contains(t, string(contents), `"name":"modifier.nvidia-hook-remover"`)
+	require.Contains(t, string(contents), `"name":"oci.FlushSpec"`)
+	require.Contains(t, string(contents), `"message":"failed"`)
+}
+
+type modifierMock struct{}
+
+func (m *modifierMock) Modify(*specs.Spec) error {
+	return nil
+}
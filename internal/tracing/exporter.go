@@ -0,0 +1,66 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// An Exporter sends a batch of spans to a backend.
+type Exporter interface {
+	Export(*exportRequest) error
+}
+
+// fileExporter appends each batch of spans to a file as a single line of
+// OTLP JSON. This is the format read by the OpenTelemetry Collector's
+// otlpjsonfile receiver. Since spans are exported on the container start
+// path, writing to a local file ensures that a slow or unavailable collector
+// does not delay the creation of containers.
+type fileExporter struct {
+	path string
+}
+
+// NewFileExporter creates an exporter that appends spans to the specified
+// file.
+func NewFileExporter(path string) Exporter {
+	return &fileExporter{path: path}
+}
+
+// Export appends the request to the file. The line is written with a single
+// write so that records from concurrent processes are not interleaved.
+func (e *fileExporter) Export(request *exportRequest) error {
+	line, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spans: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(e.path), 0755); err != nil {
+		return fmt.Errorf("failed to create trace directory: %w", err)
+	}
+	f, err := os.OpenFile(e.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write trace file: %w", err)
+	}
+	return nil
+}
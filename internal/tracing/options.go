@@ -0,0 +1,82 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"os"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// Option is a functional option for constructing a tracer.
+type Option func(*Tracer)
+
+// WithLogger sets the logger used to report export failures.
+func WithLogger(logger logger.Interface) Option {
+	return func(t *Tracer) {
+		t.logger = logger
+	}
+}
+
+// WithServiceName sets the service name reported for exported spans.
+func WithServiceName(serviceName string) Option {
+	return func(t *Tracer) {
+		t.serviceName = serviceName
+	}
+}
+
+// WithExporter sets the exporter for the tracer.
+func WithExporter(exporter Exporter) Option {
+	return func(t *Tracer) {
+		t.exporter = exporter
+	}
+}
+
+// WithTraceparent sets the remote parent of the tracer from a W3C
+// traceparent. Invalid values are ignored and a new trace is started.
+func WithTraceparent(traceparent string) Option {
+	return func(t *Tracer) {
+		if traceparent == "" {
+			return
+		}
+		trace, span, err := parseTraceparent(traceparent)
+		if err != nil {
+			if t.logger != nil {
+				t.logger.Warningf("Ignoring trace context: %v", err)
+			}
+			return
+		}
+		t.traceID = trace
+		t.parentID = span
+	}
+}
+
+// NewFromConfig creates a tracer for the specified binary from the tracing
+// config. The remote parent is read from the TRACEPARENT environment
+// variable. If tracing is not configured, nil is returned.
+func NewFromConfig(logger logger.Interface, serviceName string, cfg config.TracingConfig) *Tracer {
+	opts := []Option{
+		WithLogger(logger),
+		WithServiceName(serviceName),
+		WithTraceparent(os.Getenv(EnvVar)),
+	}
+	if cfg.File != "" {
+		opts = append(opts, WithExporter(NewFileExporter(cfg.File)))
+	}
+	return New(opts...)
+}
@@ -0,0 +1,148 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+)
+
+// scopeName is the name of the instrumentation scope for all spans.
+const scopeName = "github.com/NVIDIA/nvidia-container-toolkit"
+
+// The following types represent the subset of an OTLP
+// ExportTraceServiceRequest that is required to export spans using the OTLP
+// JSON encoding. See
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            *status    `json:"status,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	spanKindInternal = 1
+	statusCodeError  = 2
+)
+
+// newExportRequest converts the specified spans to an OTLP export request.
+func (t *Tracer) newExportRequest(spans []*Span) *exportRequest {
+	var converted []otlpSpan
+	for _, s := range spans {
+		converted = append(converted, s.toOTLP(t.traceID))
+	}
+
+	return &exportRequest{
+		ResourceSpans: []resourceSpans{
+			{
+				Resource: resource{
+					Attributes: []keyValue{
+						stringAttribute("service.name", t.serviceName),
+						stringAttribute("service.version", info.GetVersionParts()[0]),
+					},
+				},
+				ScopeSpans: []scopeSpans{
+					{
+						Scope: scope{Name: scopeName},
+						Spans: converted,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *Span) toOTLP(trace traceID) otlpSpan {
+	converted := otlpSpan{
+		TraceID:           trace.String(),
+		SpanID:            s.id.String(),
+		ParentSpanID:      s.parentID.String(),
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+
+	var keys []string
+	for key := range s.attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		converted.Attributes = append(converted.Attributes, stringAttribute(key, s.attributes[key]))
+	}
+
+	if s.err != nil {
+		converted.Status = &status{
+			Code:    statusCodeError,
+			Message: s.err.Error(),
+		}
+	}
+	return converted
+}
+
+func stringAttribute(key string, value string) keyValue {
+	return keyValue{
+		Key:   key,
+		Value: anyValue{StringValue: value},
+	}
+}
@@ -0,0 +1,87 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type traceID [16]byte
+
+type spanID [8]byte
+
+// IsZero returns whether the trace ID is unset.
+func (id traceID) IsZero() bool {
+	return id == traceID{}
+}
+
+// IsZero returns whether the span ID is unset.
+func (id spanID) IsZero() bool {
+	return id == spanID{}
+}
+
+func (id traceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id spanID) String() string {
+	if id.IsZero() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+// formatTraceparent returns a W3C traceparent for the specified IDs. The
+// sampled flag is always set.
+func formatTraceparent(trace traceID, span spanID) string {
+	if span.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", trace, hex.EncodeToString(span[:]))
+}
+
+// parseTraceparent parses a W3C traceparent. See
+// https://www.w3.org/TR/trace-context/#traceparent-header
+func parseTraceparent(value string) (traceID, spanID, error) {
+	var trace traceID
+	var span spanID
+
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return trace, span, fmt.Errorf("invalid traceparent %q", value)
+	}
+	if len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return trace, span, fmt.Errorf("unsupported traceparent version in %q", value)
+	}
+	if err := decodeHex(trace[:], parts[1]); err != nil || trace.IsZero() {
+		return trace, span, fmt.Errorf("invalid trace ID in traceparent %q", value)
+	}
+	if err := decodeHex(span[:], parts[2]); err != nil || span.IsZero() {
+		return trace, span, fmt.Errorf("invalid parent ID in traceparent %q", value)
+	}
+	return trace, span, nil
+}
+
+func decodeHex(dst []byte, value string) error {
+	if len(value) != 2*len(dst) || strings.ToLower(value) != value {
+		return fmt.Errorf("invalid length or case")
+	}
+	_, err := hex.Decode(dst, []byte(value))
+	return err
+}
@@ -0,0 +1,210 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// EnvVar is the environment variable used to propagate the trace context to
+// child processes such as hooks. The value is a W3C traceparent header.
+const EnvVar = "TRACEPARENT"
+
+const (
+	// ContainerIDAttribute is the span attribute that holds the container ID.
+	ContainerIDAttribute = "container.id"
+	// BundleAttribute is the span attribute that holds the container bundle.
+	BundleAttribute = "container.bundle"
+)
+
+// A Tracer records the spans for a single process and exports these as a
+// single batch when flushed. Spans started while another span is active are
+// recorded as children of the active span.
+//
+// A nil *Tracer is valid and records nothing. This allows tracing to be
+// disabled without checks at each call site.
+type Tracer struct {
+	sync.Mutex
+	logger      logger.Interface
+	serviceName string
+	exporter    Exporter
+
+	traceID  traceID
+	parentID spanID
+	active   []*Span
+	finished []*Span
+}
+
+// A Span represents a timed operation. A nil *Span is valid and records
+// nothing.
+type Span struct {
+	tracer     *Tracer
+	name       string
+	id         spanID
+	parentID   spanID
+	start      time.Time
+	end        time.Time
+	attributes map[string]string
+	err        error
+}
+
+// New creates a tracer with the specified options. If no exporter is
+// specified, nil is returned.
+func New(opts ...Option) *Tracer {
+	t := &Tracer{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.exporter == nil {
+		return nil
+	}
+	if t.logger == nil {
+		t.logger = &logger.NullLogger{}
+	}
+	if t.traceID.IsZero() {
+		t.traceID = newTraceID()
+	}
+	return t
+}
+
+// Start starts a span with the specified name. The span is a child of the
+// active span or, if there is no active span, of the remote parent of the
+// tracer.
+func (t *Tracer) Start(name string) *Span {
+	if t == nil {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+
+	s := &Span{
+		tracer:     t,
+		name:       name,
+		id:         newSpanID(),
+		parentID:   t.parentID,
+		start:      time.Now(),
+		attributes: make(map[string]string),
+	}
+	if len(t.active) > 0 {
+		s.parentID = t.active[len(t.active)-1].id
+	}
+	t.active = append(t.active, s)
+	return s
+}
+
+// Traceparent returns the W3C traceparent for the active span. This is used
+// to propagate the trace context to child processes. An empty string is
+// returned if tracing is disabled.
+func (t *Tracer) Traceparent() string {
+	if t == nil {
+		return ""
+	}
+	t.Lock()
+	defer t.Unlock()
+
+	id := t.parentID
+	if len(t.active) > 0 {
+		id = t.active[len(t.active)-1].id
+	}
+	return formatTraceparent(t.traceID, id)
+}
+
+// Flush ends all active spans and exports the finished spans. Errors are
+// logged and not returned since tracing must not cause a container to fail
+// to start.
+func (t *Tracer) Flush() {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	for i := len(t.active) - 1; i >= 0; i-- {
+		t.active[i].end = time.Now()
+		t.finished = append(t.finished, t.active[i])
+	}
+	t.active = nil
+	spans := t.finished
+	t.finished = nil
+	t.Unlock()
+
+	if len(spans) == 0 {
+		return
+	}
+	if err := t.exporter.Export(t.newExportRequest(spans)); err != nil {
+		t.logger.Warningf("Failed to export trace spans: %v", err)
+	}
+}
+
+// SetAttribute sets an attribute for the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.tracer.Lock()
+	defer s.tracer.Unlock()
+	s.attributes[key] = fmt.Sprintf("%v", value)
+}
+
+// RecordError marks the span as failed if err is not nil.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.tracer.Lock()
+	defer s.tracer.Unlock()
+	s.err = err
+}
+
+// End ends the span. Any spans started after this span that are still active
+// are also ended.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	t := s.tracer
+	t.Lock()
+	defer t.Unlock()
+
+	for i := len(t.active) - 1; i >= 0; i-- {
+		if t.active[i] != s {
+			continue
+		}
+		now := time.Now()
+		for j := len(t.active) - 1; j >= i; j-- {
+			t.active[j].end = now
+			t.finished = append(t.finished, t.active[j])
+		}
+		t.active = t.active[:i]
+		return
+	}
+}
+
+func newTraceID() traceID {
+	var id traceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() spanID {
+	var id spanID
+	_, _ = rand.Read(id[:])
+	return id
+}
@@ -0,0 +1,167 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracerExportsToFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "traces", "spans.json")
+
+	tracer := New(
+		WithServiceName("test-service"),
+		WithExporter(NewFileExporter(filename)),
+		WithTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"),
+	)
+	require.NotNil(t, tracer)
+
+	root := tracer.Start("root")
+	root.SetAttribute(ContainerIDAttribute, "ctr")
+	child := tracer.Start("child")
+	child.RecordError(errors.New("failed"))
+	child.End()
+	require.True(t, strings.HasPrefix(tracer.Traceparent(), "00-0af7651916cd43dd8448eb211c80319c-"))
+	tracer.Flush()
+	// A second flush does not export any spans.
+	tracer.Flush()
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	require.Len(t, lines, 1)
+
+	var request exportRequest
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &request))
+	require.Len(t, request.ResourceSpans, 1)
+	require.Contains(t, request.ResourceSpans[0].Resource.Attributes, stringAttribute("service.name", "test-service"))
+	require.Len(t, request.ResourceSpans[0].ScopeSpans, 1)
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "child", spans[0].Name)
+	require.Equal(t, "root", spans[1].Name)
+	for _, span := range spans {
+		require.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.TraceID)
+		require.Equal(t, spanKindInternal, span.Kind)
+		require.LessOrEqual(t, span.StartTimeUnixNano, span.EndTimeUnixNano)
+	}
+	require.Equal(t, "b7ad6b7169203331", spans[1].ParentSpanID)
+	require.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	require.Equal(t, &status{Code: statusCodeError, Message: "failed"}, spans[0].Status)
+	require.Nil(t, spans[1].Status)
+	require.Equal(t, []keyValue{stringAttribute(ContainerIDAttribute, "ctr")}, spans[1].Attributes)
+}
+
+func TestNilTracer(t *testing.T) {
+	tracer := New(WithServiceName("test-service"))
+	require.Nil(t, tracer)
+
+	span := tracer.Start("root")
+	require.Nil(t, span)
+	span.SetAttribute("key", "value")
+	span.RecordError(errors.New("failed"))
+	span.End()
+	require.Equal(t, "", tracer.Traceparent())
+	tracer.Flush()
+}
+
+func TestEndEndsChildSpans(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := New(WithExporter(exporter))
+
+	root := tracer.Start("root")
+	tracer.Start("child")
+	root.End()
+	tracer.Start("sibling").End()
+	tracer.Flush()
+
+	require.Len(t, exporter.requests, 1)
+	spans := exporter.requests[0].ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 3)
+	require.Equal(t, "child", spans[0].Name)
+	require.Equal(t, "root", spans[1].Name)
+	require.Equal(t, "sibling", spans[2].Name)
+	require.Equal(t, "", spans[2].ParentSpanID)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	testCases := []struct {
+		description   string
+		traceparent   string
+		expectedError bool
+	}{
+		{
+			description: "valid traceparent",
+			traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		},
+		{
+			description: "future version with additional fields",
+			traceparent: "01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra",
+		},
+		{
+			description:   "too few fields",
+			traceparent:   "00-0af7651916cd43dd8448eb211c80319c-01",
+			expectedError: true,
+		},
+		{
+			description:   "invalid version",
+			traceparent:   "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			expectedError: true,
+		},
+		{
+			description:   "zero trace ID",
+			traceparent:   "00-00000000000000000000000000000000-b7ad6b7169203331-01",
+			expectedError: true,
+		},
+		{
+			description:   "upper case parent ID",
+			traceparent:   "00-0af7651916cd43dd8448eb211c80319c-B7AD6B7169203331-01",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			trace, span, err := parseTraceparent(tc.traceparent)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "0af7651916cd43dd8448eb211c80319c", trace.String())
+			require.Equal(t, "b7ad6b7169203331", span.String())
+		})
+	}
+}
+
+type recordingExporter struct {
+	requests []*exportRequest
+}
+
+func (e *recordingExporter) Export(request *exportRequest) error {
+	e.requests = append(e.requests, request)
+	return nil
+}
@@ -19,12 +19,24 @@ package nvcdi
 import (
 	"fmt"
 
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 )
 
 // newCommonNVMLDiscoverer returns a discoverer for entities that are not associated with a specific CDI device.
 // This includes driver libraries and meta devices, for example.
 func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
+	// NVML is initialized once for the discoverers that query it.
+	if r := l.initNVML(); r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %v", r)
+	}
+	defer func() {
+		if r := l.nvmllib.Shutdown(); r != nvml.SUCCESS {
+			l.logger.Warningf("failed to shutdown NVML: %v", r)
+		}
+	}()
+
 	metaDevices := discover.NewCharDeviceDiscoverer(
 		l.logger,
 		l.devRoot,
@@ -50,6 +62,7 @@ func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
 		metaDevices,
 		graphicsMounts,
 		driverFiles,
+		l.newVGPULicensingDiscoverer(),
 	)
 
 	return d, nil
@@ -35,7 +35,7 @@ import (
 // NewDriverDiscoverer creates a discoverer for the libraries and binaries associated with a driver installation.
 // The supplied NVML Library is used to query the expected driver version.
 func (l *nvmllib) NewDriverDiscoverer() (discover.Discover, error) {
-	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
+	if r := l.initNVML(); r != nvml.SUCCESS {
 		return nil, fmt.Errorf("failed to initialize NVML: %v", r)
 	}
 	defer func() {
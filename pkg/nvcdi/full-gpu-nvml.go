@@ -40,9 +40,11 @@ func (l *nvmllib) GetGPUDeviceSpecs(i int, d device.Device) ([]specs.Device, err
 	if err != nil {
 		return nil, fmt.Errorf("failed to get device name: %v", err)
 	}
+	annotations := l.getVGPUAnnotations(d)
 	for _, name := range names {
 		spec := specs.Device{
 			Name:           name,
+			Annotations:    annotations,
 			ContainerEdits: *edits.ContainerEdits,
 		}
 		deviceSpecs = append(deviceSpecs, spec)
@@ -0,0 +1,220 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/infiniband"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/pcie"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+const gpuAffinityDeviceSuffix = "-gpu-affinity"
+
+// IsGPUAffinityDevice checks whether the specified device is a gpu-affinity
+// device. Since device names are user-configurable, the device is identified
+// by its device nodes instead: a gpu-affinity device is the only device that
+// includes both the device node of a full GPU and the uverbs device node of an
+// RDMA device.
+func IsGPUAffinityDevice(d specs.Device) bool {
+	var hasGPU, hasVerbs bool
+	for _, dn := range d.ContainerEdits.DeviceNodes {
+		if dn == nil {
+			continue
+		}
+		switch {
+		case isGPUDeviceNodePath(dn.Path):
+			hasGPU = true
+		case strings.HasPrefix(dn.Path, "/dev/infiniband/uverbs"):
+			hasVerbs = true
+		}
+	}
+	return hasGPU && hasVerbs
+}
+
+// isGPUDeviceNodePath checks whether the specified path refers to the device
+// node of a full GPU (/dev/nvidiaN).
+func isGPUDeviceNodePath(path string) bool {
+	index, found := strings.CutPrefix(path, "/dev/nvidia")
+	if !found || index == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(index, 10, 32)
+	return err == nil
+}
+
+// IsExcludedFromAllDevice checks whether the specified device should be
+// excluded from merged devices such as 'all'. This is the case for the
+// mig-config, mig-monitor, and gpu-affinity devices which grant access beyond
+// that of the GPUs themselves and must be requested explicitly.
+func IsExcludedFromAllDevice(d specs.Device) bool {
+	return IsMIGCapabilityDevice(d) || IsGPUAffinityDevice(d)
+}
+
+// rdmaDevice associates an RDMA device with its location in the PCIe
+// hierarchy.
+type rdmaDevice struct {
+	infiniband.Device
+	path pcie.Path
+}
+
+// getGPUAffinityDeviceSpecs returns the gpu-affinity device specs for all
+// GPUs. A gpu-affinity device combines the device nodes of a GPU with the
+// uverbs device nodes of the RDMA devices (NICs) that are closest to the GPU
+// in the PCIe hierarchy. Only NICs that share a PCIe switch (or root port)
+// with the GPU are considered. GPUs without such NICs are skipped.
+func (l *nvmllib) getGPUAffinityDeviceSpecs() ([]specs.Device, error) {
+	if !l.gpuAffinityDevices {
+		return nil, nil
+	}
+
+	rdmaDevices, err := l.getRDMADevicesWithPaths()
+	if err != nil {
+		return nil, err
+	}
+	if len(rdmaDevices) == 0 {
+		l.logger.Infof("No RDMA devices found; skipping generation of gpu-affinity devices")
+		return nil, nil
+	}
+
+	var deviceSpecs []specs.Device
+	err = l.devicelib.VisitDevices(func(i int, d device.Device) error {
+		specsForDevice, err := l.getGPUAffinityDeviceSpecsForGPU(i, d, rdmaDevices)
+		if err != nil {
+			return err
+		}
+		deviceSpecs = append(deviceSpecs, specsForDevice...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CDI edits for gpu-affinity devices: %v", err)
+	}
+	return deviceSpecs, nil
+}
+
+func (l *nvmllib) getGPUAffinityDeviceSpecsForGPU(i int, d device.Device, rdmaDevices []rdmaDevice) ([]specs.Device, error) {
+	busID, err := d.GetPCIBusID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PCI bus ID: %v", err)
+	}
+	path, err := pcie.GetPath(l.sysRoot, busID)
+	if err != nil {
+		l.logger.Warningf("Skipping gpu-affinity device for GPU %v: %v", busID, err)
+		return nil, nil
+	}
+
+	closest := getClosestRDMADevices(path, rdmaDevices)
+	if len(closest) == 0 {
+		l.logger.Debugf("No PCIe-local RDMA devices found for GPU %v", busID)
+		return nil, nil
+	}
+
+	var nicDeviceSpecs []specs.Device
+	for _, rdmaDevice := range closest {
+		l.logger.Debugf("Selecting RDMA device %v (%v) for GPU %v", rdmaDevice.Name, rdmaDevice.PCIBusID, busID)
+		nicDeviceSpec, err := (*mofedlib)(l).getRDMADeviceSpec(rdmaDevice.Name, rdmaDevice.VerbsDeviceNodes)
+		if err != nil {
+			return nil, err
+		}
+		nicDeviceSpecs = append(nicDeviceSpecs, *nicDeviceSpec)
+	}
+
+	gpuDeviceSpecs, err := l.GetGPUDeviceSpecs(i, d)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceSpecs []specs.Device
+	for _, gpuDeviceSpec := range gpuDeviceSpecs {
+		name := gpuDeviceSpec.Name + gpuAffinityDeviceSuffix
+		deviceSpec, err := mergeDeviceSpecs(name, append([]specs.Device{gpuDeviceSpec}, nicDeviceSpecs...))
+		if err != nil {
+			return nil, err
+		}
+		deviceSpecs = append(deviceSpecs, *deviceSpec)
+	}
+	return deviceSpecs, nil
+}
+
+// getRDMADevicesWithPaths returns the RDMA devices that have uverbs device
+// nodes together with their location in the PCIe hierarchy.
+func (l *nvmllib) getRDMADevicesWithPaths() ([]rdmaDevice, error) {
+	devices, err := infiniband.GetDevices(l.sysRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RDMA devices: %v", err)
+	}
+
+	var rdmaDevices []rdmaDevice
+	for _, d := range devices {
+		if d.PCIBusID == "" || len(d.VerbsDeviceNodes) == 0 {
+			l.logger.Debugf("Ignoring RDMA device %v", d.Name)
+			continue
+		}
+		path, err := pcie.GetPath(l.sysRoot, d.PCIBusID)
+		if err != nil {
+			l.logger.Warningf("Ignoring RDMA device %v: %v", d.Name, err)
+			continue
+		}
+		rdmaDevices = append(rdmaDevices, rdmaDevice{Device: d, path: path})
+	}
+	return rdmaDevices, nil
+}
+
+// getClosestRDMADevices returns the RDMA devices that share the most PCIe
+// bridges with the specified path. Devices that share no bridges are never
+// returned.
+func getClosestRDMADevices(path pcie.Path, rdmaDevices []rdmaDevice) []rdmaDevice {
+	var closest []rdmaDevice
+	maxCommonBridges := 0
+	for _, d := range rdmaDevices {
+		commonBridges := path.CommonBridges(d.path)
+		switch {
+		case commonBridges == 0 || commonBridges < maxCommonBridges:
+			continue
+		case commonBridges > maxCommonBridges:
+			maxCommonBridges = commonBridges
+			closest = nil
+		}
+		closest = append(closest, d)
+	}
+	return closest
+}
+
+// mergeDeviceSpecs merges the specified device specs into a single device
+// with the specified name.
+func mergeDeviceSpecs(name string, deviceSpecs []specs.Device) (*specs.Device, error) {
+	merger, err := transform.NewMergedDevice(transform.WithName(name))
+	if err != nil {
+		return nil, err
+	}
+	s := specs.Spec{Devices: deviceSpecs}
+	if err := merger.Transform(&s); err != nil {
+		return nil, err
+	}
+	for _, d := range s.Devices {
+		if d.Name == name {
+			return &d, nil
+		}
+	}
+	return nil, fmt.Errorf("merged device %q not found", name)
+}
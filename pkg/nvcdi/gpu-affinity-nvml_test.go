@@ -0,0 +1,268 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/infiniband"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/pcie"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestGetGPUAffinityDeviceSpecs(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	hostRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-gpu-affinity")
+
+	// The GPUs are attached to the PCIe hierarchy as follows:
+	//   0000:07:00.0 shares a PCIe switch with mlx5_0 (0000:03:00.0)
+	//   0000:87:00.0 shares only a host bridge with mlx5_1 (0000:83:00.0)
+	busIDs := []string{"0000:07:00.0", "0000:87:00.0"}
+
+	server := dgxa100.New()
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return len(busIDs), nvml.SUCCESS
+	}
+	for i, busID := range busIDs {
+		var pciInfo nvml.PciInfo
+		for j, c := range "0000" + busID {
+			pciInfo.BusId[j] = int8(c)
+		}
+		server.Devices[i].(*dgxa100.Device).GetPciInfoFunc = func() (nvml.PciInfo, nvml.Return) {
+			return pciInfo, nvml.SUCCESS
+		}
+		server.Devices[i].(*dgxa100.Device).GetVirtualizationModeFunc = func() (nvml.GpuVirtualizationMode, nvml.Return) {
+			return nvml.GPU_VIRTUALIZATION_MODE_NONE, nvml.SUCCESS
+		}
+	}
+
+	namer, err := NewDeviceNamer(DeviceNameStrategyIndex)
+	require.NoError(t, err)
+
+	l := &nvmllib{
+		logger:             logger,
+		nvmllib:            server,
+		devicelib:          device.New(server),
+		deviceNamers:       DeviceNamers{namer},
+		devRoot:            hostRoot,
+		nvidiaCDIHookPath:  "/usr/bin/nvidia-cdi-hook",
+		sysRoot:            filepath.Join(hostRoot, "sys"),
+		gpuAffinityDevices: true,
+	}
+
+	deviceSpecs, err := l.getGPUAffinityDeviceSpecs()
+	require.NoError(t, err)
+	require.Len(t, deviceSpecs, 1)
+
+	require.Equal(t, "0-gpu-affinity", deviceSpecs[0].Name)
+	var deviceNodes []string
+	for _, dn := range deviceSpecs[0].ContainerEdits.DeviceNodes {
+		deviceNodes = append(deviceNodes, dn.Path)
+	}
+	require.ElementsMatch(t,
+		[]string{
+			"/dev/nvidia0",
+			"/dev/infiniband/uverbs0",
+		},
+		deviceNodes,
+	)
+
+	l.gpuAffinityDevices = false
+	deviceSpecs, err = l.getGPUAffinityDeviceSpecs()
+	require.NoError(t, err)
+	require.Empty(t, deviceSpecs)
+}
+
+func TestGetDeviceSpecsByAllExcludesGPUAffinityDevices(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	hostRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-gpu-affinity")
+
+	busID := "0000:07:00.0"
+	server := dgxa100.New()
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	var pciInfo nvml.PciInfo
+	for j, c := range "0000" + busID {
+		pciInfo.BusId[j] = int8(c)
+	}
+	d := server.Devices[0].(*dgxa100.Device)
+	d.GetPciInfoFunc = func() (nvml.PciInfo, nvml.Return) {
+		return pciInfo, nvml.SUCCESS
+	}
+	d.GetVirtualizationModeFunc = func() (nvml.GpuVirtualizationMode, nvml.Return) {
+		return nvml.GPU_VIRTUALIZATION_MODE_NONE, nvml.SUCCESS
+	}
+	d.GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+		return 0, nvml.SUCCESS
+	}
+
+	namer, err := NewDeviceNamer(DeviceNameStrategyIndex)
+	require.NoError(t, err)
+
+	l := &nvmllib{
+		logger:             logger,
+		nvmllib:            server,
+		devicelib:          device.New(server),
+		deviceNamers:       DeviceNamers{namer},
+		devRoot:            hostRoot,
+		driverRoot:         hostRoot,
+		nvidiaCDIHookPath:  "/usr/bin/nvidia-cdi-hook",
+		sysRoot:            filepath.Join(hostRoot, "sys"),
+		gpuAffinityDevices: true,
+	}
+
+	allDeviceSpecs, err := l.GetAllDeviceSpecs()
+	require.NoError(t, err)
+	var allNames []string
+	for _, deviceSpec := range allDeviceSpecs {
+		allNames = append(allNames, deviceSpec.Name)
+	}
+	require.Contains(t, allNames, "0-gpu-affinity")
+
+	deviceSpecs, err := l.GetDeviceSpecsBy("all")
+	require.NoError(t, err)
+	require.NotEmpty(t, deviceSpecs)
+	for _, deviceSpec := range deviceSpecs {
+		require.False(t, IsExcludedFromAllDevice(deviceSpec), "unexpected device %v", deviceSpec.Name)
+	}
+}
+
+func TestGetClosestRDMADevices(t *testing.T) {
+	nic := func(name string, path ...string) rdmaDevice {
+		return rdmaDevice{Device: infiniband.Device{Name: name}, path: path}
+	}
+	gpu := pcie.Path{"pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:01.0", "0000:07:00.0"}
+
+	testCases := []struct {
+		description string
+		nics        []rdmaDevice
+		expected    []string
+	}{
+		{
+			description: "no NICs",
+		},
+		{
+			description: "NIC on a different root port is ignored",
+			nics: []rdmaDevice{
+				nic("mlx5_0", "pci0000:00", "0000:00:02.0", "0000:04:00.0"),
+			},
+		},
+		{
+			description: "closest NICs are selected",
+			nics: []rdmaDevice{
+				nic("mlx5_0", "pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:00.0", "0000:03:00.0"),
+				nic("mlx5_1", "pci0000:00", "0000:00:01.0", "0000:01:00.0", "0000:02:00.0", "0000:03:00.1"),
+				nic("mlx5_2", "pci0000:00", "0000:00:01.0", "0000:05:00.0"),
+				nic("mlx5_3", "pci0000:80", "0000:80:01.0", "0000:81:00.0"),
+			},
+			expected: []string{"mlx5_0", "mlx5_1"},
+		},
+		{
+			description: "NIC behind the same root port is selected",
+			nics: []rdmaDevice{
+				nic("mlx5_2", "pci0000:00", "0000:00:01.0", "0000:05:00.0"),
+			},
+			expected: []string{"mlx5_2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var names []string
+			for _, d := range getClosestRDMADevices(gpu, tc.nics) {
+				names = append(names, d.Name)
+			}
+			require.EqualValues(t, tc.expected, names)
+		})
+	}
+}
+
+func TestIsExcludedFromAllDevice(t *testing.T) {
+	withDeviceNodes := func(name string, paths ...string) specs.Device {
+		d := specs.Device{Name: name}
+		for _, path := range paths {
+			d.ContainerEdits.DeviceNodes = append(d.ContainerEdits.DeviceNodes, &specs.DeviceNode{Path: path})
+		}
+		return d
+	}
+
+	testCases := []struct {
+		description string
+		device      specs.Device
+		expected    bool
+	}{
+		{
+			description: "gpu-affinity device",
+			device:      withDeviceNodes("affine", "/dev/nvidia0", "/dev/nvidiactl", "/dev/infiniband/uverbs0"),
+			expected:    true,
+		},
+		{
+			description: "name suffix alone is not a gpu-affinity device",
+			device:      withDeviceNodes("0-gpu-affinity", "/dev/nvidia0", "/dev/nvidiactl"),
+			expected:    false,
+		},
+		{
+			description: "control device nodes are not a GPU",
+			device:      withDeviceNodes("rdma", "/dev/nvidiactl", "/dev/infiniband/uverbs0"),
+			expected:    false,
+		},
+		{
+			description: "mig-config device",
+			device:      migCapabilityDevice("0-mig-config", nvcaps.MigConfigCap),
+			expected:    true,
+		},
+		{
+			description: "gpu device",
+			device:      withDeviceNodes("0", "/dev/nvidia0", "/dev/nvidiactl"),
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsExcludedFromAllDevice(tc.device))
+		})
+	}
+}
+
+func TestMergeDeviceSpecs(t *testing.T) {
+	merged, err := mergeDeviceSpecs("merged", []specs.Device{
+		{Name: "a", ContainerEdits: specs.ContainerEdits{DeviceNodes: []*specs.DeviceNode{{Path: "/dev/a"}}}},
+		{Name: "b", ContainerEdits: specs.ContainerEdits{DeviceNodes: []*specs.DeviceNode{{Path: "/dev/b"}}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "merged", merged.Name)
+	require.Len(t, merged.ContainerEdits.DeviceNodes, 2)
+}
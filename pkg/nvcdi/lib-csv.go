@@ -23,6 +23,7 @@ import (
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra"
@@ -39,7 +40,54 @@ func (l *csvlib) GetSpec() (spec.Interface, error) {
 }
 
 // GetAllDeviceSpecs returns the device specs for all available devices.
+// If no driver capabilities are specified, a device is also generated for each
+// driver capability that the entries in the CSV files are tagged with. These
+// devices are named NAME-CAPABILITY and only include the entries required for
+// that capability.
 func (l *csvlib) GetAllDeviceSpecs() ([]specs.Device, error) {
+	names, err := l.deviceNamers.GetDeviceNames(0, uuidIgnored{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device name: %v", err)
+	}
+
+	e, err := l.getContainerEdits(l.csvDriverCapabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceSpecs []specs.Device
+	for _, name := range names {
+		deviceSpec := specs.Device{
+			Name:           name,
+			ContainerEdits: *e.ContainerEdits,
+		}
+		deviceSpecs = append(deviceSpecs, deviceSpec)
+	}
+
+	if l.csvDriverCapabilities != nil {
+		return deviceSpecs, nil
+	}
+
+	for _, capability := range tegra.CapabilitiesFromCSVFiles(l.logger, l.csvFiles) {
+		e, err := l.getContainerEdits(image.NewDriverCapabilities(capability))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			deviceSpec := specs.Device{
+				Name:           name + "-" + capability,
+				ContainerEdits: *e.ContainerEdits,
+			}
+			deviceSpecs = append(deviceSpecs, deviceSpec)
+		}
+	}
+
+	return deviceSpecs, nil
+}
+
+// getContainerEdits returns the container edits for the entries in the CSV
+// files that are required for the specified driver capabilities.
+func (l *csvlib) getContainerEdits(driverCapabilities image.DriverCapabilities) (*cdi.ContainerEdits, error) {
 	d, err := tegra.New(
 		tegra.WithLogger(l.logger),
 		tegra.WithDriverRoot(l.driverRoot),
@@ -49,6 +97,7 @@ func (l *csvlib) GetAllDeviceSpecs() ([]specs.Device, error) {
 		tegra.WithCSVFiles(l.csvFiles),
 		tegra.WithLibrarySearchPaths(l.librarySearchPaths...),
 		tegra.WithIngorePatterns(l.csvIgnorePatterns...),
+		tegra.WithDriverCapabilities(driverCapabilities),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discoverer for CSV files: %v", err)
@@ -57,21 +106,7 @@ func (l *csvlib) GetAllDeviceSpecs() ([]specs.Device, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container edits for CSV files: %v", err)
 	}
-
-	names, err := l.deviceNamers.GetDeviceNames(0, uuidIgnored{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get device name: %v", err)
-	}
-	var deviceSpecs []specs.Device
-	for _, name := range names {
-		deviceSpec := specs.Device{
-			Name:           name,
-			ContainerEdits: *e.ContainerEdits,
-		}
-		deviceSpecs = append(deviceSpecs, deviceSpec)
-	}
-
-	return deviceSpecs, nil
+	return e, nil
 }
 
 // GetCommonEdits generates a CDI specification that can be used for ANY devices
@@ -29,6 +29,7 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc/devices"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 )
 
@@ -38,6 +39,9 @@ var _ Interface = (*imexlib)(nil)
 
 const (
 	classImexChannel = "imex-channel"
+	// maxImexChannelID is the largest channel ID that can be represented as a
+	// device minor number.
+	maxImexChannelID = 1<<20 - 1
 )
 
 // GetSpec should not be called for imexlib.
@@ -46,7 +50,17 @@ func (l *imexlib) GetSpec() (spec.Interface, error) {
 }
 
 // GetAllDeviceSpecs returns the device specs for all available devices.
+// If IMEX channels have been explicitly specified, device specs are generated
+// for these channels instead of the channels that exist under devRoot.
 func (l *imexlib) GetAllDeviceSpecs() ([]specs.Device, error) {
+	if len(l.imexChannels) > 0 {
+		channelIDs, err := parseImexChannelIDs(l.imexChannels...)
+		if err != nil {
+			return nil, err
+		}
+		return l.GetDeviceSpecsByID(channelIDs...)
+	}
+
 	channelsDiscoverer := discover.NewCharDeviceDiscoverer(
 		l.logger,
 		l.devRoot,
@@ -72,24 +86,43 @@ func (l *imexlib) GetCommonEdits() (*cdi.ContainerEdits, error) {
 }
 
 // GetDeviceSpecsByID returns the CDI device specs for the IMEX channels specified.
+// If the major number for IMEX channels is available from /proc/devices, the
+// device nodes are fully specified so that the nodes need not exist on the
+// host when the spec is generated. Since the device nodes for explicitly
+// specified channels are not expected to exist, the major number is required
+// in this case.
 func (l *imexlib) GetDeviceSpecsByID(ids ...string) ([]specs.Device, error) {
+	major, err := l.getImexChannelMajor()
+	if err != nil && len(l.imexChannels) > 0 {
+		return nil, err
+	}
+	if err != nil {
+		l.logger.Debugf("Not setting IMEX channel major number: %v", err)
+	}
+
 	var deviceSpecs []specs.Device
 	for _, id := range ids {
 		trimmed := strings.TrimPrefix(id, "channel")
-		_, err := strconv.ParseUint(trimmed, 10, 64)
+		minor, err := strconv.ParseUint(trimmed, 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("invalid channel ID %v: %w", id, err)
 		}
 		path := "/dev/nvidia-caps-imex-channels/channel" + trimmed
+		deviceNode := &specs.DeviceNode{
+			Path:     path,
+			HostPath: filepath.Join(l.devRoot, path),
+			UID:      l.imexChannelUID,
+			GID:      l.imexChannelGID,
+		}
+		if major != 0 {
+			deviceNode.Type = "c"
+			deviceNode.Major = int64(major)
+			deviceNode.Minor = int64(minor)
+		}
 		deviceSpec := specs.Device{
 			Name: trimmed,
 			ContainerEdits: specs.ContainerEdits{
-				DeviceNodes: []*specs.DeviceNode{
-					{
-						Path:     path,
-						HostPath: filepath.Join(l.devRoot, path),
-					},
-				},
+				DeviceNodes: []*specs.DeviceNode{deviceNode},
 			},
 		}
 		deviceSpecs = append(deviceSpecs, deviceSpec)
@@ -97,6 +130,70 @@ func (l *imexlib) GetDeviceSpecsByID(ids ...string) ([]specs.Device, error) {
 	return deviceSpecs, nil
 }
 
+// getImexChannelMajor returns the major number of the IMEX channel devices as
+// defined in /proc/devices.
+func (l *imexlib) getImexChannelMajor() (devices.Major, error) {
+	if l.procDevices == nil {
+		procDevices, err := devices.GetNVIDIADevices()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get NVIDIA devices: %w", err)
+		}
+		if procDevices == nil {
+			return 0, fmt.Errorf("no NVIDIA devices found")
+		}
+		l.procDevices = procDevices
+	}
+	major, exists := l.procDevices.Get(devices.NVIDIACapsImexChannels)
+	if !exists {
+		return 0, fmt.Errorf("%v not found in /proc/devices", devices.NVIDIACapsImexChannels)
+	}
+	return major, nil
+}
+
+// parseImexChannelIDs parses the specified channels and returns the list of
+// channel IDs. Each channel is either an ID or an inclusive range of IDs such
+// as 0-2047.
+func parseImexChannelIDs(channels ...string) ([]string, error) {
+	var ids []string
+	seen := make(map[uint64]bool)
+	for _, channel := range channels {
+		first, last, isRange := strings.Cut(strings.TrimSpace(channel), "-")
+		if !isRange {
+			last = first
+		}
+		start, err := parseImexChannelID(first)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseImexChannelID(last)
+		if err != nil {
+			return nil, err
+		}
+		if start > end {
+			return nil, fmt.Errorf("invalid channel range %v: start is larger than end", channel)
+		}
+		for id := start; id <= end; id++ {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, strconv.FormatUint(id, 10))
+		}
+	}
+	return ids, nil
+}
+
+func parseImexChannelID(id string) (uint64, error) {
+	channel, err := strconv.ParseUint(strings.TrimSpace(id), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid channel ID %q: %w", id, err)
+	}
+	if channel > maxImexChannelID {
+		return 0, fmt.Errorf("invalid channel ID %v: exceeds maximum of %v", channel, maxImexChannelID)
+	}
+	return channel, nil
+}
+
 // GetGPUDeviceEdits is unsupported for the imexlib specs
 func (l *imexlib) GetGPUDeviceEdits(device.Device) (*cdi.ContainerEdits, error) {
 	return nil, fmt.Errorf("GetGPUDeviceEdits is not supported")
@@ -25,6 +25,7 @@ import (
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc/devices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
 )
 
@@ -66,6 +67,7 @@ containerEdits:
 		WithLogger(logger),
 		WithMode(ModeImex),
 		WithDriverRoot(hostRoot),
+		withProcDevices(map[string]int{}),
 	)
 	require.NoError(t, err)
 
@@ -78,3 +80,141 @@ containerEdits:
 	require.NoError(t, err)
 	require.Equal(t, expectedSpec, b.String())
 }
+
+func TestImexModeChannels(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		channels      []string
+		procDevices   map[string]int
+		options       []Option
+		expectedError bool
+		expectedSpec  string
+	}{
+		{
+			description: "channel range without device nodes",
+			channels:    []string{"0-1", "4"},
+			procDevices: map[string]int{"nvidia-caps-imex-channels": 234},
+			expectedSpec: `---
+cdiVersion: 0.5.0
+kind: nvidia.com/imex-channel
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia-caps-imex-channels/channel0
+              hostPath: /dev/nvidia-caps-imex-channels/channel0
+              type: c
+              major: 234
+    - name: "1"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia-caps-imex-channels/channel1
+              hostPath: /dev/nvidia-caps-imex-channels/channel1
+              type: c
+              major: 234
+              minor: 1
+    - name: "4"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia-caps-imex-channels/channel4
+              hostPath: /dev/nvidia-caps-imex-channels/channel4
+              type: c
+              major: 234
+              minor: 4
+containerEdits:
+    env:
+        - NVIDIA_VISIBLE_DEVICES=void
+`,
+		},
+		{
+			description: "channel owner is set",
+			channels:    []string{"2047"},
+			procDevices: map[string]int{"nvidia-caps-imex-channels": 234},
+			options:     []Option{WithImexChannelOwner(1000, 1001)},
+			expectedSpec: `---
+cdiVersion: 0.5.0
+kind: nvidia.com/imex-channel
+devices:
+    - name: "2047"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia-caps-imex-channels/channel2047
+              hostPath: /dev/nvidia-caps-imex-channels/channel2047
+              type: c
+              major: 234
+              minor: 2047
+              uid: 1000
+              gid: 1001
+containerEdits:
+    env:
+        - NVIDIA_VISIBLE_DEVICES=void
+`,
+		},
+		{
+			description:   "missing major number is an error",
+			channels:      []string{"0-2047"},
+			procDevices:   map[string]int{"nvidia-caps": 235},
+			expectedError: true,
+		},
+		{
+			description:   "invalid range is an error",
+			channels:      []string{"10-2"},
+			procDevices:   map[string]int{"nvidia-caps-imex-channels": 234},
+			expectedError: true,
+		},
+		{
+			description:   "invalid channel ID is an error",
+			channels:      []string{"channel0"},
+			procDevices:   map[string]int{"nvidia-caps-imex-channels": 234},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			lib, err := New(
+				append([]Option{
+					WithLogger(logger),
+					WithMode(ModeImex),
+					WithImexChannels(tc.channels...),
+					withProcDevices(tc.procDevices),
+				}, tc.options...)...,
+			)
+			require.NoError(t, err)
+
+			spec, err := lib.GetSpec()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			_, err = spec.WriteTo(&b)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSpec, b.String())
+		})
+	}
+}
+
+func TestParseImexChannelIDs(t *testing.T) {
+	ids, err := parseImexChannelIDs("0-2", "1", " 5 ")
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"0", "1", "2", "5"}, ids)
+
+	ids, err = parseImexChannelIDs("0-2047")
+	require.NoError(t, err)
+	require.Len(t, ids, 2048)
+
+	_, err = parseImexChannelIDs("0-1048576")
+	require.Error(t, err)
+}
+
+// withProcDevices sets the devices defined in /proc/devices.
+func withProcDevices(deviceToMajor map[string]int) Option {
+	return func(l *nvcdilib) {
+		l.procDevices = devices.New(devices.WithDeviceToMajor(deviceToMajor))
+	}
+}
@@ -35,6 +35,13 @@ type nvmllib nvcdilib
 
 var _ Interface = (*nvmllib)(nil)
 
+// initNVML initializes NVML and records the time taken.
+func (l *nvmllib) initNVML() nvml.Return {
+	span := l.tracer.Start("nvml.Init")
+	defer span.End()
+	return l.nvmllib.Init()
+}
+
 // GetSpec should not be called for nvmllib
 func (l *nvmllib) GetSpec() (spec.Interface, error) {
 	return nil, fmt.Errorf("unexpected call to nvmllib.GetSpec()")
@@ -44,7 +51,7 @@ func (l *nvmllib) GetSpec() (spec.Interface, error) {
 func (l *nvmllib) GetAllDeviceSpecs() ([]specs.Device, error) {
 	var deviceSpecs []specs.Device
 
-	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
+	if r := l.initNVML(); r != nvml.SUCCESS {
 		return nil, fmt.Errorf("failed to initialize NVML: %v", r)
 	}
 	defer func() {
@@ -78,6 +85,35 @@ func (l *nvmllib) GetAllDeviceSpecs() ([]specs.Device, error) {
 	}
 	deviceSpecs = append(deviceSpecs, migDeviceSpecs...)
 
+	migCapabilityDeviceSpecs, err := l.getMIGCapabilityDeviceSpecs()
+	if err != nil {
+		return nil, err
+	}
+	deviceSpecs = append(deviceSpecs, migCapabilityDeviceSpecs...)
+
+	gpuAffinityDeviceSpecs, err := l.getGPUAffinityDeviceSpecs()
+	if err != nil {
+		return nil, err
+	}
+	deviceSpecs = append(deviceSpecs, gpuAffinityDeviceSpecs...)
+
+	return deviceSpecs, nil
+}
+
+// getAllDeviceSpecsForAllDevice returns the device specs for all available
+// devices that are included in the "all" device.
+func (l *nvmllib) getAllDeviceSpecsForAllDevice() ([]specs.Device, error) {
+	allDeviceSpecs, err := l.GetAllDeviceSpecs()
+	if err != nil {
+		return nil, err
+	}
+	var deviceSpecs []specs.Device
+	for _, deviceSpec := range allDeviceSpecs {
+		if IsExcludedFromAllDevice(deviceSpec) {
+			continue
+		}
+		deviceSpecs = append(deviceSpecs, deviceSpec)
+	}
 	return deviceSpecs, nil
 }
 
@@ -104,16 +140,19 @@ func (l *nvmllib) GetDeviceSpecsByID(ids ...string) ([]specs.Device, error) {
 }
 
 // GetDeviceSpecsBy returns the device specs for devices with the specified identifiers.
+// If "all" devices are requested, the devices that are excluded from the "all"
+// device (e.g. the mig-config, mig-monitor, and gpu-affinity devices) are not
+// returned since these must be requested explicitly.
 func (l *nvmllib) GetDeviceSpecsBy(identifiers ...device.Identifier) ([]specs.Device, error) {
 	for _, id := range identifiers {
 		if id == "all" {
-			return l.GetAllDeviceSpecs()
+			return l.getAllDeviceSpecsForAllDevice()
 		}
 	}
 
 	var deviceSpecs []specs.Device
 
-	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
+	if r := l.initNVML(); r != nvml.SUCCESS {
 		return nil, fmt.Errorf("failed to initialize NVML: %w", r)
 	}
 	defer func() {
@@ -23,10 +23,16 @@ import (
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/infiniband"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc/devices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvsandboxutils"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 )
 
@@ -44,8 +50,32 @@ type nvcdilib struct {
 	configSearchPaths  []string
 	librarySearchPaths []string
 
-	csvFiles          []string
-	csvIgnorePatterns []string
+	csvFiles              []string
+	csvIgnorePatterns     []string
+	csvDriverCapabilities image.DriverCapabilities
+
+	imexChannels   []string
+	imexChannelUID *uint32
+	imexChannelGID *uint32
+	// procDevices is used to determine the major number of the IMEX channels.
+	// This is overridden for testing.
+	procDevices devices.Devices
+	// migCaps is used to determine the MIG capability device nodes. This is
+	// overridden for testing.
+	migCaps nvcaps.MigCaps
+	// sysRoot is the path at which sysfs is mounted. This is used to discover
+	// the RDMA devices and the PCIe topology and is overridden for testing.
+	sysRoot string
+	// procRoot is the path at which procfs is mounted. This is used to locate
+	// the files that grant the MIG capabilities and is overridden for testing.
+	procRoot string
+
+	gpuAffinityDevices bool
+	cleanupHook        bool
+
+	// tracer records the time taken to generate CDI specs. This is nil if
+	// tracing is disabled.
+	tracer *tracing.Tracer
 
 	vendor string
 	class  string
@@ -148,6 +178,9 @@ func New(opts ...Option) (Interface, error) {
 		l.disabledHooks[HookEnableCudaCompat] = true
 		lib = (*managementlib)(l)
 	case ModeNvml:
+		if l.sysRoot == "" {
+			l.sysRoot = infiniband.DefaultSysRoot
+		}
 		lib = (*nvmllib)(l)
 	case ModeWsl:
 		lib = (*wsllib)(l)
@@ -160,6 +193,9 @@ func New(opts ...Option) (Interface, error) {
 		if l.class == "" {
 			l.class = "mofed"
 		}
+		if l.sysRoot == "" {
+			l.sysRoot = infiniband.DefaultSysRoot
+		}
 		lib = (*mofedlib)(l)
 	case ModeImex:
 		if l.class == "" {
@@ -175,6 +211,10 @@ func New(opts ...Option) (Interface, error) {
 		vendor:              l.vendor,
 		class:               l.class,
 		mergedDeviceOptions: l.mergedDeviceOptions,
+		tracer:              l.tracer,
+	}
+	if l.cleanupHook {
+		w.poststopHooks = append(w.poststopHooks, discover.CreateCleanupHook(l.nvidiaCDIHookPath))
 	}
 	return &w, nil
 }
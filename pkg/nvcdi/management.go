@@ -28,6 +28,7 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/cuda"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvsandboxutils"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 )
@@ -36,8 +37,17 @@ type managementlib nvcdilib
 
 var _ Interface = (*managementlib)(nil)
 
+const (
+	// ManagementMonitoringDeviceName is the name of the device in the
+	// management spec that is intended for read-only monitoring containers
+	// such as the DCGM exporter.
+	ManagementMonitoringDeviceName = "monitoring"
+)
+
 // GetAllDeviceSpecs returns all device specs for use in managemnt containers.
-// A single device with the name `all` is returned.
+// A device with the name `all` that includes all device nodes is returned
+// along with a `monitoring` device that only includes the device nodes
+// required to query the GPUs using NVML.
 func (m *managementlib) GetAllDeviceSpecs() ([]specs.Device, error) {
 	devices, err := m.newManagementDeviceDiscoverer()
 	if err != nil {
@@ -57,7 +67,36 @@ func (m *managementlib) GetAllDeviceSpecs() ([]specs.Device, error) {
 		Name:           "all",
 		ContainerEdits: *edits.ContainerEdits,
 	}
-	return []specs.Device{device}, nil
+
+	monitoringDevice, err := m.getMonitoringDeviceSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	return []specs.Device{device, *monitoringDevice}, nil
+}
+
+// getMonitoringDeviceSpec returns the device spec for monitoring containers.
+// This only includes the control device node, the GPU device nodes, and the
+// MIG monitor capability. Notably the nvidia-uvm device nodes, which are
+// required to run CUDA applications, the nvidia-modeset device node, and the
+// MIG config capability are excluded.
+func (m *managementlib) getMonitoringDeviceSpec() (*specs.Device, error) {
+	devices, err := m.newMonitoringDeviceDiscoverer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create monitoring device discoverer: %v", err)
+	}
+
+	edits, err := edits.FromDiscoverer(devices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create edits from discoverer: %v", err)
+	}
+
+	device := specs.Device{
+		Name:           ManagementMonitoringDeviceName,
+		ContainerEdits: *edits.ContainerEdits,
+	}
+	return &device, nil
 }
 
 // GetCommonEdits returns the common edits for use in managementlib containers.
@@ -149,6 +188,39 @@ func (m *managementlib) newManagementDeviceDiscoverer() (discover.Discover, erro
 	return d, nil
 }
 
+// newMonitoringDeviceDiscoverer returns a discover.Discover that discovers the
+// device nodes for use in monitoring containers.
+func (m *managementlib) newMonitoringDeviceDiscoverer() (discover.Discover, error) {
+	required := []string{
+		"/dev/nvidiactl",
+		"/dev/nvidia[0-9]*",
+	}
+	if migCaps := (*nvmllib)(m).getMigCaps(); migCaps != nil {
+		if path, err := migCaps.GetCapDevicePath(nvcaps.MigMonitorCap); err == nil {
+			required = append(required, path)
+		}
+	}
+
+	deviceNodes := discover.NewCharDeviceDiscoverer(
+		m.logger,
+		m.devRoot,
+		required,
+	)
+
+	deviceFolderPermissionHooks := newDeviceFolderPermissionHookDiscoverer(
+		m.logger,
+		m.devRoot,
+		m.nvidiaCDIHookPath,
+		deviceNodes,
+	)
+
+	d := discover.Merge(
+		deviceNodes,
+		deviceFolderPermissionHooks,
+	)
+	return d, nil
+}
+
 func (m *managementDiscoverer) Devices() ([]discover.Device, error) {
 	devices, err := m.Discover.Devices()
 	if err != nil {
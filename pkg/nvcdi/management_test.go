@@ -0,0 +1,94 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+)
+
+func TestManagementDeviceSpecs(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	devRoot := t.TempDir()
+	for _, path := range []string{
+		"/dev/nvidia0",
+		"/dev/nvidia1",
+		"/dev/nvidiactl",
+		"/dev/nvidia-modeset",
+		"/dev/nvidia-uvm",
+		"/dev/nvidia-uvm-tools",
+		"/dev/nvidia-caps/nvidia-cap1",
+		"/dev/nvidia-caps/nvidia-cap2",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(devRoot, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(devRoot, path), nil, 0600))
+	}
+
+	m := &managementlib{
+		logger:            logger,
+		devRoot:           devRoot,
+		nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+		migCaps: nvcaps.MigCaps{
+			nvcaps.MigConfigCap:  1,
+			nvcaps.MigMonitorCap: 2,
+		},
+	}
+
+	deviceSpecs, err := m.GetAllDeviceSpecs()
+	require.NoError(t, err)
+	require.Len(t, deviceSpecs, 2)
+
+	devicePaths := make(map[string][]string)
+	for _, d := range deviceSpecs {
+		for _, dn := range d.ContainerEdits.DeviceNodes {
+			devicePaths[d.Name] = append(devicePaths[d.Name], dn.Path)
+		}
+		sort.Strings(devicePaths[d.Name])
+	}
+
+	require.EqualValues(t,
+		[]string{
+			"/dev/nvidia-caps/nvidia-cap1",
+			"/dev/nvidia-caps/nvidia-cap2",
+			"/dev/nvidia-modeset",
+			"/dev/nvidia-uvm",
+			"/dev/nvidia-uvm-tools",
+			"/dev/nvidia0",
+			"/dev/nvidia1",
+			"/dev/nvidiactl",
+		},
+		devicePaths["all"],
+	)
+	require.EqualValues(t,
+		[]string{
+			"/dev/nvidia-caps/nvidia-cap2",
+			"/dev/nvidia0",
+			"/dev/nvidia1",
+			"/dev/nvidiactl",
+		},
+		devicePaths[ManagementMonitoringDeviceName],
+	)
+}
@@ -0,0 +1,160 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/dgpu"
+)
+
+// migCapabilityDeviceSuffixes maps the global MIG capabilities to the suffixes
+// of the names of the devices that grant them.
+var migCapabilityDeviceSuffixes = []struct {
+	migCap nvcaps.MigCap
+	suffix string
+}{
+	{nvcaps.MigConfigCap, "-mig-config"},
+	{nvcaps.MigMonitorCap, "-mig-monitor"},
+}
+
+// GetMIGCapability returns the global MIG capability (config or monitor) that
+// is granted by the specified device. A device grants a capability if it
+// mounts the procfs file that is checked by MIG management tools for the
+// capability. The device name is not considered.
+func GetMIGCapability(d specs.Device) (nvcaps.MigCap, bool) {
+	for _, m := range d.ContainerEdits.Mounts {
+		if m == nil {
+			continue
+		}
+		for _, c := range migCapabilityDeviceSuffixes {
+			if m.ContainerPath == c.migCap.ProcPath() {
+				return c.migCap, true
+			}
+		}
+	}
+	return "", false
+}
+
+// IsMIGCapabilityDevice checks whether the specified device is a mig-config
+// or mig-monitor device. Since these devices grant access to the MIG instances
+// of all tenants of a GPU, they should not be included in merged devices such
+// as 'all'.
+func IsMIGCapabilityDevice(d specs.Device) bool {
+	_, ok := GetMIGCapability(d)
+	return ok
+}
+
+// getMIGCapabilityDeviceSpecs returns the mig-config and mig-monitor device
+// specs for all MIG-capable GPUs.
+func (l *nvmllib) getMIGCapabilityDeviceSpecs() ([]specs.Device, error) {
+	migCaps := l.getMigCaps()
+	if migCaps == nil {
+		return nil, nil
+	}
+
+	var deviceSpecs []specs.Device
+	err := l.devicelib.VisitDevices(func(i int, d device.Device) error {
+		isMigCapable, err := d.IsMigCapable()
+		if err != nil {
+			return fmt.Errorf("error checking if device is MIG capable: %v", err)
+		}
+		if !isMigCapable {
+			return nil
+		}
+		specsForDevice, err := l.getMIGCapabilityDeviceSpecsForGPU(i, d, migCaps)
+		if err != nil {
+			return err
+		}
+		deviceSpecs = append(deviceSpecs, specsForDevice...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CDI edits for MIG capability devices: %v", err)
+	}
+	return deviceSpecs, nil
+}
+
+// getMIGCapabilityDeviceSpecsForGPU returns the mig-config and mig-monitor
+// device specs for the specified GPU. The device names are the names of the
+// GPU with a -mig-config or -mig-monitor suffix. Since the devices are
+// identified by the procfs file for the capability, a device is skipped if
+// this file does not exist.
+func (l *nvmllib) getMIGCapabilityDeviceSpecsForGPU(i int, d device.Device, migCaps nvcaps.MigCaps) ([]specs.Device, error) {
+	names, err := l.deviceNamers.GetDeviceNames(i, convert{d})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device name: %v", err)
+	}
+
+	var deviceSpecs []specs.Device
+	for _, c := range migCapabilityDeviceSuffixes {
+		if _, err := migCaps.GetCapDevicePath(c.migCap); err != nil {
+			l.logger.Debugf("Skipping %v device: %v", c.migCap, err)
+			continue
+		}
+		discoverer, err := dgpu.NewForMigCapability(d, c.migCap,
+			dgpu.WithDevRoot(l.devRoot),
+			dgpu.WithProcRoot(l.procRoot),
+			dgpu.WithLogger(l.logger),
+			dgpu.WithMIGCaps(migCaps),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %v device discoverer: %v", c.migCap, err)
+		}
+		editsForDevice, err := edits.FromDiscoverer(discoverer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create container edits for %v device: %v", c.migCap, err)
+		}
+		if !IsMIGCapabilityDevice(specs.Device{ContainerEdits: *editsForDevice.ContainerEdits}) {
+			l.logger.Warningf("Skipping %v device: %v not found", c.migCap, c.migCap.ProcPath())
+			continue
+		}
+		// The order of the discovered device nodes is not guaranteed.
+		slices.SortFunc(editsForDevice.DeviceNodes, func(a, b *specs.DeviceNode) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+		for _, name := range names {
+			deviceSpecs = append(deviceSpecs, specs.Device{
+				Name:           name + c.suffix,
+				ContainerEdits: *editsForDevice.ContainerEdits,
+			})
+		}
+	}
+	return deviceSpecs, nil
+}
+
+// getMigCaps returns the MIG capabilities of the system. If these cannot be
+// determined, nil is returned.
+func (l *nvmllib) getMigCaps() nvcaps.MigCaps {
+	if l.migCaps != nil {
+		return l.migCaps
+	}
+	migCaps, err := nvcaps.NewMigCaps()
+	if err != nil {
+		l.logger.Warningf("Ignoring error getting MIG capabilities: %v", err)
+		return nil
+	}
+	l.migCaps = migCaps
+	return migCaps
+}
@@ -0,0 +1,255 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+)
+
+func TestGetMIGCapabilityDeviceSpecs(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	devRoot := t.TempDir()
+	for _, path := range []string{"/dev/nvidia0", "/dev/nvidia-caps/nvidia-cap1", "/dev/nvidia-caps/nvidia-cap2"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(devRoot, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(devRoot, path), nil, 0600))
+	}
+
+	procRoot := t.TempDir()
+	for _, path := range []string{nvcaps.MigConfigCap.ProcPath(), nvcaps.MigMonitorCap.ProcPath()} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(procRoot, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(procRoot, path), nil, 0600))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(procRoot, nvcaps.GPUMigProcPath(0)), 0755))
+
+	mounts := func(paths ...string) []*specs.Mount {
+		var mounts []*specs.Mount
+		for _, path := range paths {
+			mounts = append(mounts, &specs.Mount{
+				HostPath:      filepath.Join(procRoot, path),
+				ContainerPath: path,
+				Options:       []string{"ro", "nosuid", "nodev", "bind"},
+			})
+		}
+		return mounts
+	}
+
+	deviceNodes := func(paths ...string) []*specs.DeviceNode {
+		var nodes []*specs.DeviceNode
+		for _, path := range paths {
+			nodes = append(nodes, &specs.DeviceNode{Path: path, HostPath: filepath.Join(devRoot, path)})
+		}
+		return nodes
+	}
+
+	testCases := []struct {
+		description     string
+		migCaps         nvcaps.MigCaps
+		migMode         nvml.Return
+		expectedDevices []specs.Device
+	}{
+		{
+			description: "config and monitor devices are generated",
+			migCaps: nvcaps.MigCaps{
+				nvcaps.MigConfigCap:  1,
+				nvcaps.MigMonitorCap: 2,
+			},
+			migMode: nvml.SUCCESS,
+			expectedDevices: []specs.Device{
+				{
+					Name: "0-mig-config",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: deviceNodes("/dev/nvidia-caps/nvidia-cap1", "/dev/nvidia0"),
+						Mounts:      mounts(nvcaps.MigConfigCap.ProcPath(), nvcaps.GPUMigProcPath(0)),
+					},
+				},
+				{
+					Name: "0-mig-monitor",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: deviceNodes("/dev/nvidia-caps/nvidia-cap2", "/dev/nvidia0"),
+						Mounts:      mounts(nvcaps.MigMonitorCap.ProcPath()),
+					},
+				},
+			},
+		},
+		{
+			description: "missing capability is skipped",
+			migCaps: nvcaps.MigCaps{
+				nvcaps.MigMonitorCap: 2,
+			},
+			migMode: nvml.SUCCESS,
+			expectedDevices: []specs.Device{
+				{
+					Name: "0-mig-monitor",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: deviceNodes("/dev/nvidia-caps/nvidia-cap2", "/dev/nvidia0"),
+						Mounts:      mounts(nvcaps.MigMonitorCap.ProcPath()),
+					},
+				},
+			},
+		},
+		{
+			description: "non-MIG-capable devices are skipped",
+			migCaps: nvcaps.MigCaps{
+				nvcaps.MigConfigCap:  1,
+				nvcaps.MigMonitorCap: 2,
+			},
+			migMode: nvml.ERROR_NOT_SUPPORTED,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 1, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				d.(*dgxa100.Device).GetMigModeFunc = func() (int, int, nvml.Return) {
+					return 0, 0, tc.migMode
+				}
+			}
+
+			namer, err := NewDeviceNamer(DeviceNameStrategyIndex)
+			require.NoError(t, err)
+
+			l := &nvmllib{
+				logger:       logger,
+				nvmllib:      server,
+				devicelib:    device.New(server),
+				deviceNamers: DeviceNamers{namer},
+				devRoot:      devRoot,
+				migCaps:      tc.migCaps,
+				procRoot:     procRoot,
+			}
+
+			deviceSpecs, err := l.getMIGCapabilityDeviceSpecs()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, deviceSpecs)
+		})
+	}
+}
+
+// migCapabilityDevice returns a device that grants the specified MIG
+// capability.
+func migCapabilityDevice(name string, migCap nvcaps.MigCap) specs.Device {
+	return specs.Device{
+		Name: name,
+		ContainerEdits: specs.ContainerEdits{
+			Mounts: []*specs.Mount{{HostPath: migCap.ProcPath(), ContainerPath: migCap.ProcPath()}},
+		},
+	}
+}
+
+func TestGetMIGCapability(t *testing.T) {
+	migCap, ok := GetMIGCapability(migCapabilityDevice("0-mig-config", nvcaps.MigConfigCap))
+	require.True(t, ok)
+	require.Equal(t, nvcaps.MigConfigCap, migCap)
+
+	migCap, ok = GetMIGCapability(migCapabilityDevice("0", nvcaps.MigMonitorCap))
+	require.True(t, ok)
+	require.Equal(t, nvcaps.MigMonitorCap, migCap)
+
+	_, ok = GetMIGCapability(specs.Device{Name: "0-mig-config"})
+	require.False(t, ok)
+
+	_, ok = GetMIGCapability(specs.Device{
+		Name: "0:1",
+		ContainerEdits: specs.ContainerEdits{
+			Mounts: []*specs.Mount{{ContainerPath: nvcaps.MigCap("gpu0/gi1/access").ProcPath()}},
+		},
+	})
+	require.False(t, ok)
+}
+
+func TestGetDeviceSpecsByAllExcludesMIGCapabilityDevices(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	devRoot := t.TempDir()
+	for _, path := range []string{"/dev/nvidia0", "/dev/nvidiactl", "/dev/nvidia-caps/nvidia-cap1", "/dev/nvidia-caps/nvidia-cap2"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(devRoot, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(devRoot, path), nil, 0600))
+	}
+
+	procRoot := t.TempDir()
+	for _, path := range []string{nvcaps.MigConfigCap.ProcPath(), nvcaps.MigMonitorCap.ProcPath()} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(procRoot, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(procRoot, path), nil, 0600))
+	}
+
+	server := dgxa100.New()
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		d.(*dgxa100.Device).GetMigModeFunc = func() (int, int, nvml.Return) {
+			return 0, 0, nvml.SUCCESS
+		}
+		d.(*dgxa100.Device).GetVirtualizationModeFunc = func() (nvml.GpuVirtualizationMode, nvml.Return) {
+			return nvml.GPU_VIRTUALIZATION_MODE_NONE, nvml.SUCCESS
+		}
+		d.(*dgxa100.Device).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+	}
+
+	namer, err := NewDeviceNamer(DeviceNameStrategyIndex)
+	require.NoError(t, err)
+
+	l := &nvmllib{
+		logger:       logger,
+		nvmllib:      server,
+		devicelib:    device.New(server),
+		deviceNamers: DeviceNamers{namer},
+		devRoot:      devRoot,
+		driverRoot:   devRoot,
+		migCaps: nvcaps.MigCaps{
+			nvcaps.MigConfigCap:  1,
+			nvcaps.MigMonitorCap: 2,
+		},
+		procRoot: procRoot,
+	}
+
+	allDeviceSpecs, err := l.GetAllDeviceSpecs()
+	require.NoError(t, err)
+	var allNames []string
+	for _, deviceSpec := range allDeviceSpecs {
+		allNames = append(allNames, deviceSpec.Name)
+	}
+	require.Contains(t, allNames, "0-mig-config")
+	require.Contains(t, allNames, "0-mig-monitor")
+
+	deviceSpecs, err := l.GetDeviceSpecsBy("all")
+	require.NoError(t, err)
+	require.NotEmpty(t, deviceSpecs)
+	for _, deviceSpec := range deviceSpecs {
+		require.False(t, IsExcludedFromAllDevice(deviceSpec), "unexpected device %v", deviceSpec.Name)
+	}
+}
@@ -66,6 +66,7 @@ func getModes() modes {
 			ModeGds,
 			ModeMofed,
 			ModeCSV,
+			ModeImex,
 		}
 		lookup := make(map[Mode]bool)
 
@@ -25,14 +25,24 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/infiniband"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 )
 
+// rdmaCMDeviceName is the name of both the device node and the CDI device for
+// the RDMA connection manager.
+const rdmaCMDeviceName = "rdma_cm"
+
 type mofedlib nvcdilib
 
 var _ Interface = (*mofedlib)(nil)
 
 // GetAllDeviceSpecs returns the device specs for all available devices.
+// In addition to the 'all' device, a device is generated for each RDMA device
+// (HCA) and each of its ports. These are named by RDMA device name (e.g.
+// mlx5_0 and mlx5_0-port1) to allow containers to request specific NICs.
+// Since the RDMA connection manager is not associated with a specific HCA, it
+// is exposed as a separate rdma_cm device that must be requested explicitly.
 func (l *mofedlib) GetAllDeviceSpecs() ([]specs.Device, error) {
 	discoverer, err := discover.NewMOFEDDiscoverer(l.logger, l.driverRoot)
 	if err != nil {
@@ -48,7 +58,88 @@ func (l *mofedlib) GetAllDeviceSpecs() ([]specs.Device, error) {
 		ContainerEdits: *edits.ContainerEdits,
 	}
 
-	return []specs.Device{deviceSpec}, nil
+	rdmaDeviceSpecs, err := l.getRDMADeviceSpecs()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(rdmaDeviceSpecs, deviceSpec), nil
+}
+
+// getRDMADeviceSpecs returns the device specs for the RDMA devices listed in
+// sysfs. A device spec is generated for each HCA and for each port of an HCA.
+// Each of these include the uverbs device nodes of the HCA. An HCA device also
+// includes the umad device nodes of all its ports, whereas a port device only
+// includes the umad device nodes associated with that port. If the rdma_cm
+// device node exists, an rdma_cm device is also generated.
+func (l *mofedlib) getRDMADeviceSpecs() ([]specs.Device, error) {
+	rdmaDevices, err := infiniband.GetDevices(l.sysRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RDMA devices: %v", err)
+	}
+
+	var deviceSpecs []specs.Device
+	for _, rdmaDevice := range rdmaDevices {
+		if len(rdmaDevice.VerbsDeviceNodes) == 0 {
+			l.logger.Warningf("Skipping RDMA device %v with no uverbs device nodes", rdmaDevice.Name)
+			continue
+		}
+
+		var deviceNodes []string
+		deviceNodes = append(deviceNodes, rdmaDevice.VerbsDeviceNodes...)
+		for _, port := range rdmaDevice.Ports {
+			deviceNodes = append(deviceNodes, port.MADDeviceNodes...)
+		}
+		deviceSpec, err := l.getRDMADeviceSpec(rdmaDevice.Name, deviceNodes)
+		if err != nil {
+			return nil, err
+		}
+		deviceSpecs = append(deviceSpecs, *deviceSpec)
+
+		for _, port := range rdmaDevice.Ports {
+			name := fmt.Sprintf("%s-port%d", rdmaDevice.Name, port.Number)
+			var deviceNodes []string
+			deviceNodes = append(deviceNodes, rdmaDevice.VerbsDeviceNodes...)
+			deviceNodes = append(deviceNodes, port.MADDeviceNodes...)
+			deviceSpec, err := l.getRDMADeviceSpec(name, deviceNodes)
+			if err != nil {
+				return nil, err
+			}
+			deviceSpecs = append(deviceSpecs, *deviceSpec)
+		}
+	}
+	if len(deviceSpecs) == 0 {
+		return nil, nil
+	}
+
+	deviceSpec, err := l.getRDMADeviceSpec(rdmaCMDeviceName, []string{rdmaCMDeviceName})
+	if err != nil {
+		return nil, err
+	}
+	if len(deviceSpec.ContainerEdits.DeviceNodes) == 0 {
+		l.logger.Debugf("Skipping %v device: device node not found", rdmaCMDeviceName)
+		return deviceSpecs, nil
+	}
+	return append(deviceSpecs, *deviceSpec), nil
+}
+
+func (l *mofedlib) getRDMADeviceSpec(name string, deviceNodes []string) (*specs.Device, error) {
+	var paths []string
+	for _, deviceNode := range deviceNodes {
+		paths = append(paths, infiniband.DeviceNodePath(deviceNode))
+	}
+
+	discoverer := discover.NewCharDeviceDiscoverer(l.logger, l.devRoot, paths)
+	edits, err := edits.FromDiscoverer(discoverer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container edits for RDMA device %v: %v", name, err)
+	}
+
+	deviceSpec := specs.Device{
+		Name:           name,
+		ContainerEdits: *edits.ContainerEdits,
+	}
+	return &deviceSpec, nil
 }
 
 // GetCommonEdits generates a CDI specification that can be used for ANY devices
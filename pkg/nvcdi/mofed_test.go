@@ -0,0 +1,143 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestMofedMode(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	hostRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-rdma")
+
+	expectedSpec := `---
+cdiVersion: 0.5.0
+kind: nvidia.com/mofed
+devices:
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: /dev/infiniband/rdma_cm
+              hostPath: {{ .hostRoot }}/dev/infiniband/rdma_cm
+            - path: /dev/infiniband/uverbs0
+              hostPath: {{ .hostRoot }}/dev/infiniband/uverbs0
+            - path: /dev/infiniband/uverbs1
+              hostPath: {{ .hostRoot }}/dev/infiniband/uverbs1
+    - name: mlx5_0
+      containerEdits:
+        deviceNodes:
+            - path: /dev/infiniband/umad0
+              hostPath: {{ .hostRoot }}/dev/infiniband/umad0
+            - path: /dev/infiniband/uverbs0
+              hostPath: {{ .hostRoot }}/dev/infiniband/uverbs0
+    - name: mlx5_0-port1
+      containerEdits:
+        deviceNodes:
+            - path: /dev/infiniband/umad0
+              hostPath: {{ .hostRoot }}/dev/infiniband/umad0
+            - path: /dev/infiniband/uverbs0
+              hostPath: {{ .hostRoot }}/dev/infiniband/uverbs0
+    - name: mlx5_1
+      containerEdits:
+        deviceNodes:
+            - path: /dev/infiniband/umad1
+              hostPath: {{ .hostRoot }}/dev/infiniband/umad1
+            - path: /dev/infiniband/umad2
+              hostPath: {{ .hostRoot }}/dev/infiniband/umad2
+            - path: /dev/infiniband/uverbs1
+              hostPath: {{ .hostRoot }}/dev/infiniband/uverbs1
+    - name: mlx5_1-port1
+      containerEdits:
+        deviceNodes:
+            - path: /dev/infiniband/umad1
+              hostPath: {{ .hostRoot }}/dev/infiniband/umad1
+            - path: /dev/infiniband/uverbs1
+              hostPath: {{ .hostRoot }}/dev/infiniband/uverbs1
+    - name: mlx5_1-port2
+      containerEdits:
+        deviceNodes:
+            - path: /dev/infiniband/umad2
+              hostPath: {{ .hostRoot }}/dev/infiniband/umad2
+            - path: /dev/infiniband/uverbs1
+              hostPath: {{ .hostRoot }}/dev/infiniband/uverbs1
+    - name: rdma_cm
+      containerEdits:
+        deviceNodes:
+            - path: /dev/infiniband/rdma_cm
+              hostPath: {{ .hostRoot }}/dev/infiniband/rdma_cm
+containerEdits:
+    env:
+        - NVIDIA_VISIBLE_DEVICES=void
+`
+	expectedSpec = strings.ReplaceAll(expectedSpec, "{{ .hostRoot }}", hostRoot)
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeMofed),
+		WithDriverRoot(hostRoot),
+		withSysRoot(filepath.Join(hostRoot, "sys")),
+	)
+	require.NoError(t, err)
+
+	spec, err := lib.GetSpec()
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	_, err = spec.WriteTo(&b)
+	require.NoError(t, err)
+	require.Equal(t, expectedSpec, b.String())
+}
+
+func TestMofedModeWithoutRDMADevices(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+
+	logger, _ := testlog.NewNullLogger()
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeMofed),
+		WithDriverRoot(t.TempDir()),
+		withSysRoot(t.TempDir()),
+	)
+	require.NoError(t, err)
+
+	deviceSpecs, err := lib.GetAllDeviceSpecs()
+	require.NoError(t, err)
+	require.Len(t, deviceSpecs, 1)
+	require.Equal(t, "all", deviceSpecs[0].Name)
+}
+
+// withSysRoot sets the path at which sysfs is mounted.
+func withSysRoot(sysRoot string) Option {
+	return func(l *nvcdilib) {
+		l.sysRoot = sysRoot
+	}
+}
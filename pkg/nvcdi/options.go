@@ -21,7 +21,9 @@ import (
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 )
 
@@ -141,6 +143,54 @@ func WithCSVIgnorePatterns(csvIgnorePatterns []string) Option {
 	}
 }
 
+// WithCSVDriverCapabilities sets the driver capabilities that the entries in
+// the CSV files are filtered by. If these are not set, a device is generated
+// for all entries as well as a device for each of the driver capabilities that
+// the entries are tagged with.
+func WithCSVDriverCapabilities(driverCapabilities image.DriverCapabilities) Option {
+	return func(o *nvcdilib) {
+		o.csvDriverCapabilities = driverCapabilities
+	}
+}
+
+// WithImexChannels sets the IMEX channels to generate specs for in IMEX mode.
+// Each channel is specified as an ID (e.g. 0) or an inclusive range of IDs
+// (e.g. 0-2047). The device nodes for the channels need not exist. If no
+// channels are specified, specs are generated for the existing channels.
+func WithImexChannels(channels ...string) Option {
+	return func(o *nvcdilib) {
+		o.imexChannels = channels
+	}
+}
+
+// WithImexChannelOwner sets the owner of the IMEX channel device nodes in the
+// container.
+func WithImexChannelOwner(uid uint32, gid uint32) Option {
+	return func(o *nvcdilib) {
+		o.imexChannelUID = &uid
+		o.imexChannelGID = &gid
+	}
+}
+
+// WithGPUAffinityDevices sets whether gpu-affinity devices are generated.
+// A gpu-affinity device includes a GPU as well as the RDMA devices (NICs)
+// that are attached to the same PCIe switch.
+// This option only applies to nvml mode.
+func WithGPUAffinityDevices(enabled bool) Option {
+	return func(o *nvcdilib) {
+		o.gpuAffinityDevices = enabled
+	}
+}
+
+// WithCleanupHook sets whether a poststop hook is added to the common edits
+// of the generated spec. This hook removes the host artifacts that were
+// tracked for a container once it has stopped.
+func WithCleanupHook(enabled bool) Option {
+	return func(o *nvcdilib) {
+		o.cleanupHook = enabled
+	}
+}
+
 // WithConfigSearchPaths sets the search paths for config files.
 func WithConfigSearchPaths(paths []string) Option {
 	return func(o *nvcdilib) {
@@ -166,3 +216,11 @@ func WithDisabledHook(hook HookName) Option {
 		o.disabledHooks[hook] = true
 	}
 }
+
+// WithTracer sets the tracer used to record the time taken to generate CDI
+// specs.
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(o *nvcdilib) {
+		o.tracer = tracer
+	}
+}
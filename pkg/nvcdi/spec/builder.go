@@ -33,6 +33,7 @@ type builder struct {
 	class       string
 	deviceSpecs []cdi.Device
 	edits       cdi.ContainerEdits
+	annotations map[string]string
 	format      string
 
 	mergedDeviceOptions []transform.MergedDeviceOption
@@ -88,6 +89,7 @@ func (o *builder) Build() (*spec, error) {
 		raw = &cdi.Spec{
 			Version:        o.version,
 			Kind:           fmt.Sprintf("%s/%s", o.vendor, o.class),
+			Annotations:    o.annotations,
 			Devices:        o.deviceSpecs,
 			ContainerEdits: o.edits,
 		}
@@ -139,6 +141,13 @@ func WithEdits(edits cdi.ContainerEdits) Option {
 	}
 }
 
+// WithAnnotations sets the spec-level annotations for the spec builder
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *builder) {
+		o.annotations = annotations
+	}
+}
+
 // WithVersion sets the version for the spec builder
 func WithVersion(version string) Option {
 	return func(o *builder) {
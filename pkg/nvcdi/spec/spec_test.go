@@ -146,6 +146,24 @@ containerEdits:
     deviceNodes:
         - path: /dev/dev0
           hostPath: /some/dev/dev0
+`,
+		},
+		{
+			description: "spec with annotations uses 0.6.0 version",
+			options: []Option{
+				WithDeviceSpecs(minimalSpec.Devices),
+				WithAnnotations(map[string]string{"nvidia.com/driver-root": "/run/nvidia/driver"}),
+			},
+			expectedSpec: `---
+cdiVersion: 0.6.0
+kind: nvidia.com/gpu
+annotations:
+    nvidia.com/driver-root: /run/nvidia/driver
+devices:
+    - name: one
+      containerEdits:
+        env:
+            - DEVICE_FOO=bar
 `,
 		},
 		{
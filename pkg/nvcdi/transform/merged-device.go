@@ -33,6 +33,7 @@ const (
 type mergedDevice struct {
 	name         string
 	skipIfExists bool
+	filter       func(specs.Device) bool
 	simplifier   Transformer
 }
 
@@ -55,6 +56,14 @@ func WithSkipIfExists(skipIfExists bool) MergedDeviceOption {
 	}
 }
 
+// WithFilter specifies a filter to exclude devices from the merged device.
+// Devices for which the filter returns true are excluded.
+func WithFilter(filter func(specs.Device) bool) MergedDeviceOption {
+	return func(m *mergedDevice) {
+		m.filter = filter
+	}
+}
+
 // NewMergedDevice creates a transformer with the specified options
 func NewMergedDevice(opts ...MergedDeviceOption) (Transformer, error) {
 	m := &mergedDevice{}
@@ -79,7 +88,18 @@ func (m mergedDevice) Transform(spec *specs.Spec) error {
 		return nil
 	}
 
-	mergedDevice, err := mergeDeviceSpecs(spec.Devices, m.name)
+	deviceSpecs := spec.Devices
+	if m.filter != nil {
+		deviceSpecs = nil
+		for _, d := range spec.Devices {
+			if m.filter(d) {
+				continue
+			}
+			deviceSpecs = append(deviceSpecs, d)
+		}
+	}
+
+	mergedDevice, err := mergeDeviceSpecs(deviceSpecs, m.name)
 	if err != nil {
 		return fmt.Errorf("failed to generate merged device %q: %v", m.name, err)
 	}
@@ -127,6 +127,7 @@ func TestMergeDeviceSpecs(t *testing.T) {
 func TestMergedDevice(t *testing.T) {
 	testCases := []struct {
 		description   string
+		options       []MergedDeviceOption
 		spec          *specs.Spec
 		expectedError error
 		expectedSpec  *specs.Spec
@@ -202,11 +203,57 @@ func TestMergedDevice(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "filtered devices are not merged",
+			options: []MergedDeviceOption{
+				WithFilter(func(d specs.Device) bool {
+					return d.Name == "gpu0-mig-config"
+				}),
+			},
+			spec: &specs.Spec{
+				Devices: []specs.Device{
+					{
+						Name: "gpu0",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"GPU=0"},
+						},
+					},
+					{
+						Name: "gpu0-mig-config",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"MIG_CONFIG=0"},
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Devices: []specs.Device{
+					{
+						Name: "all",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"GPU=0"},
+						},
+					},
+					{
+						Name: "gpu0",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"GPU=0"},
+						},
+					},
+					{
+						Name: "gpu0-mig-config",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"MIG_CONFIG=0"},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			m, err := NewMergedDevice()
+			m, err := NewMergedDevice(tc.options...)
 			require.NoError(t, err)
 
 			err = m.Transform(tc.spec)
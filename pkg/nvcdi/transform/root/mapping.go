@@ -0,0 +1,188 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform/noop"
+)
+
+// A Scope selects the paths in a CDI specification that a prefix rule is
+// applied to.
+type Scope string
+
+const (
+	// ScopeHostPath selects the host paths of device nodes and mounts.
+	ScopeHostPath = Scope("hostPath")
+	// ScopeContainerPath selects the container paths of device nodes and mounts.
+	ScopeContainerPath = Scope("containerPath")
+	// ScopeHookPath selects the paths of hook executables.
+	ScopeHookPath = Scope("hookPath")
+	// ScopeHookArgs selects the arguments of hooks. For arguments of the
+	// form <target>::<link> both paths are transformed.
+	ScopeHookArgs = Scope("hookArgs")
+)
+
+// A Rule replaces the prefix From with To for paths in the specified scopes.
+// If no scopes are specified, the rule applies to host paths only.
+type Rule struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Scopes []Scope `json:"scopes,omitempty"`
+}
+
+// A Mapping defines an ordered list of prefix rules. For each path, the first
+// rule whose prefix matches is applied and the remaining rules are ignored.
+type Mapping struct {
+	Rules []Rule `json:"rules"`
+}
+
+// mappingTransformer applies the rules of a mapping to a CDI specification.
+type mappingTransformer struct {
+	rules []Rule
+}
+
+var _ transform.Transformer = (*mappingTransformer)(nil)
+
+// LoadMapping loads a mapping from the specified YAML or JSON file.
+func LoadMapping(filename string) (*Mapping, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var m Mapping
+	if err := yaml.UnmarshalStrict(contents, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+	return &m, nil
+}
+
+// NewFromMapping creates a transformer that applies the rules of the specified
+// mapping. An error is returned if any of the rules is invalid.
+func NewFromMapping(m *Mapping) (transform.Transformer, error) {
+	if m == nil || len(m.Rules) == 0 {
+		return noop.New(), nil
+	}
+
+	var rules []Rule
+	for i, rule := range m.Rules {
+		if !filepath.IsAbs(rule.From) || !filepath.IsAbs(rule.To) {
+			return nil, fmt.Errorf("rule %d: from and to must be absolute paths", i)
+		}
+		if len(rule.Scopes) == 0 {
+			rule.Scopes = []Scope{ScopeHostPath}
+		}
+		for _, scope := range rule.Scopes {
+			switch scope {
+			case ScopeHostPath, ScopeContainerPath, ScopeHookPath, ScopeHookArgs:
+			default:
+				return nil, fmt.Errorf("rule %d: invalid scope %q", i, scope)
+			}
+		}
+		rule.From = filepath.Clean(rule.From)
+		rule.To = filepath.Clean(rule.To)
+		rules = append(rules, rule)
+	}
+
+	return &mappingTransformer{rules: rules}, nil
+}
+
+// Transform applies the mapping rules to all paths in the specified spec.
+func (t mappingTransformer) Transform(spec *specs.Spec) error {
+	if spec == nil {
+		return nil
+	}
+
+	for i := range spec.Devices {
+		t.applyToEdits(&spec.Devices[i].ContainerEdits)
+	}
+	t.applyToEdits(&spec.ContainerEdits)
+
+	return nil
+}
+
+func (t mappingTransformer) applyToEdits(edits *specs.ContainerEdits) {
+	for _, dn := range edits.DeviceNodes {
+		// The host path of a device node defaults to its container path. This
+		// is made explicit so that transforming the container path does not
+		// also change the host path.
+		if dn.HostPath == "" {
+			dn.HostPath = dn.Path
+		}
+		dn.HostPath = t.transformPath(ScopeHostPath, dn.HostPath)
+		dn.Path = t.transformPath(ScopeContainerPath, dn.Path)
+	}
+
+	for _, hook := range edits.Hooks {
+		hook.Path = t.transformPath(ScopeHookPath, hook.Path)
+		for i, arg := range hook.Args {
+			if !strings.Contains(arg, "::") {
+				hook.Args[i] = t.transformPath(ScopeHookArgs, arg)
+				continue
+			}
+			split := strings.SplitN(arg, "::", 2)
+			split[0] = t.transformPath(ScopeHookArgs, split[0])
+			split[1] = t.transformPath(ScopeHookArgs, split[1])
+			hook.Args[i] = strings.Join(split, "::")
+		}
+	}
+
+	for _, mount := range edits.Mounts {
+		mount.HostPath = t.transformPath(ScopeHostPath, mount.HostPath)
+		mount.ContainerPath = t.transformPath(ScopeContainerPath, mount.ContainerPath)
+	}
+}
+
+// transformPath applies the first rule in the specified scope whose prefix
+// matches the path. Prefixes only match on path component boundaries.
+func (t mappingTransformer) transformPath(scope Scope, path string) string {
+	for _, rule := range t.rules {
+		if !rule.appliesTo(scope) {
+			continue
+		}
+		if path == rule.From {
+			return rule.To
+		}
+		prefix := rule.From
+		if prefix != "/" {
+			prefix += "/"
+		}
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		return filepath.Join(rule.To, strings.TrimPrefix(path, prefix))
+	}
+	return path
+}
+
+func (r Rule) appliesTo(scope Scope) bool {
+	for _, s := range r.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,281 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestMappingTransformer(t *testing.T) {
+	testCases := []struct {
+		description   string
+		mapping       *Mapping
+		spec          *specs.Spec
+		expectedSpec  *specs.Spec
+		expectedError string
+	}{
+		{
+			description:  "nil mapping is a no-op",
+			mapping:      nil,
+			spec:         &specs.Spec{ContainerEdits: specs.ContainerEdits{Mounts: []*specs.Mount{{HostPath: "/root/lib.so"}}}},
+			expectedSpec: &specs.Spec{ContainerEdits: specs.ContainerEdits{Mounts: []*specs.Mount{{HostPath: "/root/lib.so"}}}},
+		},
+		{
+			description: "relative prefix is rejected",
+			mapping: &Mapping{
+				Rules: []Rule{{From: "root", To: "/"}},
+			},
+			expectedError: "rule 0: from and to must be absolute paths",
+		},
+		{
+			description: "invalid scope is rejected",
+			mapping: &Mapping{
+				Rules: []Rule{{From: "/root", To: "/", Scopes: []Scope{"env"}}},
+			},
+			expectedError: `rule 0: invalid scope "env"`,
+		},
+		{
+			description: "default scope is host paths",
+			mapping: &Mapping{
+				Rules: []Rule{{From: "/driver-root", To: "/"}},
+			},
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/driver-root/dev/nvidia0"},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "/driver-root/lib/libcuda.so.1", ContainerPath: "/driver-root/lib/libcuda.so.1"},
+					},
+					Hooks: []*specs.Hook{
+						{Path: "/driver-root/bin/hook", Args: []string{"hook", "/driver-root/lib"}},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{HostPath: "/dev/nvidia0", Path: "/driver-root/dev/nvidia0"},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "/lib/libcuda.so.1", ContainerPath: "/driver-root/lib/libcuda.so.1"},
+					},
+					Hooks: []*specs.Hook{
+						{Path: "/driver-root/bin/hook", Args: []string{"hook", "/driver-root/lib"}},
+					},
+				},
+			},
+		},
+		{
+			description: "first matching rule is applied",
+			mapping: &Mapping{
+				Rules: []Rule{
+					{From: "/run/nvidia/driver/dev", To: "/dev", Scopes: []Scope{ScopeHostPath, ScopeContainerPath}},
+					{From: "/run/nvidia/driver", To: "/driver", Scopes: []Scope{ScopeHostPath}},
+					{From: "/run/nvidia/driver", To: "/", Scopes: []Scope{ScopeContainerPath}},
+				},
+			},
+			spec: &specs.Spec{
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/run/nvidia/driver/dev/nvidia0"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/run/nvidia/driver/lib/libcuda.so.1", ContainerPath: "/run/nvidia/driver/lib/libcuda.so.1"},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{HostPath: "/dev/nvidia0", Path: "/dev/nvidia0"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/driver/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+					},
+				},
+			},
+		},
+		{
+			description: "container path scope does not change device node host paths",
+			mapping: &Mapping{
+				Rules: []Rule{{From: "/driver-root", To: "/", Scopes: []Scope{ScopeContainerPath}}},
+			},
+			spec: &specs.Spec{
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/driver-root/dev/nvidia0"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/driver-root/lib/libcuda.so.1", ContainerPath: "/driver-root/lib/libcuda.so.1"},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{HostPath: "/driver-root/dev/nvidia0", Path: "/dev/nvidia0"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/driver-root/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+					},
+				},
+			},
+		},
+		{
+			description: "prefixes match on path boundaries",
+			mapping: &Mapping{
+				Rules: []Rule{{From: "/usr", To: "/host/usr"}},
+			},
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/usr"},
+						{HostPath: "/usr/lib/libcuda.so.1"},
+						{HostPath: "/usrlocal/lib/libcuda.so.1"},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/host/usr"},
+						{HostPath: "/host/usr/lib/libcuda.so.1"},
+						{HostPath: "/usrlocal/lib/libcuda.so.1"},
+					},
+				},
+			},
+		},
+		{
+			description: "hook paths and args",
+			mapping: &Mapping{
+				Rules: []Rule{
+					{From: "/usr/local/nvidia/toolkit", To: "/opt/toolkit", Scopes: []Scope{ScopeHookPath}},
+					{From: "/driver-root", To: "/", Scopes: []Scope{ScopeHookArgs}},
+				},
+			},
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Hooks: []*specs.Hook{
+						{
+							HookName: "createContainer",
+							Path:     "/usr/local/nvidia/toolkit/nvidia-cdi-hook",
+							Args: []string{
+								"nvidia-cdi-hook", "create-symlinks",
+								"--link", "/driver-root/lib/libcuda.so.1::/driver-root/lib/libcuda.so",
+								"--folder", "/usr/local/nvidia/toolkit",
+							},
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Hooks: []*specs.Hook{
+						{
+							HookName: "createContainer",
+							Path:     "/opt/toolkit/nvidia-cdi-hook",
+							Args: []string{
+								"nvidia-cdi-hook", "create-symlinks",
+								"--link", "/lib/libcuda.so.1::/lib/libcuda.so",
+								"--folder", "/usr/local/nvidia/toolkit",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			transformer, err := NewFromMapping(tc.mapping)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			err = transformer.Transform(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "mapping.yaml")
+	contents := `
+rules:
+- from: /run/nvidia/driver
+  to: /
+  scopes: [hostPath, hookArgs]
+- from: /usr/local/nvidia/toolkit
+  to: /opt/toolkit
+  scopes: [hookPath]
+`
+	require.NoError(t, os.WriteFile(filename, []byte(contents), 0600))
+
+	m, err := LoadMapping(filename)
+	require.NoError(t, err)
+	require.EqualValues(t,
+		&Mapping{
+			Rules: []Rule{
+				{From: "/run/nvidia/driver", To: "/", Scopes: []Scope{ScopeHostPath, ScopeHookArgs}},
+				{From: "/usr/local/nvidia/toolkit", To: "/opt/toolkit", Scopes: []Scope{ScopeHookPath}},
+			},
+		},
+		m,
+	)
+
+	require.NoError(t, os.WriteFile(filename, []byte("rules:\n- source: /\n"), 0600))
+	_, err = LoadMapping(filename)
+	require.Error(t, err)
+}
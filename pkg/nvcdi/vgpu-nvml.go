@@ -0,0 +1,66 @@
+/**
+# Copyright (c) 2026, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"strconv"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+)
+
+const (
+	vgpuProfileAnnotation   = "nvidia.com/vgpu.profile"
+	vgpuMIGBackedAnnotation = "nvidia.com/vgpu.mig-backed"
+	vgpuProductAnnotation   = "nvidia.com/vgpu.product"
+)
+
+// newVGPULicensingDiscoverer returns a discoverer for the vGPU licensing files
+// if the system is a vGPU guest.
+// NVML is expected to be initialized.
+func (l *nvmllib) newVGPULicensingDiscoverer() discover.Discover {
+	isVGPUGuest, reason := info.IsVGPUGuest(l.devicelib)
+	l.logger.Debugf("Is vGPU guest? %v: %v", isVGPUGuest, reason)
+	if !isVGPUGuest {
+		return discover.None{}
+	}
+	return discover.NewVGPULicensingDiscoverer(l.logger, l.driverRoot)
+}
+
+// getVGPUAnnotations returns the annotations describing the vGPU profile of the
+// specified device. If the device is not a vGPU, no annotations are returned.
+func (l *nvmllib) getVGPUAnnotations(d device.Device) map[string]string {
+	vgpu, err := info.GetVGPU(d)
+	if err != nil {
+		l.logger.Debugf("Failed to get vGPU information: %v", err)
+		return nil
+	}
+	if vgpu == nil {
+		return nil
+	}
+
+	annotations := map[string]string{
+		vgpuProfileAnnotation:   vgpu.Profile,
+		vgpuMIGBackedAnnotation: strconv.FormatBool(vgpu.MIGBacked),
+	}
+	if vgpu.ProductName != "" {
+		annotations[vgpuProductAnnotation] = vgpu.ProductName
+	}
+	return annotations
+}
@@ -21,6 +21,8 @@ import (
 	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/tracing"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 )
@@ -32,6 +34,12 @@ type wrapper struct {
 	class  string
 
 	mergedDeviceOptions []transform.MergedDeviceOption
+
+	tracer *tracing.Tracer
+
+	// poststopHooks are added to the common edits and run once a container
+	// has stopped.
+	poststopHooks []discover.Hook
 }
 
 // GetSpec combines the device specs and common edits from the wrapped Interface to a single spec.Interface.
@@ -46,27 +54,59 @@ func (l *wrapper) GetSpec() (spec.Interface, error) {
 		return nil, err
 	}
 
+	mergedDeviceOptions := l.mergedDeviceOptions
+	if len(mergedDeviceOptions) > 0 {
+		mergedDeviceOptions = append([]transform.MergedDeviceOption{transform.WithFilter(IsExcludedFromAllDevice)}, mergedDeviceOptions...)
+	}
+
 	return spec.New(
 		spec.WithDeviceSpecs(deviceSpecs),
 		spec.WithEdits(*edits.ContainerEdits),
 		spec.WithVendor(l.vendor),
 		spec.WithClass(l.class),
-		spec.WithMergedDeviceOptions(l.mergedDeviceOptions...),
+		spec.WithMergedDeviceOptions(mergedDeviceOptions...),
 	)
 }
 
 // GetAllDeviceSpecs returns the device specs for all available devices.
 func (l *wrapper) GetAllDeviceSpecs() ([]specs.Device, error) {
-	return l.Interface.GetAllDeviceSpecs()
+	span := l.tracer.Start("nvcdi.GetAllDeviceSpecs")
+	defer span.End()
+
+	deviceSpecs, err := l.Interface.GetAllDeviceSpecs()
+	span.RecordError(err)
+	return deviceSpecs, err
+}
+
+// GetDeviceSpecsByID returns the device specs for the specified devices.
+func (l *wrapper) GetDeviceSpecsByID(ids ...string) ([]specs.Device, error) {
+	span := l.tracer.Start("nvcdi.GetDeviceSpecsByID")
+	defer span.End()
+
+	deviceSpecs, err := l.Interface.GetDeviceSpecsByID(ids...)
+	span.RecordError(err)
+	return deviceSpecs, err
 }
 
 // GetCommonEdits returns the wrapped edits and adds additional edits on top.
 func (m *wrapper) GetCommonEdits() (*cdi.ContainerEdits, error) {
+	span := m.tracer.Start("nvcdi.GetCommonEdits")
+	defer span.End()
+
 	edits, err := m.Interface.GetCommonEdits()
 	if err != nil {
+		span.RecordError(err)
 		return nil, err
 	}
 	edits.Env = append(edits.Env, image.EnvVarNvidiaVisibleDevices+"=void")
 
+	for _, hook := range m.poststopHooks {
+		edits.Hooks = append(edits.Hooks, &specs.Hook{
+			HookName: hook.Lifecycle,
+			Path:     hook.Path,
+			Args:     hook.Args,
+		})
+	}
+
 	return edits, nil
 }